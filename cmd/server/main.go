@@ -4,9 +4,11 @@ import (
 	"fmt"
 
 	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/handler"
 	"github.com/Dhar01/incident_resp/internal/database"
 	"github.com/Dhar01/incident_resp/internal/migrate"
 	"github.com/Dhar01/incident_resp/router"
+	"github.com/Dhar01/incident_resp/service"
 )
 
 func main() {
@@ -30,6 +32,19 @@ func main() {
 			return
 		}
 
+		// email reminders for overdue follow-up tasks
+		if config.IsTaskReminder() {
+			go service.StartTaskReminders()
+		}
+
+		// SLA at risk and breach alerts
+		go service.StartSLAChecker()
+
+		// missed heartbeats open incidents
+		go handler.StartHeartbeatChecker()
+
+		// synthetic uptime checks
+		go handler.StartCheckScheduler()
 	}
 
 	if config.IsRedis() {
@@ -15,6 +15,7 @@ import (
 	"github.com/pilinux/gorest/lib"
 	"github.com/pilinux/gorest/lib/middleware"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 )
 
@@ -32,6 +33,8 @@ type Configuration struct {
 	Logger    LoggerConfig
 	Server    ServerConfig
 	Security  SecurityConfig
+	Priority  PriorityConfig
+	Monitor   MonitorConfig
 	// ViewConfig ViewConfig
 }
 
@@ -70,7 +73,20 @@ func Config() (err error) {
 		return
 	}
 
-	configuration.Server = server()
+	configuration.Server, err = server()
+	if err != nil {
+		return
+	}
+
+	configuration.Priority, err = priority()
+	if err != nil {
+		return
+	}
+
+	configuration.Monitor, err = monitor()
+	if err != nil {
+		return
+	}
 
 	// configuration.ViewConfig, err = view()
 	// if err != nil {
@@ -273,6 +289,163 @@ func email() (emailConfig EmailConfig, err error) {
 		if err != nil {
 			return
 		}
+		taskReminderTemplateID := strings.TrimSpace(os.Getenv("EMAIL_TASK_REMINDER_TEMPLATE_ID"))
+		if taskReminderTemplateID != "" {
+			emailConfig.TaskReminderTemplateID, err = strconv.ParseInt(taskReminderTemplateID, 10, 64)
+			if err != nil {
+				return
+			}
+			emailConfig.TaskReminderTag = strings.TrimSpace(os.Getenv("EMAIL_TASK_REMINDER_TAG"))
+			emailConfig.TaskReminderInterval = 3600
+			taskReminderInterval := strings.TrimSpace(os.Getenv("EMAIL_TASK_REMINDER_INTERVAL"))
+			if taskReminderInterval != "" {
+				emailConfig.TaskReminderInterval, err = strconv.ParseUint(taskReminderInterval, 10, 32)
+				if err != nil {
+					return
+				}
+				if emailConfig.TaskReminderInterval == 0 {
+					err = errors.New("EMAIL_TASK_REMINDER_INTERVAL must be at least 1 second")
+					return
+				}
+			}
+		}
+		slaAlertTemplateID := strings.TrimSpace(os.Getenv("EMAIL_SLA_ALERT_TEMPLATE_ID"))
+		if slaAlertTemplateID != "" {
+			emailConfig.SLAAlertTemplateID, err = strconv.ParseInt(slaAlertTemplateID, 10, 64)
+			if err != nil {
+				return
+			}
+			emailConfig.SLAAlertTag = strings.TrimSpace(os.Getenv("EMAIL_SLA_ALERT_TAG"))
+		}
+		automationTemplateID := strings.TrimSpace(os.Getenv("EMAIL_AUTOMATION_TEMPLATE_ID"))
+		if automationTemplateID != "" {
+			emailConfig.AutomationTemplateID, err = strconv.ParseInt(automationTemplateID, 10, 64)
+			if err != nil {
+				return
+			}
+			emailConfig.AutomationTag = strings.TrimSpace(os.Getenv("EMAIL_AUTOMATION_TAG"))
+		}
+	}
+	return
+}
+
+// priority - impact and urgency matrix, SLA targets per priority
+// and the interval of the SLA checker
+func priority() (priorityConfig PriorityConfig, err error) {
+	matrix := strings.TrimSpace(os.Getenv("PRIORITY_MATRIX"))
+	if matrix == "" {
+		matrix = "P1,P2,P3;P2,P3,P4;P3,P4,P5"
+	}
+	rows := strings.Split(matrix, ";")
+	if len(rows) != 3 {
+		err = errors.New("PRIORITY_MATRIX needs 3 rows of 3 priorities")
+		return
+	}
+	for i, row := range rows {
+		cells := strings.Split(row, ",")
+		if len(cells) != 3 {
+			err = errors.New("PRIORITY_MATRIX needs 3 rows of 3 priorities")
+			return
+		}
+		for j, cell := range cells {
+			cell = strings.ToUpper(strings.TrimSpace(cell))
+			if len(cell) != 2 || cell[0] != 'P' || cell[1] < '1' || cell[1] > '5' {
+				err = errors.New("PRIORITY_MATRIX accepts P1 to P5 only")
+				return
+			}
+			priorityConfig.Matrix[i][j] = cell
+		}
+	}
+
+	priorityConfig.ResponseTargets, err = priorityTargets("PRIORITY_RESPONSE_TARGETS", "15m,30m,1h,4h,8h")
+	if err != nil {
+		return
+	}
+	priorityConfig.ResolutionTargets, err = priorityTargets("PRIORITY_RESOLUTION_TARGETS", "4h,8h,24h,72h,168h")
+	if err != nil {
+		return
+	}
+
+	priorityConfig.SLACheckInterval = 60
+	slaCheckInterval := strings.TrimSpace(os.Getenv("SLA_CHECK_INTERVAL"))
+	if slaCheckInterval != "" {
+		priorityConfig.SLACheckInterval, err = strconv.ParseUint(slaCheckInterval, 10, 32)
+		if err != nil {
+			return
+		}
+		if priorityConfig.SLACheckInterval == 0 {
+			err = errors.New("SLA_CHECK_INTERVAL must be at least 1 second")
+		}
+	}
+	return
+}
+
+// priorityTargets - comma separated durations of P1 to P5
+func priorityTargets(key, fallback string) (targets [5]time.Duration, err error) {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		value = fallback
+	}
+	durations := strings.Split(value, ",")
+	if len(durations) != 5 {
+		err = errors.New(key + " needs 5 durations, P1 to P5")
+		return
+	}
+	for i, d := range durations {
+		targets[i], err = time.ParseDuration(strings.TrimSpace(d))
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// monitor - heartbeat monitoring and synthetic checks
+func monitor() (monitorConfig MonitorConfig, err error) {
+	monitorConfig.HeartbeatCheckInterval = 30
+	heartbeatCheckInterval := strings.TrimSpace(os.Getenv("HEARTBEAT_CHECK_INTERVAL"))
+	if heartbeatCheckInterval != "" {
+		monitorConfig.HeartbeatCheckInterval, err = strconv.ParseUint(heartbeatCheckInterval, 10, 32)
+		if err != nil {
+			return
+		}
+		if monitorConfig.HeartbeatCheckInterval == 0 {
+			err = errors.New("HEARTBEAT_CHECK_INTERVAL must be at least 1 second")
+			return
+		}
+	}
+
+	monitorConfig.HeartbeatSeverity, err = monitorSeverity("HEARTBEAT_SEVERITY")
+	if err != nil {
+		return
+	}
+
+	monitorConfig.CheckWorkers = 4
+	checkWorkers := strings.TrimSpace(os.Getenv("CHECK_WORKERS"))
+	if checkWorkers != "" {
+		monitorConfig.CheckWorkers, err = strconv.ParseUint(checkWorkers, 10, 32)
+		if err != nil {
+			return
+		}
+		if monitorConfig.CheckWorkers == 0 {
+			err = errors.New("CHECK_WORKERS must be at least 1")
+			return
+		}
+	}
+
+	monitorConfig.CheckSeverity, err = monitorSeverity("CHECK_SEVERITY")
+	return
+}
+
+// monitorSeverity reads an incident severity, high by default
+func monitorSeverity(key string) (severity string, err error) {
+	severity = strings.ToLower(strings.TrimSpace(os.Getenv(key)))
+	switch severity {
+	case "":
+		severity = "high"
+	case "low", "medium", "high", "critical":
+	default:
+		err = errors.New(key + " must be low, medium, high or critical")
 	}
 	return
 }
@@ -320,6 +493,19 @@ func security() (securityConfig SecurityConfig, err error) {
 		setParamsJWT(securityConfig.JWT)
 	}
 
+	// Administrators, comma separated auth IDs
+	for _, id := range strings.Split(os.Getenv("ADMIN_AUTH_IDS"), ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+		authID, errThis := strconv.ParseUint(id, 10, 64)
+		if errThis != nil {
+			err = errThis
+			return
+		}
+		securityConfig.AdminAuthIDs = append(securityConfig.AdminAuthIDs, authID)
+	}
+
 	// When user logs off, invalidate the tokens
 	securityConfig.InvalidateJWT = strings.ToLower(strings.TrimSpace(os.Getenv("INVALIDATE_JWT")))
 
@@ -595,11 +781,19 @@ func security() (securityConfig SecurityConfig, err error) {
 }
 
 // server - port and env
-func server() (serverConfig ServerConfig) {
+func server() (serverConfig ServerConfig, err error) {
 	serverConfig.ServerHost = strings.TrimSpace(os.Getenv("APP_HOST"))
 	serverConfig.ServerPort = strings.TrimSpace(os.Getenv("APP_PORT"))
 	serverConfig.ServerEnv = strings.ToLower(strings.TrimSpace(os.Getenv("APP_ENV")))
 
+	serverConfig.StixNamespace = strings.TrimSpace(os.Getenv("STIX_NAMESPACE"))
+	if serverConfig.StixNamespace != "" {
+		if _, err = uuid.Parse(serverConfig.StixNamespace); err != nil {
+			err = errors.New("STIX_NAMESPACE must be a UUID")
+			return
+		}
+	}
+
 	return
 }
 
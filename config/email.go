@@ -23,4 +23,17 @@ type EmailConfig struct {
 	HTMLModel                   string
 	EmailVerifyValidityPeriod   uint64 // in seconds
 	PassRecoverValidityPeriod   uint64 // in seconds
+
+	// overdue task reminders, disabled when no template is set
+	TaskReminderTemplateID int64
+	TaskReminderTag        string
+	TaskReminderInterval   uint64 // in seconds
+
+	// SLA at risk and breach alerts, disabled when no template is set
+	SLAAlertTemplateID int64
+	SLAAlertTag        string
+
+	// notify actions of automation rules, disabled when no template is set
+	AutomationTemplateID int64
+	AutomationTag        string
 }
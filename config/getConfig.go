@@ -105,6 +105,34 @@ func IsMongo() bool {
 // 	return GetConfig().Security.RecoverPass
 // }
 
+// IsAdmin returns true when the user is listed in ADMIN_AUTH_IDS in .env
+func IsAdmin(authID uint64) bool {
+	for _, id := range GetConfig().Security.AdminAuthIDs {
+		if id == authID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTaskReminder returns true when overdue task reminders are enabled in .env
+func IsTaskReminder() bool {
+	return GetConfig().EmailConf.Activate == Activated &&
+		GetConfig().EmailConf.TaskReminderTemplateID != 0
+}
+
+// IsSLAAlert returns true when SLA alert emails are enabled in .env
+func IsSLAAlert() bool {
+	return GetConfig().EmailConf.Activate == Activated &&
+		GetConfig().EmailConf.SLAAlertTemplateID != 0
+}
+
+// IsAutomationNotice returns true when automation rules may send emails
+func IsAutomationNotice() bool {
+	return GetConfig().EmailConf.Activate == Activated &&
+		GetConfig().EmailConf.AutomationTemplateID != 0
+}
+
 // IsEmailVerificationCodeUUIDv4 returns true when it is enabled in .env
 func IsEmailVerificationCodeUUIDv4() bool {
 	return GetConfig().EmailConf.EmailVerificationCodeUUIDv4
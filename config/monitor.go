@@ -0,0 +1,10 @@
+package config
+
+// MonitorConfig ...
+type MonitorConfig struct {
+	HeartbeatCheckInterval uint64 // in seconds
+	HeartbeatSeverity      string // of the incident when a heartbeat sets none
+
+	CheckWorkers  uint64 // synthetic checks run concurrently at most
+	CheckSeverity string // of the incident when a check sets none
+}
@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// PriorityConfig ...
+type PriorityConfig struct {
+	// Matrix[impact][urgency], both ordered high, medium, low
+	Matrix [3][3]string
+
+	// targets of P1 to P5
+	ResponseTargets   [5]time.Duration
+	ResolutionTargets [5]time.Duration
+
+	SLACheckInterval uint64 // in seconds
+}
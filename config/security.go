@@ -21,6 +21,9 @@ type SecurityConfig struct {
 	MustJWT string
 	JWT     middleware.JWTParameters
 
+	// users allowed to manage instance-wide settings
+	AdminAuthIDs []uint64
+
 	InvalidateJWT string // when user logs off, invalidate the tokens
 
 	AuthCookieActivate bool
@@ -5,4 +5,6 @@ type ServerConfig struct {
 	ServerHost string
 	ServerPort string // public port of server
 	ServerEnv  string
+
+	StixNamespace string // UUID, the IDs of STIX exports are derived from it
 }
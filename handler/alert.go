@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IngestAlert opens an incident for a monitoring alert unless a
+// maintenance window covers its services, which suppresses the alert
+// or downgrades the incident to low severity. Every alert is recorded,
+// in the same transaction as the incident it opens.
+func IngestAlert(req model.AlertReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	alert, err := ingestAlert(req, authID)
+	if err != nil {
+		var rejected *alertError
+		if errors.As(err, &rejected) {
+			return rejected.httpResponse, rejected.httpStatusCode
+		}
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = alert
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// alertError - alert which was not recorded, with the response the
+// API answers
+type alertError struct {
+	httpResponse   model.HTTPResponse
+	httpStatusCode int
+}
+
+func (e *alertError) Error() string {
+	return fmt.Sprint(e.httpResponse.Message)
+}
+
+// rejectAlert prepares the error of an alert which was not recorded
+func rejectAlert(message string, statusCode int) error {
+	return &alertError{model.HTTPResponse{Message: message}, statusCode}
+}
+
+// ingestAlert records an alert for IngestAlert, heartbeats and checks.
+// Requests which cannot be recorded fail with an *alertError.
+func ingestAlert(req model.AlertReq, authID uint64) (model.Alert, error) {
+	db := database.GetDB()
+
+	if msg := validateAlertReq(&req); msg != "" {
+		return model.Alert{}, rejectAlert(msg, http.StatusBadRequest)
+	}
+
+	if _, err := findServices(req.ServiceIDs); err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 4701.1")
+			return model.Alert{}, err
+		}
+		return model.Alert{}, rejectAlert("service not found", http.StatusNotFound)
+	}
+
+	action, windowID, until, err := activeMaintenance(db, req.ServiceIDs, time.Now())
+	if err != nil {
+		log.WithError(err).Error("error code: 4701.2")
+		return model.Alert{}, err
+	}
+
+	alert := model.Alert{
+		AuthID:      authID,
+		Source:      req.Source,
+		Title:       req.Title,
+		Description: req.Description,
+		Severity:    req.Severity,
+		ServiceIDs:  req.ServiceIDs,
+		Outcome:     model.AlertIncidentCreated,
+		WindowID:    windowID,
+	}
+
+	if action == model.MaintenanceSuppress {
+		alert.Outcome = model.AlertSuppressed
+		alert.SuppressedUntil = &until
+		if err := db.Create(&alert).Error; err != nil {
+			log.WithError(err).Error("error code: 4701.3")
+			return model.Alert{}, err
+		}
+		return alert, nil
+	}
+
+	incidentReq := model.IncidentReq{
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      model.Open,
+		Severity:    req.Severity,
+		AssignedTo:  req.AssignedTo,
+		ServiceIDs:  req.ServiceIDs,
+	}
+	if incidentReq.AssignedTo == 0 {
+		incidentReq.AssignedTo = authID
+	}
+	if action == model.MaintenanceDowngrade {
+		alert.Outcome = model.AlertDowngraded
+		incidentReq.Severity = model.Low
+	}
+
+	// nobody is there to fill required custom fields
+	incident, resp, code, ok := buildIncident(incidentReq, authID, false)
+	if !ok {
+		return model.Alert{}, &alertError{resp, code}
+	}
+
+	// the incident and its alert are stored together, a monitor which
+	// retries a failed alert must not open a second incident
+	tx := db.Begin()
+	if resp, code, ok := insertIncident(tx, &incident, authID); !ok {
+		tx.Rollback()
+		return model.Alert{}, &alertError{resp, code}
+	}
+	alert.IncidentID = &incident.IncidentID
+
+	if req.Severity != incidentReq.Severity {
+		message := "severity downgraded from " + string(req.Severity) + " to " + string(incidentReq.Severity) +
+			" during maintenance window " + strconv.FormatUint(*windowID, 10)
+		if err := recordEvent(tx, incident.IncidentID, 0, model.EventSeverityChanged, message); err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 4701.4")
+			return model.Alert{}, err
+		}
+	}
+	if err := tx.Create(&alert).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4701.5")
+		return model.Alert{}, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4701.6")
+		return model.Alert{}, err
+	}
+
+	incidentCreated(&incident, authID)
+
+	return alert, nil
+}
+
+// GetAlerts lists a page of the received alerts, newest first. The
+// next page starts before the last alert of this one.
+func GetAlerts(filter model.AlertFilter) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if filter.Outcome != "" && !filter.Outcome.Valid() {
+		return setErrorMessage("outcome must be created, downgraded or suppressed", http.StatusBadRequest)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = model.AlertsPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > model.AlertsPageMax {
+		return setErrorMessage("limit must be between 1 and "+strconv.Itoa(model.AlertsPageMax), http.StatusBadRequest)
+	}
+
+	query := db.Model(&model.Alert{})
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+	if filter.WindowID != 0 {
+		query = query.Where("window_id = ?", filter.WindowID)
+	}
+	if filter.Before != 0 {
+		query = query.Where("alert_id < ?", filter.Before)
+	}
+
+	alerts := []model.Alert{}
+
+	if err := query.Order("alert_id DESC").Limit(filter.Limit).Find(&alerts).Error; err != nil {
+		log.WithError(err).Error("error code: 4702.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = alerts
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func validateAlertReq(req *model.AlertReq) string {
+	req.Source = strings.TrimSpace(req.Source)
+	req.Title = strings.TrimSpace(req.Title)
+
+	if req.Title == "" {
+		return "title is required"
+	}
+	if len(req.Source) > 64 {
+		return "source must be at most 64 characters"
+	}
+	if req.Severity == "" {
+		req.Severity = model.Medium
+	}
+	if !req.Severity.Valid() {
+		return "severity must be low, medium, high or critical"
+	}
+	if req.ServiceIDs == nil {
+		req.ServiceIDs = []uint64{}
+	}
+
+	return ""
+}
+
+// resolveMonitorIncident closes the incident a monitor opened once it
+// recovered, unless someone closed it already
+func resolveMonitorIncident(tx *gorm.DB, incidentID uint64, reason string, now time.Time) error {
+	var incident model.Incident
+
+	if err := tx.First(&incident, incidentID).Error; err != nil {
+		if err.Error() == database.RecordNotFound {
+			return nil
+		}
+		return err
+	}
+	if incident.Status == model.Closed {
+		return nil
+	}
+
+	previousStatus := incident.Status
+	incident.Status = model.Closed
+	incident.TrackStatus(now)
+
+	err := tx.Model(&model.Incident{IncidentID: incidentID}).UpdateColumns(map[string]any{
+		"status":          incident.Status,
+		"acknowledged_at": incident.AcknowledgedAt,
+		"resolved_at":     incident.ResolvedAt,
+		"updated_at":      now,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	message := "status changed from " + string(previousStatus) + " to " + string(incident.Status) +
+		", " + reason
+	return recordEvent(tx, incidentID, 0, model.EventStatusChanged, message)
+}
+
+// monitorTargetsExist checks that the assignee and the services of the
+// incidents a monitor opens exist
+func monitorTargetsExist(assignedTo uint64, serviceIDs []uint64, errCode string) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Auth{}, assignedTo).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("assigned user not found", http.StatusNotFound)
+		return
+	}
+
+	if _, err := findServices(serviceIDs); err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("service not found", http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
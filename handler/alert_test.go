@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+func TestIngestAlertRequiredCustomField(t *testing.T) {
+	setupTestDB(t)
+	db := database.GetDB()
+
+	auth := model.Auth{EmailHash: "alert", Password: "x"}
+	if err := db.Create(&auth).Error; err != nil {
+		t.Fatal(err)
+	}
+	field := model.CustomField{Key: "customer", Label: "Customer", Type: model.FieldText, Required: true}
+	if err := db.Create(&field).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// people still have to fill it
+	_, code := CreateIncident(model.IncidentReq{
+		Title:      "database down",
+		Status:     model.Open,
+		Severity:   model.High,
+		AssignedTo: auth.AuthID,
+	}, auth.AuthID)
+	if code != http.StatusBadRequest {
+		t.Errorf("create incident without the required field = %d, want %d", code, http.StatusBadRequest)
+	}
+
+	resp, code := IngestAlert(model.AlertReq{Source: "monitoring", Title: "database down"}, auth.AuthID)
+	if code != http.StatusCreated {
+		t.Fatalf("ingest alert = %d %v, want %d", code, resp.Message, http.StatusCreated)
+	}
+	alert := resp.Message.(model.Alert)
+	if alert.IncidentID == nil {
+		t.Fatal("alert opened no incident")
+	}
+
+	var incident model.Incident
+	if err := db.First(&incident, *alert.IncidentID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := incident.CustomFields[field.Key]; ok {
+		t.Errorf("custom field %s = %v, want it empty", field.Key, incident.CustomFields[field.Key])
+	}
+	if alerts := countTestAlerts(t, model.AlertIncidentCreated); alerts != 1 {
+		t.Errorf("alerts which opened an incident = %d, want 1", alerts)
+	}
+}
+
+// setupTestDB points the handlers at an empty SQLite database
+func setupTestDB(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	for key, value := range map[string]string{
+		"ACTIVATE_RDBMS":    "yes",
+		"DBDRIVER":          "sqlite3",
+		"DBNAME":            filepath.Join(dir, "test.db"),
+		"DBMAXIDLECONNS":    "1",
+		"DBMAXOPENCONNS":    "1",
+		"DBCONNMAXLIFETIME": "1h",
+		"DBLOGLEVEL":        "1",
+	} {
+		t.Setenv(key, value)
+	}
+	// the configuration is read from the environment, the file must exist
+	if err := os.WriteFile(filepath.Join(dir, ".env"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	if err := config.Config(); err != nil {
+		t.Fatal(err)
+	}
+	db := database.InitDB()
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	err := db.AutoMigrate(
+		&model.Auth{}, &model.Service{}, &model.Team{}, &model.TeamMember{},
+		&model.Incident{}, &model.IncidentParticipant{}, &model.IncidentEvent{}, &model.Tag{},
+		&model.CustomField{}, &model.SLAPolicy{}, &model.MaintenanceWindow{}, &model.Alert{},
+		&model.Check{}, &model.CheckResult{}, &model.AutomationRule{}, &model.AutomationRun{},
+		&model.Playbook{}, &model.IncidentPlaybook{}, &model.IncidentPlaybookStep{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// countTestAlerts counts the stored alerts of an outcome, all when empty
+func countTestAlerts(t *testing.T, outcome model.AlertOutcome) int64 {
+	t.Helper()
+
+	query := database.GetDB().Model(&model.Alert{})
+	if outcome != "" {
+		query = query.Where("outcome = ?", outcome)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetAttackTactics lists the tactics of the ATT&CK matrix in matrix order
+func GetAttackTactics() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	httpResponse.Message = service.Attack().Tactics
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetAttackTechniques lists the techniques of the ATT&CK matrix, of one
+// tactic when given, whose ID or name contains the query
+func GetAttackTechniques(tactic, query string) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	tacticID := ""
+	if tactic != "" {
+		found, ok := service.AttackTactic(tactic)
+		if !ok {
+			return setErrorMessage("'"+tactic+"' is not an ATT&CK tactic", http.StatusBadRequest)
+		}
+		tacticID = found.ID
+	}
+
+	httpResponse.Message = service.SearchAttackTechniques(tacticID, query)
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetIncidentTechniques lists the ATT&CK techniques of an incident
+func GetIncidentTechniques(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Incident{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5301.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	techniques := []model.IncidentTechnique{}
+
+	if err := db.Where("incident_id = ?", id).Order("id").Find(&techniques).Error; err != nil {
+		log.WithError(err).Error("error code: 5301.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	for i := range techniques {
+		fillTechnique(&techniques[i])
+	}
+
+	httpResponse.Message = techniques
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// AddIncidentTechnique tags an incident with an ATT&CK technique. The
+// tactic it served is implied when the technique has only one.
+func AddIncidentTechnique(id uint64, req model.IncidentTechniqueReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	technique, ok := service.AttackTechnique(req.TechniqueID)
+	if !ok {
+		return setErrorMessage("'"+req.TechniqueID+"' is not an ATT&CK enterprise technique", http.StatusBadRequest)
+	}
+
+	tacticID := ""
+	if req.TacticID != "" {
+		tactic, ok := service.AttackTactic(req.TacticID)
+		if !ok {
+			return setErrorMessage("'"+req.TacticID+"' is not an ATT&CK tactic", http.StatusBadRequest)
+		}
+		if !slices.Contains(technique.Tactics, tactic.ID) {
+			return setErrorMessage(technique.ID+" is not a technique of the "+tactic.Name+" tactic", http.StatusBadRequest)
+		}
+		tacticID = tactic.ID
+	} else if len(technique.Tactics) == 1 {
+		tacticID = technique.Tactics[0]
+	}
+
+	incident, resp, code, ok := editableIncident(id, authID, "5302.1")
+	if !ok {
+		return resp, code
+	}
+
+	var count int64
+	if err := db.Model(&model.IncidentTechnique{}).Where("incident_id = ? AND technique_id = ?", incident.IncidentID, technique.ID).Count(&count).Error; err != nil {
+		log.WithError(err).Error("error code: 5302.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if count > 0 {
+		return setErrorMessage("technique already tagged on the incident", http.StatusConflict)
+	}
+
+	tagged := model.IncidentTechnique{
+		IncidentID:  incident.IncidentID,
+		TechniqueID: technique.ID,
+		TacticID:    tacticID,
+		AuthID:      authID,
+		Note:        strings.TrimSpace(req.Note),
+	}
+
+	tx := db.Begin()
+	if err := tx.Create(&tagged).Error; err != nil {
+		tx.Rollback()
+		// tagged at the same time by another request
+		if duplicateKey(db, err) {
+			return setErrorMessage("technique already tagged on the incident", http.StatusConflict)
+		}
+		log.WithError(err).Error("error code: 5302.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	message := "technique tagged: " + techniqueName(technique)
+	if err := recordEvent(tx, incident.IncidentID, authID, model.EventTechniqueTagged, message); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5302.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 5302.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	fillTechnique(&tagged)
+
+	httpResponse.Message = tagged
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// RemoveIncidentTechnique removes an ATT&CK technique from an incident
+func RemoveIncidentTechnique(id uint64, techniqueID string, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	incident, resp, code, ok := editableIncident(id, authID, "5303.1")
+	if !ok {
+		return resp, code
+	}
+
+	var tagged model.IncidentTechnique
+
+	techniqueID = strings.ToUpper(strings.TrimSpace(techniqueID))
+	if err := db.Where("incident_id = ? AND technique_id = ?", incident.IncidentID, techniqueID).First(&tagged).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5303.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("technique not found on the incident", http.StatusNotFound)
+	}
+
+	tx := db.Begin()
+	if err := tx.Delete(&tagged).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5303.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	technique, _ := service.AttackTechnique(tagged.TechniqueID)
+	technique.ID = tagged.TechniqueID
+	message := "technique removed: " + techniqueName(technique)
+	if err := recordEvent(tx, incident.IncidentID, authID, model.EventTechniqueUntagged, message); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5303.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 5303.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "technique removed"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetTechniqueReport counts the incidents created in a date range each
+// ATT&CK technique and tactic was seen in
+func GetTechniqueReport(filter model.MetricsFilter) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.AddDate(0, 0, -model.MetricsDefaultDays)
+	}
+	if !filter.From.Before(filter.To) {
+		return setErrorMessage("from must be before to", http.StatusBadRequest)
+	}
+	if filter.To.Sub(filter.From) > model.MetricsMaxDays*24*time.Hour {
+		return setErrorMessage(fmt.Sprintf("date range must not exceed %d days", model.MetricsMaxDays), http.StatusBadRequest)
+	}
+
+	rows := []struct {
+		IncidentID  uint64
+		TechniqueID string
+		TacticID    string
+		CreatedAt   time.Time
+	}{}
+
+	err := db.Model(&model.Incident{}).
+		Select("incidents.incident_id, incident_techniques.technique_id, incident_techniques.tactic_id, incidents.created_at").
+		Joins("JOIN incident_techniques ON incident_techniques.incident_id = incidents.incident_id").
+		Scopes(metricsScope(filter)).
+		Scan(&rows).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 5304.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	report := model.TechniqueReport{
+		From:       filter.From,
+		To:         filter.To,
+		Techniques: []model.TechniqueCount{},
+		Tactics:    []model.TacticCount{},
+	}
+
+	incidents := map[uint64]bool{}
+	techniques := map[string]*model.TechniqueCount{}
+	tactics := map[string]map[uint64]bool{}
+	for _, row := range rows {
+		incidents[row.IncidentID] = true
+
+		technique, _ := service.AttackTechnique(row.TechniqueID)
+		count, ok := techniques[row.TechniqueID]
+		if !ok {
+			count = &model.TechniqueCount{TechniqueID: row.TechniqueID, Name: technique.Name, Tactics: technique.Tactics}
+			techniques[row.TechniqueID] = count
+		}
+		count.Count++
+		if row.CreatedAt.After(count.LastSeenAt) {
+			count.LastSeenAt = row.CreatedAt
+		}
+
+		served := technique.Tactics
+		if row.TacticID != "" {
+			served = []string{row.TacticID}
+		}
+		for _, tacticID := range served {
+			if tactics[tacticID] == nil {
+				tactics[tacticID] = map[uint64]bool{}
+			}
+			tactics[tacticID][row.IncidentID] = true
+		}
+	}
+	report.Total = int64(len(incidents))
+
+	for _, count := range techniques {
+		report.Techniques = append(report.Techniques, *count)
+	}
+	slices.SortFunc(report.Techniques, func(a, b model.TechniqueCount) int {
+		if a.Count != b.Count {
+			return int(b.Count - a.Count)
+		}
+		return strings.Compare(a.TechniqueID, b.TechniqueID)
+	})
+
+	for _, tactic := range service.Attack().Tactics {
+		report.Tactics = append(report.Tactics, model.TacticCount{TacticID: tactic.ID, Name: tactic.Name, Count: int64(len(tactics[tactic.ID]))})
+	}
+
+	httpResponse.Message = report
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// fillTechnique sets the name and tactics of a tagged technique from the
+// matrix
+func fillTechnique(tagged *model.IncidentTechnique) {
+	technique, _ := service.AttackTechnique(tagged.TechniqueID)
+	tagged.Name = technique.Name
+	tagged.Tactics = technique.Tactics
+}
+
+func techniqueName(technique model.AttackTechnique) string {
+	if technique.Name == "" {
+		return technique.ID
+	}
+	return technique.ID + " " + technique.Name
+}
@@ -174,3 +174,15 @@ func setErrorMessage(message string, statusCode int) (httpResponse model.HTTPRes
 	httpStatusCode = statusCode
 	return
 }
+
+// requireAdmin prepares a 403 response unless the user is listed in
+// ADMIN_AUTH_IDS; ok is true when the user may proceed
+func requireAdmin(authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	if !config.IsAdmin(authID) {
+		httpResponse, httpStatusCode = setErrorMessage("administrator required", http.StatusForbidden)
+		return
+	}
+
+	ok = true
+	return
+}
@@ -0,0 +1,722 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetAutomationRules lists the rules in the order they run
+func GetAutomationRules() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	rules := []model.AutomationRule{}
+
+	if err := db.Order("position").Order("rule_id").Find(&rules).Error; err != nil {
+		log.WithError(err).Error("error code: 5001.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = rules
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func GetAutomationRule(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	rule, resp, code, ok := findAutomationRule(id, "5002.1")
+	if !ok {
+		return resp, code
+	}
+
+	httpResponse.Message = rule
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// CreateAutomationRule adds a rule, only administrators can as rules
+// reassign incidents and call webhooks
+func CreateAutomationRule(req model.AutomationRuleReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if resp, code, ok := validateAutomationRuleReq(&req, "5003.1"); !ok {
+		return resp, code
+	}
+
+	rule := model.AutomationRule{AuthID: authID}
+	applyAutomationRuleReq(&rule, req)
+
+	if err := db.Create(&rule).Error; err != nil {
+		log.WithError(err).Error("error code: 5003.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = rule
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+func UpdateAutomationRule(id uint64, req model.AutomationRuleReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	rule, resp, code, ok := findAutomationRule(id, "5004.1")
+	if !ok {
+		return resp, code
+	}
+
+	if resp, code, ok := validateAutomationRuleReq(&req, "5004.2"); !ok {
+		return resp, code
+	}
+
+	applyAutomationRuleReq(&rule, req)
+
+	if err := db.Save(&rule).Error; err != nil {
+		log.WithError(err).Error("error code: 5004.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = rule
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DeleteAutomationRule removes a rule, its runs stay in the audit trail
+func DeleteAutomationRule(id, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	result := db.Delete(&model.AutomationRule{}, id)
+	if result.Error != nil {
+		log.WithError(result.Error).Error("error code: 5005.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected == 0 {
+		return setErrorMessage("automation rule not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = "automation rule deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetAutomationRuns lists the latest rules which fired, newest first
+func GetAutomationRuns(filter model.AutomationRunFilter) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	query := db.Model(&model.AutomationRun{})
+	if filter.RuleID != 0 {
+		query = query.Where("rule_id = ?", filter.RuleID)
+	}
+	if filter.IncidentID != 0 {
+		query = query.Where("incident_id = ?", filter.IncidentID)
+	}
+
+	runs := []model.AutomationRun{}
+
+	if err := query.Order("run_id DESC").Limit(model.AutomationRunsLimit).Find(&runs).Error; err != nil {
+		log.WithError(err).Error("error code: 5006.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = runs
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DryRunAutomation reports what the enabled rules, or the given draft
+// rule, would do to an incident without changing or sending anything.
+// Only administrators can, as for changing the rules.
+func DryRunAutomation(req model.AutomationDryRunReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if req.Event == "" {
+		req.Event = model.AutomationUpdated
+	}
+	if !req.Event.Valid() {
+		return setErrorMessage("event must be created or updated", http.StatusBadRequest)
+	}
+
+	var rules []model.AutomationRule
+	if req.Rule != nil {
+		if resp, code, ok := validateAutomationRuleReq(req.Rule, "5007.1"); !ok {
+			return resp, code
+		}
+		rule := model.AutomationRule{}
+		applyAutomationRuleReq(&rule, *req.Rule)
+		rule.Disabled = false
+		rule.Events = []model.AutomationEvent{req.Event}
+		rules = []model.AutomationRule{rule}
+	} else {
+		var err error
+		if rules, err = automationRules(db, req.Event); err != nil {
+			log.WithError(err).Error("error code: 5007.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+
+	var incident model.Incident
+
+	if err := db.Preload("Services").Preload("Tags").First(&incident, req.IncidentID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5007.3")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	firings, err := planAutomation(db, &incident, rules)
+	if err != nil {
+		log.WithError(err).Error("error code: 5007.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	dryRun := model.AutomationDryRun{
+		IncidentID: incident.IncidentID,
+		Event:      req.Event,
+		Rules:      []model.AutomationRuleResult{},
+	}
+	for _, firing := range firings {
+		result := model.AutomationRuleResult{
+			RuleID:  firing.rule.RuleID,
+			Name:    firing.rule.Name,
+			Matched: firing.matched,
+		}
+		for _, step := range firing.steps {
+			result.Actions = append(result.Actions, step.result)
+		}
+		dryRun.Rules = append(dryRun.Rules, result)
+	}
+
+	httpResponse.Message = dryRun
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// automationFiring - outcome of one rule on an incident
+type automationFiring struct {
+	rule    model.AutomationRule
+	matched bool
+	steps   []automationStep
+}
+
+// automationStep - planned outcome of one action
+type automationStep struct {
+	result model.AutomationActionResult
+
+	// changes of the incident
+	event   model.EventType // timeline entry, none when nothing changed
+	columns []string
+	tag     *model.Tag // added to the incident
+
+	// notifications, sent once the changes are saved
+	notify  uint64 // recipient of an email
+	webhook string
+}
+
+// automateIncident runs the enabled rules for event on the incident and
+// applies the actions of those which match. The incident was already
+// saved, so failures are only logged. The incident is updated in place,
+// emails and webhooks are sent in the background.
+func automateIncident(incident *model.Incident, event model.AutomationEvent, authID uint64) {
+	db := database.GetDB()
+
+	rules, err := automationRules(db, event)
+	if err != nil {
+		log.WithError(err).Error("error code: 5008.1")
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	var current model.Incident
+
+	if err := db.Preload("Services").Preload("Tags").First(&current, incident.IncidentID).Error; err != nil {
+		log.WithError(err).Error("error code: 5008.2")
+		return
+	}
+
+	firings, err := planAutomation(db, &current, rules)
+	if err != nil {
+		log.WithError(err).Error("error code: 5008.3")
+		return
+	}
+
+	now := time.Now()
+	columns := []string{}
+	tagged := false
+	for _, firing := range firings {
+		for _, step := range firing.steps {
+			for _, column := range step.columns {
+				if !slices.Contains(columns, column) {
+					columns = append(columns, column)
+				}
+			}
+			tagged = tagged || step.tag != nil
+		}
+	}
+
+	// the severity picks the SLA policy
+	if slices.Contains(columns, "severity") {
+		policies, err := slaPolicies(db)
+		if err != nil {
+			log.WithError(err).Error("error code: 5008.4")
+			return
+		}
+		if _, err := applySLA(&current, policies); err != nil {
+			log.WithError(err).Error("error code: 5008.5")
+			return
+		}
+		columns = append(columns, "sla_policy_id", "acknowledge_due_at", "resolve_due_at", "sla_alerts")
+	}
+
+	tx := db.Begin()
+	for _, firing := range firings {
+		for _, step := range firing.steps {
+			if step.tag == nil {
+				continue
+			}
+			tags := []model.Tag{*step.tag}
+			if err := saveTags(tx, tags); err != nil {
+				tx.Rollback()
+				log.WithError(err).Error("error code: 5008.6")
+				return
+			}
+			if err := tx.Model(&model.Incident{IncidentID: current.IncidentID}).Association("Tags").Append(&tags[0]); err != nil {
+				tx.Rollback()
+				log.WithError(err).Error("error code: 5008.7")
+				return
+			}
+		}
+	}
+	if len(columns) > 0 || tagged {
+		current.UpdatedAt = now
+		columns = append(columns, "updated_at")
+		if err := tx.Model(&current).Select(columns).Updates(&current).Error; err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 5008.8")
+			return
+		}
+	}
+	for _, firing := range firings {
+		for _, step := range firing.steps {
+			if step.event == "" {
+				continue
+			}
+			message := step.result.Message + " by rule '" + firing.rule.Name + "'"
+			if err := recordEvent(tx, current.IncidentID, 0, step.event, message); err != nil {
+				tx.Rollback()
+				log.WithError(err).Error("error code: 5008.9")
+				return
+			}
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 5008.14")
+		return
+	}
+
+	if tagged {
+		if current.Tags, err = incidentTags(db, current.IncidentID); err != nil {
+			log.WithError(err).Error("error code: 5008.13")
+		}
+	}
+
+	// emails and webhooks may take seconds, they must not hold up
+	// the request or the monitors which changed the incident
+	go recordAutomationRuns(firings, current, event, authID)
+
+	incident.AssignedTo = current.AssignedTo
+	incident.Severity = current.Severity
+	incident.SLAPolicyID = current.SLAPolicyID
+	incident.AcknowledgeDueAt = current.AcknowledgeDueAt
+	incident.ResolveDueAt = current.ResolveDueAt
+	incident.SLAAlerts = current.SLAAlerts
+	incident.UpdatedAt = current.UpdatedAt
+	if tagged {
+		incident.Tags = current.Tags
+	}
+}
+
+// recordAutomationRuns sends the emails and calls the webhooks of the
+// matching rules, then records their runs with the outcome of every
+// action
+func recordAutomationRuns(firings []automationFiring, incident model.Incident, event model.AutomationEvent, authID uint64) {
+	db := database.GetDB()
+
+	for _, firing := range firings {
+		if !firing.matched {
+			continue
+		}
+
+		run := model.AutomationRun{
+			RuleID:     firing.rule.RuleID,
+			RuleName:   firing.rule.Name,
+			IncidentID: incident.IncidentID,
+			Event:      event,
+			AuthID:     authID,
+			Actions:    []model.AutomationActionResult{},
+		}
+		for _, step := range firing.steps {
+			sendAutomationStep(&step, firing.rule, incident, event)
+			run.Actions = append(run.Actions, step.result)
+		}
+
+		if err := db.Create(&run).Error; err != nil {
+			log.WithError(err).Error("error code: 5008.10")
+		}
+	}
+}
+
+// sendAutomationStep sends the email or calls the webhook of a step
+func sendAutomationStep(step *automationStep, rule model.AutomationRule, incident model.Incident, event model.AutomationEvent) {
+	switch {
+	case step.notify != 0:
+		sent, err := service.NotifyAutomation(step.notify, incident, rule.Name)
+		if err != nil {
+			log.WithError(err).Error("error code: 5008.11")
+			step.result.Error = err.Error()
+		} else if !sent {
+			step.result.Error = "automation emails are not configured"
+		}
+
+	case step.webhook != "":
+		payload := model.AutomationWebhookPayload{
+			Event:    event,
+			RuleID:   rule.RuleID,
+			RuleName: rule.Name,
+			Incident: incident,
+		}
+		if err := service.PostAutomationWebhook(step.webhook, payload); err != nil {
+			log.WithError(err).Error("error code: 5008.12")
+			step.result.Error = err.Error()
+		}
+	}
+}
+
+// planAutomation runs the rules on the incident in memory, each rule
+// sees the changes of the rules before it
+func planAutomation(db *gorm.DB, incident *model.Incident, rules []model.AutomationRule) ([]automationFiring, error) {
+	now := time.Now()
+
+	firings := []automationFiring{}
+	for _, rule := range rules {
+		firing := automationFiring{rule: rule, matched: service.MatchAutomation(rule, *incident)}
+		if firing.matched {
+			for _, action := range rule.Actions {
+				step, err := planAutomationAction(db, incident, action, now)
+				if err != nil {
+					return nil, err
+				}
+				firing.steps = append(firing.steps, step)
+			}
+		}
+		firings = append(firings, firing)
+	}
+	return firings, nil
+}
+
+// planAutomationAction changes the incident in memory or prepares the
+// notification of one action
+func planAutomationAction(db *gorm.DB, incident *model.Incident, action model.AutomationAction, now time.Time) (step automationStep, err error) {
+	step.result.Type = action.Type
+
+	switch action.Type {
+	case model.AutomationAssign:
+		assignee, onCall, msg, err := automationRecipient(db, action, now)
+		if err != nil || msg != "" {
+			step.result.Error = msg
+			return step, err
+		}
+		if incident.AssignedTo == assignee {
+			step.result.Message = "already assigned to user " + strconv.FormatUint(assignee, 10)
+			return step, nil
+		}
+		step.event = model.EventReassigned
+		step.result.Message = "reassigned from user " + strconv.FormatUint(incident.AssignedTo, 10) +
+			" to user " + strconv.FormatUint(assignee, 10) + onCall
+		incident.AssignedTo = assignee
+		step.columns = []string{"assigned_to"}
+
+	case model.AutomationSetSeverity:
+		if incident.Severity == action.Severity {
+			step.result.Message = "severity already " + string(action.Severity)
+			return
+		}
+		step.event = model.EventSeverityChanged
+		step.result.Message = "severity changed from " + string(incident.Severity) + " to " + string(action.Severity)
+		incident.Severity = action.Severity
+		step.columns = []string{"severity"}
+
+	case model.AutomationAddTag:
+		tag, _ := parseTag(action.Tag)
+		for _, existing := range incident.Tags {
+			if existing.Name == tag.Name {
+				step.result.Message = "already tagged " + tag.Name
+				return
+			}
+		}
+		step.event = model.EventTagged
+		step.result.Message = "tagged " + tag.Name
+		step.tag = &tag
+		incident.Tags = append(incident.Tags, tag)
+
+	case model.AutomationNotify:
+		recipient, onCall, msg, err := automationRecipient(db, action, now)
+		if err != nil || msg != "" {
+			step.result.Error = msg
+			return step, err
+		}
+		if recipient == 0 {
+			recipient = incident.AssignedTo
+		}
+		step.result.Message = "email to user " + strconv.FormatUint(recipient, 10) + onCall
+		step.notify = recipient
+
+	case model.AutomationWebhook:
+		step.result.Message = "POST to " + action.URL
+		step.webhook = action.URL
+	}
+
+	return
+}
+
+// automationRecipient returns the user of an assign or notify action,
+// whoever is on call when it names a schedule, 0 when it names nobody.
+// msg explains why there is no one to pick.
+func automationRecipient(db *gorm.DB, action model.AutomationAction, now time.Time) (authID uint64, onCall, msg string, err error) {
+	if action.ScheduleID == 0 {
+		return action.AuthID, "", "", nil
+	}
+
+	var schedule model.Schedule
+
+	if err = db.Preload("Members").First(&schedule, action.ScheduleID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			return
+		}
+		return 0, "", "schedule " + strconv.FormatUint(action.ScheduleID, 10) + " not found", nil
+	}
+
+	authID, ok := service.OnCallAt(schedule, now)
+	if !ok {
+		return 0, "", "nobody is on call in schedule '" + schedule.Name + "'", nil
+	}
+	return authID, " (on call in schedule '" + schedule.Name + "')", "", nil
+}
+
+// automationRules loads the enabled rules for an event in the order
+// they run
+func automationRules(db *gorm.DB, event model.AutomationEvent) ([]model.AutomationRule, error) {
+	all := []model.AutomationRule{}
+
+	if err := db.Where("disabled = ?", false).Order("position").Order("rule_id").Find(&all).Error; err != nil {
+		return nil, err
+	}
+
+	rules := []model.AutomationRule{}
+	for _, rule := range all {
+		if slices.Contains(rule.Events, event) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func findAutomationRule(id uint64, errCode string) (rule model.AutomationRule, httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	db := database.GetDB()
+
+	if err := db.First(&rule, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("automation rule not found", http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
+
+func applyAutomationRuleReq(rule *model.AutomationRule, req model.AutomationRuleReq) {
+	rule.Name = req.Name
+	rule.Description = req.Description
+	rule.Disabled = req.Disabled
+	rule.Position = req.Position
+	rule.Events = req.Events
+	rule.MatchAny = req.MatchAny
+	rule.Conditions = req.Conditions
+	rule.Actions = req.Actions
+}
+
+// validateAutomationRuleReq normalizes the rule and checks that the
+// custom fields, users and schedules it names exist
+func validateAutomationRuleReq(req *model.AutomationRuleReq, errCode string) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	db := database.GetDB()
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Events == nil {
+		req.Events = []model.AutomationEvent{model.AutomationCreated, model.AutomationUpdated}
+	}
+	if req.Conditions == nil {
+		req.Conditions = []model.AutomationCondition{}
+	}
+
+	msg := ""
+	switch {
+	case req.Name == "":
+		msg = "name is required"
+	case len(req.Events) == 0:
+		msg = "at least one event is required"
+	case len(req.Conditions) > model.AutomationConditionsMax:
+		msg = "a rule may have " + strconv.Itoa(model.AutomationConditionsMax) + " conditions at most"
+	case len(req.Actions) == 0 || len(req.Actions) > model.AutomationActionsMax:
+		msg = "a rule needs 1 to " + strconv.Itoa(model.AutomationActionsMax) + " actions"
+	}
+	for _, event := range req.Events {
+		if msg == "" && !event.Valid() {
+			msg = "events must be created or updated"
+		}
+	}
+	if msg != "" {
+		httpResponse, httpStatusCode = setErrorMessage(msg, http.StatusBadRequest)
+		return
+	}
+	req.Events = slices.Compact(slices.Sorted(slices.Values(req.Events)))
+
+	fields, err := customFields()
+	if err != nil {
+		log.WithError(err).Error("error code: " + errCode)
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
+	}
+
+	for i := range req.Conditions {
+		if msg = validateAutomationCondition(&req.Conditions[i], fields); msg != "" {
+			httpResponse, httpStatusCode = setErrorMessage(msg, http.StatusBadRequest)
+			return
+		}
+	}
+
+	for i := range req.Actions {
+		action, msg := normalizeAutomationAction(req.Actions[i])
+		if msg != "" {
+			httpResponse, httpStatusCode = setErrorMessage(msg, http.StatusBadRequest)
+			return
+		}
+		req.Actions[i] = action
+
+		if action.AuthID != 0 {
+			if err := db.First(&model.Auth{}, action.AuthID).Error; err != nil {
+				if err.Error() != database.RecordNotFound {
+					log.WithError(err).Error("error code: " + errCode)
+					httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+					return
+				}
+				httpResponse, httpStatusCode = setErrorMessage("user "+strconv.FormatUint(action.AuthID, 10)+" not found", http.StatusNotFound)
+				return
+			}
+		}
+		if action.ScheduleID != 0 {
+			if err := db.First(&model.Schedule{}, action.ScheduleID).Error; err != nil {
+				if err.Error() != database.RecordNotFound {
+					log.WithError(err).Error("error code: " + errCode)
+					httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+					return
+				}
+				httpResponse, httpStatusCode = setErrorMessage("schedule "+strconv.FormatUint(action.ScheduleID, 10)+" not found", http.StatusNotFound)
+				return
+			}
+		}
+	}
+
+	ok = true
+	return
+}
+
+func validateAutomationCondition(condition *model.AutomationCondition, fields []model.CustomField) string {
+	condition.Field = strings.TrimSpace(condition.Field)
+	condition.Value = strings.TrimSpace(condition.Value)
+
+	if !service.AutomationField(condition.Field) {
+		return "unknown condition field: " + condition.Field
+	}
+	if key, ok := strings.CutPrefix(condition.Field, "custom."); ok {
+		if !slices.ContainsFunc(fields, func(field model.CustomField) bool { return field.Key == key }) {
+			return "unknown custom field: " + key
+		}
+	}
+	if !condition.Operator.Valid() {
+		return "operator must be eq, ne, contains or in"
+	}
+	return ""
+}
+
+// normalizeAutomationAction keeps only the arguments of the action type
+func normalizeAutomationAction(action model.AutomationAction) (model.AutomationAction, string) {
+	normalized := model.AutomationAction{Type: action.Type}
+
+	switch action.Type {
+	case model.AutomationAssign, model.AutomationNotify:
+		if action.AuthID != 0 && action.ScheduleID != 0 {
+			return normalized, string(action.Type) + " takes either authID or scheduleID"
+		}
+		if action.Type == model.AutomationAssign && action.AuthID == 0 && action.ScheduleID == 0 {
+			return normalized, "assign needs authID or scheduleID"
+		}
+		normalized.AuthID = action.AuthID
+		normalized.ScheduleID = action.ScheduleID
+
+	case model.AutomationSetSeverity:
+		if !action.Severity.Valid() {
+			return normalized, "severity must be low, medium, high or critical"
+		}
+		normalized.Severity = action.Severity
+
+	case model.AutomationAddTag:
+		tag, msg := parseTag(action.Tag)
+		if msg != "" {
+			return normalized, msg
+		}
+		normalized.Tag = tag.Name
+
+	case model.AutomationWebhook:
+		target, err := url.Parse(strings.TrimSpace(action.URL))
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return normalized, "webhook needs an http or https url"
+		}
+		normalized.URL = target.String()
+
+	default:
+		return normalized, "action type must be assign, set_severity, add_tag, notify or webhook"
+	}
+
+	return normalized, ""
+}
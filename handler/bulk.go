@@ -0,0 +1,341 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// BulkUpdateIncidents applies one action to many incidents at once.
+//
+// Every incident is checked first, the changes are only written
+// (in a single transaction) when none of them failed
+func BulkUpdateIncidents(req model.BulkReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if msg := validateBulkReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	if req.Action == model.BulkAssign {
+		if err := db.First(&model.Auth{}, req.AssignedTo).Error; err != nil {
+			if err.Error() != database.RecordNotFound {
+				log.WithError(err).Error("error code: 4001.1")
+				return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			}
+			return setErrorMessage("assigned user not found", http.StatusNotFound)
+		}
+	}
+
+	if req.Action == model.BulkMerge {
+		if _, resp, code, ok := mergeTarget(req.TargetID, authID, "4001.7"); !ok {
+			return resp, code
+		}
+	}
+
+	// select the incidents
+	var incidents []model.Incident
+	var ids []uint64
+
+	query := db
+	if req.Action == model.BulkAddTag {
+		query = db.Preload("Tags")
+	}
+
+	if req.Filter != nil {
+		filter := model.IncidentFilter{
+			ServiceID: req.Filter.ServiceID,
+			TeamID:    req.Filter.TeamID,
+			Status:    req.Filter.Status,
+		}
+		if err := query.Scopes(incidentFilterScope(filter)).Order("incident_id").
+			Limit(model.BulkMaxIncidents + 1).Find(&incidents).Error; err != nil {
+			log.WithError(err).Error("error code: 4001.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		if len(incidents) > model.BulkMaxIncidents {
+			return setErrorMessage("filter matches more than "+strconv.Itoa(model.BulkMaxIncidents)+" incidents", http.StatusBadRequest)
+		}
+		for _, incident := range incidents {
+			ids = append(ids, incident.IncidentID)
+		}
+	} else {
+		ids = uniqueIDs(req.IncidentIDs)
+		if err := query.Where("incident_id IN ?", ids).Find(&incidents).Error; err != nil {
+			log.WithError(err).Error("error code: 4001.3")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+
+	found := map[uint64]*model.Incident{}
+	for i := range incidents {
+		found[incidents[i].IncidentID] = &incidents[i]
+	}
+
+	result := model.BulkResult{
+		Action: req.Action,
+		Total:  len(ids),
+		Items:  make([]model.BulkItemResult, 0, len(ids)),
+	}
+	changed := []bulkChange{}
+	allowed := map[uint64]bool{} // edit permission per team
+	now := time.Now()
+
+	for _, id := range ids {
+		item := model.BulkItemResult{IncidentID: id}
+
+		incident, ok := found[id]
+		if ok && incident.TeamID != nil {
+			if _, seen := allowed[*incident.TeamID]; !seen {
+				permitted, err := hasTeamPermission(authID, *incident.TeamID, model.PermIncidentEdit)
+				if err != nil {
+					log.WithError(err).Error("error code: 4001.6")
+					return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+				}
+				allowed[*incident.TeamID] = permitted
+			}
+		}
+
+		switch {
+		case !ok:
+			item.Result, item.Message = model.BulkItemFailed, "incident not found"
+			result.Failed++
+		case incident.TeamID != nil && !allowed[*incident.TeamID]:
+			item.Result, item.Message = model.BulkItemFailed, "permission '"+string(model.PermIncidentEdit)+"' required in this team"
+			result.Failed++
+		case req.Action == model.BulkMerge && bulkMergeConflict(incident, req.TargetID) != "":
+			item.Result, item.Message = model.BulkItemFailed, bulkMergeConflict(incident, req.TargetID)
+			result.Failed++
+		case incident.DuplicateOf != nil && req.Action != model.BulkMerge && req.Action != model.BulkAddTag:
+			item.Result, item.Message = model.BulkItemFailed, "incident was merged into incident #"+strconv.FormatUint(*incident.DuplicateOf, 10)
+			result.Failed++
+		default:
+			change := applyBulkAction(incident, req, now)
+			if change.event == "" {
+				item.Result = model.BulkItemUnchanged
+				result.Unchanged++
+				break
+			}
+			item.Result, item.Message = model.BulkItemChanged, change.message
+			result.Changed++
+			changed = append(changed, change)
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	if result.Failed > 0 {
+		httpResponse.Message = result
+		httpStatusCode = http.StatusUnprocessableEntity
+		return
+	}
+
+	// the severity picks the SLA policy
+	var policies []model.SLAPolicy
+	if req.Action == model.BulkSetSeverity {
+		var err error
+		if policies, err = slaPolicies(db); err != nil {
+			log.WithError(err).Error("error code: 4001.11")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+
+	tx := db.Begin()
+	for _, change := range changed {
+		if change.mergeInto != 0 {
+			if err := mergeIncident(tx, change.incident, change.mergeInto, authID, now); err != nil {
+				tx.Rollback()
+				log.WithError(err).Error("error code: 4001.8")
+				return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			}
+			continue
+		}
+
+		if change.tag != nil {
+			tags := []model.Tag{*change.tag}
+			if err := saveTags(tx, tags); err != nil {
+				tx.Rollback()
+				log.WithError(err).Error("error code: 4001.9")
+				return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			}
+			if err := tx.Model(&model.Incident{IncidentID: change.incident.IncidentID}).Association("Tags").Append(&tags[0]); err != nil {
+				tx.Rollback()
+				log.WithError(err).Error("error code: 4001.10")
+				return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			}
+		}
+
+		if req.Action == model.BulkSetSeverity {
+			if _, err := applySLA(change.incident, policies); err != nil {
+				tx.Rollback()
+				log.WithError(err).Error("error code: 4001.12")
+				return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			}
+			change.columns = append(change.columns, "sla_policy_id", "acknowledge_due_at", "resolve_due_at", "sla_alerts")
+		}
+
+		if err := tx.Model(change.incident).Select(change.columns).Updates(change.incident).Error; err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 4001.4")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+
+		if err := recordEvent(tx, change.incident.IncidentID, authID, change.event, change.message+" (bulk operation)"); err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 4001.5")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4001.13")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	// merged incidents are closed as duplicates, rules only see the
+	// incidents which were updated
+	for _, change := range changed {
+		if change.mergeInto == 0 {
+			automateIncident(change.incident, model.AutomationUpdated, authID)
+		}
+	}
+
+	result.Applied = true
+
+	httpResponse.Message = result
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// bulkChange - pending update of one incident
+type bulkChange struct {
+	incident *model.Incident
+	columns  []string
+	event    model.EventType
+	message  string
+
+	mergeInto uint64     // merged instead of updated
+	tag       *model.Tag // added to the incident
+}
+
+// applyBulkAction changes the incident in memory,
+// an empty event means there was nothing to change
+func applyBulkAction(incident *model.Incident, req model.BulkReq, now time.Time) (change bulkChange) {
+	change.incident = incident
+
+	switch req.Action {
+	case model.BulkSetStatus:
+		if incident.Status == req.Status {
+			return
+		}
+		change.event = model.EventStatusChanged
+		change.message = "status changed from " + string(incident.Status) + " to " + string(req.Status)
+		incident.Status = req.Status
+		incident.TrackStatus(now)
+		change.columns = []string{"status", "acknowledged_at", "resolved_at", "updated_at"}
+
+	case model.BulkSetSeverity:
+		if incident.Severity == req.Severity {
+			return
+		}
+		change.event = model.EventSeverityChanged
+		change.message = "severity changed from " + string(incident.Severity) + " to " + string(req.Severity)
+		incident.Severity = req.Severity
+		change.columns = []string{"severity", "updated_at"}
+
+	case model.BulkAssign:
+		if incident.AssignedTo == req.AssignedTo {
+			return
+		}
+		change.event = model.EventReassigned
+		change.message = "reassigned from user " + strconv.FormatUint(incident.AssignedTo, 10) +
+			" to user " + strconv.FormatUint(req.AssignedTo, 10)
+		incident.AssignedTo = req.AssignedTo
+		change.columns = []string{"assigned_to", "updated_at"}
+
+	case model.BulkAddTag:
+		tag, _ := parseTag(req.Tag)
+		for _, existing := range incident.Tags {
+			if existing.Name == tag.Name {
+				return
+			}
+		}
+		change.event = model.EventTagged
+		change.message = "tagged " + tag.Name
+		change.tag = &tag
+		change.columns = []string{"updated_at"}
+
+	case model.BulkMerge:
+		if incident.DuplicateOf != nil {
+			return
+		}
+		change.event = model.EventMerged
+		change.message = "merged into incident #" + strconv.FormatUint(req.TargetID, 10)
+		change.mergeInto = req.TargetID
+		return
+	}
+
+	incident.UpdatedAt = now
+	return
+}
+
+// bulkMergeConflict explains why an incident cannot be merged into the target
+func bulkMergeConflict(incident *model.Incident, targetID uint64) string {
+	if incident.IncidentID == targetID {
+		return "an incident cannot be merged into itself"
+	}
+	if incident.DuplicateOf != nil && *incident.DuplicateOf != targetID {
+		return "incident was already merged into incident #" + strconv.FormatUint(*incident.DuplicateOf, 10)
+	}
+	return ""
+}
+
+// validateBulkReq checks the selection and the action arguments
+func validateBulkReq(req *model.BulkReq) string {
+	switch {
+	case req.Filter != nil && len(req.IncidentIDs) > 0:
+		return "either incidentIDs or filter is allowed, not both"
+	case req.Filter != nil:
+		if req.Filter.ServiceID == 0 && req.Filter.TeamID == 0 && req.Filter.Status == "" {
+			return "filter needs at least one criterion"
+		}
+		if req.Filter.Status != "" && !req.Filter.Status.Valid() {
+			return "invalid filter status"
+		}
+	case len(req.IncidentIDs) == 0:
+		return "incidentIDs or filter is required"
+	case len(req.IncidentIDs) > model.BulkMaxIncidents:
+		return "at most " + strconv.Itoa(model.BulkMaxIncidents) + " incidents can be changed at once"
+	}
+
+	switch req.Action {
+	case model.BulkSetStatus:
+		if !req.Status.Valid() {
+			return "status must be open, acknowledged or closed"
+		}
+	case model.BulkSetSeverity:
+		if !req.Severity.Valid() {
+			return "severity must be low, medium, high or critical"
+		}
+	case model.BulkAssign:
+		if req.AssignedTo == 0 {
+			return "assignedTo is required"
+		}
+	case model.BulkAddTag:
+		if _, msg := parseTag(req.Tag); msg != "" {
+			return msg
+		}
+	case model.BulkMerge:
+		if req.TargetID == 0 {
+			return "targetID is required"
+		}
+	default:
+		return "unknown action: " + string(req.Action)
+	}
+
+	return ""
+}
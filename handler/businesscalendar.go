@@ -0,0 +1,391 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func GetBusinessCalendars() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	calendars := []model.BusinessCalendar{}
+
+	if err := db.Order("business_calendars.name").Find(&calendars).Error; err != nil {
+		log.WithError(err).Error("error code: 4501.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = calendars
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetBusinessCalendar returns a calendar with its holidays
+func GetBusinessCalendar(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	calendar, resp, code, ok := businessCalendar(id, "4502.1")
+	if !ok {
+		return resp, code
+	}
+
+	httpResponse.Message = calendar
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func CreateBusinessCalendar(req model.BusinessCalendarReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if msg := validateBusinessCalendarReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	if msg, err := businessCalendarConflict(db, req.Name, 0); err != nil {
+		log.WithError(err).Error("error code: 4503.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	} else if msg != "" {
+		return setErrorMessage(msg, http.StatusConflict)
+	}
+
+	calendar := model.BusinessCalendar{
+		Name:     req.Name,
+		TimeZone: req.TimeZone,
+		Hours:    req.Hours,
+	}
+
+	if err := db.Create(&calendar).Error; err != nil {
+		log.WithError(err).Error("error code: 4503.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = calendar
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// UpdateBusinessCalendar replaces name, time zone and working hours,
+// the SLA due times of open incidents are computed again
+func UpdateBusinessCalendar(id uint64, req model.BusinessCalendarReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if msg := validateBusinessCalendarReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	calendar, resp, code, ok := businessCalendar(id, "4504.1")
+	if !ok {
+		return resp, code
+	}
+
+	if msg, err := businessCalendarConflict(db, req.Name, id); err != nil {
+		log.WithError(err).Error("error code: 4504.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	} else if msg != "" {
+		return setErrorMessage(msg, http.StatusConflict)
+	}
+
+	calendar.Name = req.Name
+	calendar.TimeZone = req.TimeZone
+	calendar.Hours = req.Hours
+
+	tx := db.Begin()
+	if err := tx.Omit("Holidays").Save(&calendar).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4504.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := refreshSLAs(tx); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4504.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4504.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = calendar
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DeleteBusinessCalendar removes a calendar which no SLA policy uses
+func DeleteBusinessCalendar(id, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if _, resp, code, ok := businessCalendar(id, "4505.1"); !ok {
+		return resp, code
+	}
+
+	var policies int64
+	if err := db.Model(&model.SLAPolicy{}).Where("calendar_id = ?", id).Count(&policies).Error; err != nil {
+		log.WithError(err).Error("error code: 4505.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if policies > 0 {
+		return setErrorMessage("business calendar is used by an SLA policy", http.StatusConflict)
+	}
+
+	tx := db.Begin()
+	if err := tx.Where("calendar_id = ?", id).Delete(&model.Holiday{}).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4505.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Delete(&model.BusinessCalendar{}, id).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4505.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4505.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "business calendar deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func AddHoliday(id uint64, req model.HolidayReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	date, err := time.Parse(model.HolidayDateLayout, strings.TrimSpace(req.Date))
+	if err != nil {
+		return setErrorMessage("date must be YYYY-MM-DD", http.StatusBadRequest)
+	}
+
+	calendar, resp, code, ok := businessCalendar(id, "4506.1")
+	if !ok {
+		return resp, code
+	}
+
+	holiday := model.Holiday{
+		CalendarID: id,
+		Date:       date.Format(model.HolidayDateLayout),
+		Name:       strings.TrimSpace(req.Name),
+	}
+	for _, existing := range calendar.Holidays {
+		if existing.Date == holiday.Date {
+			return setErrorMessage(holiday.Date+" is already a holiday", http.StatusConflict)
+		}
+	}
+
+	tx := db.Begin()
+	if err := tx.Create(&holiday).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4506.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := refreshSLAs(tx); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4506.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4506.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = holiday
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+func DeleteHoliday(id, holidayID, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	tx := db.Begin()
+	result := tx.Where("calendar_id = ?", id).Delete(&model.Holiday{}, holidayID)
+	if result.Error != nil {
+		tx.Rollback()
+		log.WithError(result.Error).Error("error code: 4507.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return setErrorMessage("holiday not found", http.StatusNotFound)
+	}
+	if err := refreshSLAs(tx); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4507.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4507.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "holiday deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// ImportHolidays adds the days of the events of an iCalendar file,
+// dates the calendar already has are skipped
+func ImportHolidays(id uint64, body io.Reader, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	calendar, resp, code, ok := businessCalendar(id, "4508.1")
+	if !ok {
+		return resp, code
+	}
+
+	holidays, err := service.ParseICalendarHolidays(body)
+	if err != nil {
+		return setErrorMessage(err.Error(), http.StatusBadRequest)
+	}
+
+	known := map[string]bool{}
+	for _, holiday := range calendar.Holidays {
+		known[holiday.Date] = true
+	}
+
+	result := model.HolidayImport{}
+	added := []model.Holiday{}
+	for _, holiday := range holidays {
+		if known[holiday.Date] {
+			result.Skipped++
+			continue
+		}
+		known[holiday.Date] = true
+		holiday.CalendarID = id
+		added = append(added, holiday)
+	}
+	result.Imported = len(added)
+
+	if len(added) > 0 {
+		tx := db.Begin()
+		if err := tx.CreateInBatches(&added, 100).Error; err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 4508.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		if err := refreshSLAs(tx); err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 4508.3")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		if err := tx.Commit().Error; err != nil {
+			log.WithError(err).Error("error code: 4508.4")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+
+	httpResponse.Message = result
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetBusinessDue computes the moment minutes of business time after from
+func GetBusinessDue(id uint64, from time.Time, minutes int64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	if minutes < 0 {
+		return setErrorMessage("minutes must not be negative", http.StatusBadRequest)
+	}
+
+	calendar, resp, code, ok := businessCalendar(id, "4509.1")
+	if !ok {
+		return resp, code
+	}
+
+	if from.IsZero() {
+		from = time.Now()
+	}
+
+	businessTime, err := service.NewBusinessTime(calendar)
+	if err != nil {
+		log.WithError(err).Error("error code: 4509.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	due, err := businessTime.AddMinutes(from, minutes)
+	if err != nil {
+		return setErrorMessage(err.Error(), http.StatusUnprocessableEntity)
+	}
+
+	httpResponse.Message = model.BusinessDue{From: from, Minutes: minutes, Due: due}
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// businessCalendar loads a calendar with its holidays by date
+func businessCalendar(id uint64, errCode string) (calendar model.BusinessCalendar, httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	db := database.GetDB()
+
+	err := db.Preload("Holidays", func(db *gorm.DB) *gorm.DB { return db.Order("date") }).First(&calendar, id).Error
+	if err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("business calendar not found", http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
+
+// businessCalendarConflict reports another calendar of the same name
+func businessCalendarConflict(db *gorm.DB, name string, id uint64) (string, error) {
+	var count int64
+	if err := db.Model(&model.BusinessCalendar{}).Where("business_calendars.name = ? AND calendar_id <> ?", name, id).Count(&count).Error; err != nil {
+		return "", err
+	}
+	if count > 0 {
+		return "business calendar '" + name + "' already exists", nil
+	}
+	return "", nil
+}
+
+func validateBusinessCalendarReq(req *model.BusinessCalendarReq) string {
+	req.Name = strings.TrimSpace(req.Name)
+	req.TimeZone = strings.TrimSpace(req.TimeZone)
+
+	if req.Name == "" {
+		return "name is required"
+	}
+	if req.TimeZone == "" {
+		return "time zone is required"
+	}
+	if len(req.Hours) == 0 {
+		return "working hours are required"
+	}
+
+	if _, err := service.NewBusinessTime(model.BusinessCalendar{TimeZone: req.TimeZone, Hours: req.Hours}); err != nil {
+		return err.Error()
+	}
+	return ""
+}
@@ -0,0 +1,496 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// checkPollInterval - how often the scheduler looks for due checks
+const checkPollInterval = 5 * time.Second
+
+// StartCheckScheduler runs the due synthetic checks. It blocks, run it
+// in its own goroutine.
+func StartCheckScheduler() {
+	ticker := time.NewTicker(checkPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := RunDueChecks(time.Now()); err != nil {
+			log.WithError(err).Error("error code: 4909.1")
+		}
+		<-ticker.C
+	}
+}
+
+// RunDueChecks runs the checks which are due at now on a pool of at
+// most CHECK_WORKERS workers and waits for them to finish. It returns
+// the number of checks run.
+func RunDueChecks(now time.Time) (int, error) {
+	db := database.GetDB()
+
+	var checks []model.Check
+
+	if err := db.Where("paused = ? AND next_check_at <= ?", false, now).Order("next_check_at").Find(&checks).Error; err != nil {
+		return 0, err
+	}
+
+	jobs := make(chan model.Check)
+
+	var wg sync.WaitGroup
+	for range min(int(config.GetConfig().Monitor.CheckWorkers), len(checks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for check := range jobs {
+				if _, err := runCheck(check); err != nil {
+					log.WithError(err).WithField("checkID", check.CheckID).Error("error code: 4909.2")
+				}
+			}
+		}()
+	}
+
+	for _, check := range checks {
+		jobs <- check
+	}
+	close(jobs)
+	wg.Wait()
+
+	return len(checks), nil
+}
+
+func GetChecks() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	checks := []model.Check{}
+
+	if err := db.Order("checks.name").Find(&checks).Error; err != nil {
+		log.WithError(err).Error("error code: 4901.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	for i := range checks {
+		checks[i].FillStatus()
+	}
+
+	httpResponse.Message = checks
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func GetCheck(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	check, resp, code, ok := findCheck(id, "4902.1")
+	if !ok {
+		return resp, code
+	}
+
+	check.FillStatus()
+
+	httpResponse.Message = check
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// CreateCheck adds a synthetic check, only administrators can as the
+// server connects to its target. It first runs right away.
+func CreateCheck(req model.CheckReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if req.AssignedTo == 0 {
+		req.AssignedTo = authID
+	}
+	if resp, code, ok := validateCheckReq(&req, "4903.1"); !ok {
+		return resp, code
+	}
+
+	check := model.Check{AuthID: authID, NextCheckAt: time.Now()}
+	applyCheckReq(&check, req)
+
+	if err := db.Create(&check).Error; err != nil {
+		log.WithError(err).Error("error code: 4903.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	check.FillStatus()
+
+	httpResponse.Message = check
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// UpdateCheck replaces the settings of a check, the failures in a row
+// so far are kept
+func UpdateCheck(id uint64, req model.CheckReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	check, resp, code, ok := findCheck(id, "4904.1")
+	if !ok {
+		return resp, code
+	}
+
+	if req.AssignedTo == 0 {
+		req.AssignedTo = check.AssignedTo
+	}
+	if resp, code, ok := validateCheckReq(&req, "4904.2"); !ok {
+		return resp, code
+	}
+
+	applyCheckReq(&check, req)
+	if check.LastCheckedAt != nil {
+		check.NextCheckAt = check.LastCheckedAt.Add(time.Duration(check.Interval) * time.Second)
+	}
+
+	if err := db.Save(&check).Error; err != nil {
+		log.WithError(err).Error("error code: 4904.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	check.FillStatus()
+
+	httpResponse.Message = check
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DeleteCheck removes a check with its results
+func DeleteCheck(id uint64, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	tx := db.Begin()
+	result := tx.Delete(&model.Check{}, id)
+	if result.Error != nil {
+		tx.Rollback()
+		log.WithError(result.Error).Error("error code: 4905.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return setErrorMessage("check not found", http.StatusNotFound)
+	}
+	if err := tx.Where("check_id = ?", id).Delete(&model.CheckResult{}).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4905.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4905.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "check deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetCheckResults lists the latest results of a check, newest first
+func GetCheckResults(id uint64, limit int) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if limit == 0 {
+		limit = model.CheckResultsPageSize
+	}
+	if limit < 0 || limit > model.CheckResultsMax {
+		return setErrorMessage("limit must be between 1 and "+strconv.Itoa(model.CheckResultsMax), http.StatusBadRequest)
+	}
+
+	if _, resp, code, ok := findCheck(id, "4906.1"); !ok {
+		return resp, code
+	}
+
+	results := []model.CheckResult{}
+
+	if err := db.Where("check_id = ?", id).Order("result_id DESC").Limit(limit).Find(&results).Error; err != nil {
+		log.WithError(err).Error("error code: 4906.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = results
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// RunCheckNow runs a check right away, paused ones too, and records
+// its result like a scheduled run. Only administrators can, like they
+// manage checks.
+func RunCheckNow(id uint64, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	check, resp, code, ok := findCheck(id, "4907.1")
+	if !ok {
+		return resp, code
+	}
+
+	result, err := runCheck(check)
+	if err != nil {
+		log.WithError(err).Error("error code: 4907.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = result
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// runCheck probes the target of a check and records the result. An
+// alert is raised once the check failed FailureThreshold times in a
+// row, its incident is closed by the next success. When a maintenance
+// window suppresses the alert, it is raised again by the first failure
+// after the window.
+func runCheck(check model.Check) (model.CheckResult, error) {
+	db := database.GetDB()
+
+	result := service.RunCheck(check)
+
+	failures := 0
+	if !result.Success {
+		failures = check.ConsecutiveFailures + 1
+	}
+	state := map[string]any{
+		"consecutive_failures": failures,
+		"last_checked_at":      result.CreatedAt,
+		"next_check_at":        result.CreatedAt.Add(time.Duration(check.Interval) * time.Second),
+	}
+	recovered := result.Success && check.AlertedAt != nil
+	if recovered {
+		state["alerted_at"] = nil
+		state["incident_id"] = nil
+	}
+	if result.Success {
+		state["suppressed_until"] = nil
+	}
+
+	tx := db.Begin()
+	update := tx.Model(&model.Check{CheckID: check.CheckID}).UpdateColumns(state)
+	if update.Error != nil {
+		tx.Rollback()
+		return result, update.Error
+	}
+	if update.RowsAffected == 0 {
+		// deleted while it ran
+		tx.Rollback()
+		return result, nil
+	}
+	if err := tx.Create(&result).Error; err != nil {
+		tx.Rollback()
+		return result, err
+	}
+	if err := pruneCheckResults(tx, check.CheckID); err != nil {
+		tx.Rollback()
+		return result, err
+	}
+	if recovered && check.IncidentID != nil {
+		reason := "check '" + check.Name + "' succeeded again"
+		if err := resolveMonitorIncident(tx, *check.IncidentID, reason, result.CreatedAt); err != nil {
+			tx.Rollback()
+			return result, err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return result, err
+	}
+
+	if failures < check.FailureThreshold || check.AlertedAt != nil {
+		return result, nil
+	}
+	if check.SuppressedUntil != nil && result.CreatedAt.Before(*check.SuppressedUntil) {
+		return result, nil
+	}
+
+	alert, err := ingestAlert(model.AlertReq{
+		Source: "check",
+		Title:  "Check '" + check.Name + "' failing",
+		Description: string(check.Type) + " check on " + check.Target + " failed " + strconv.Itoa(failures) +
+			" times in a row, last: " + result.Message,
+		Severity:   check.Severity,
+		ServiceIDs: check.ServiceIDs,
+		AssignedTo: check.AssignedTo,
+	}, check.AuthID)
+	if err != nil {
+		log.WithError(err).WithField("checkID", check.CheckID).Error("error code: 4908.1")
+		return result, nil
+	}
+
+	if alert.IncidentID == nil {
+		err := db.Model(&model.Check{CheckID: check.CheckID}).UpdateColumn("suppressed_until", alert.SuppressedUntil).Error
+		return result, err
+	}
+
+	err = db.Model(&model.Check{CheckID: check.CheckID}).UpdateColumns(map[string]any{
+		"alerted_at":       result.CreatedAt,
+		"incident_id":      alert.IncidentID,
+		"suppressed_until": nil,
+	}).Error
+	return result, err
+}
+
+// pruneCheckResults keeps the latest CheckResultsMax results of a check
+func pruneCheckResults(tx *gorm.DB, checkID uint64) error {
+	var oldest []uint64
+
+	err := tx.Model(&model.CheckResult{}).Where("check_id = ?", checkID).
+		Order("result_id DESC").Offset(model.CheckResultsMax-1).Limit(1).
+		Pluck("result_id", &oldest).Error
+	if err != nil || len(oldest) == 0 {
+		return err
+	}
+
+	return tx.Where("check_id = ? AND result_id < ?", checkID, oldest[0]).Delete(&model.CheckResult{}).Error
+}
+
+func findCheck(id uint64, errCode string) (check model.Check, httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	db := database.GetDB()
+
+	if err := db.First(&check, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("check not found", http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
+
+func applyCheckReq(check *model.Check, req model.CheckReq) {
+	check.Name = req.Name
+	check.Type = req.Type
+	check.Target = req.Target
+	check.Paused = req.Paused
+	check.Interval = req.Interval
+	check.Timeout = req.Timeout
+	check.Method = req.Method
+	check.ExpectedStatus = req.ExpectedStatus
+	check.BodyContains = req.BodyContains
+	check.MaxLatency = req.MaxLatency
+	check.ExpiryDays = req.ExpiryDays
+	check.SkipVerify = req.SkipVerify
+	check.FailureThreshold = req.FailureThreshold
+	check.Severity = req.Severity
+	check.AssignedTo = req.AssignedTo
+	check.ServiceIDs = req.ServiceIDs
+}
+
+// validateCheckReq fills the defaults, checks the settings of the
+// check type and that the assignee and services of the incident exist
+func validateCheckReq(req *model.CheckReq, errCode string) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Target = strings.TrimSpace(req.Target)
+	req.Method = strings.ToUpper(strings.TrimSpace(req.Method))
+
+	if req.Interval == 0 {
+		req.Interval = 60
+	}
+	if req.Timeout == 0 {
+		req.Timeout = 10
+	}
+	if req.FailureThreshold == 0 {
+		req.FailureThreshold = 3
+	}
+	if req.Severity == "" {
+		req.Severity = model.SeverityType(config.GetConfig().Monitor.CheckSeverity)
+	}
+	if req.ServiceIDs == nil {
+		req.ServiceIDs = []uint64{}
+	}
+
+	msg := ""
+	switch {
+	case req.Name == "":
+		msg = "name is required"
+	case !req.Type.Valid():
+		msg = "type must be http, tcp or tls"
+	case req.Interval < model.CheckIntervalMin || req.Interval > model.CheckIntervalMax:
+		msg = "interval must be 10 seconds to 1 day"
+	case req.Timeout < 1 || req.Timeout > model.CheckTimeoutMax || req.Timeout > req.Interval:
+		msg = "timeout must be 1 to 60 seconds and at most the interval"
+	case req.MaxLatency < 0 || req.MaxLatency > req.Timeout*1000:
+		msg = "maxLatency must be 0 to the timeout in milliseconds"
+	case req.FailureThreshold < 1 || req.FailureThreshold > model.CheckFailuresMax:
+		msg = "failureThreshold must be 1 to " + strconv.Itoa(model.CheckFailuresMax)
+	case !req.Severity.Valid():
+		msg = "severity must be low, medium, high or critical"
+	default:
+		msg = validateCheckTarget(req)
+	}
+	if msg != "" {
+		httpResponse, httpStatusCode = setErrorMessage(msg, http.StatusBadRequest)
+		return
+	}
+
+	return monitorTargetsExist(req.AssignedTo, req.ServiceIDs, errCode)
+}
+
+// validateCheckTarget checks the target and the assertions which only
+// apply to some check types
+func validateCheckTarget(req *model.CheckReq) string {
+	if req.Type == model.CheckHTTP {
+		target, err := url.Parse(req.Target)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return "target of an http check must be an http or https URL"
+		}
+		if req.Method == "" {
+			req.Method = http.MethodGet
+		}
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			return "method must be GET or HEAD"
+		}
+		if req.ExpectedStatus != 0 && (req.ExpectedStatus < 100 || req.ExpectedStatus > 599) {
+			return "expectedStatus must be 100 to 599"
+		}
+		if req.ExpiryDays != 0 {
+			return "expiryDays only applies to tls checks"
+		}
+		return ""
+	}
+
+	if _, port, err := net.SplitHostPort(req.Target); err != nil || port == "" {
+		return "target of a " + string(req.Type) + " check must be host:port"
+	}
+	if req.Method != "" || req.ExpectedStatus != 0 || req.BodyContains != "" {
+		return "method, expectedStatus and bodyContains only apply to http checks"
+	}
+
+	switch req.Type {
+	case model.CheckTCP:
+		if req.ExpiryDays != 0 || req.SkipVerify {
+			return "expiryDays and skipVerify only apply to tls checks"
+		}
+	case model.CheckTLS:
+		if req.ExpiryDays == 0 {
+			req.ExpiryDays = 14
+		}
+		if req.ExpiryDays < 1 || req.ExpiryDays > model.CheckExpiryDaysMax {
+			return "expiryDays must be 1 to " + strconv.Itoa(model.CheckExpiryDaysMax)
+		}
+	}
+	return ""
+}
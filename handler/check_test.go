@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+func TestRunCheckThresholdAndRecovery(t *testing.T) {
+	setupTestDB(t)
+	target, failing := testCheckTarget(t)
+	check := createTestCheck(t, target)
+
+	steps := []struct {
+		name     string
+		failing  bool
+		failures int
+		alerts   int64
+		open     bool // incident of the check still open
+	}{
+		{name: "first failure", failing: true, failures: 1},
+		{name: "threshold reached", failing: true, failures: 2, alerts: 1, open: true},
+		{name: "alerted once", failing: true, failures: 3, alerts: 1, open: true},
+		{name: "recovered", failures: 0, alerts: 1},
+		{name: "failing again", failing: true, failures: 1, alerts: 1},
+	}
+
+	var incidentID uint64
+	for _, step := range steps {
+		failing.Store(step.failing)
+		check = runTestCheck(t, check)
+
+		if check.ConsecutiveFailures != step.failures {
+			t.Errorf("%s: consecutive failures = %d, want %d", step.name, check.ConsecutiveFailures, step.failures)
+		}
+		if alerts := countTestAlerts(t, ""); alerts != step.alerts {
+			t.Errorf("%s: alerts = %d, want %d", step.name, alerts, step.alerts)
+		}
+		if (check.IncidentID != nil) != step.open || (check.AlertedAt != nil) != step.open {
+			t.Errorf("%s: incident = %v, alerted at = %v, want set %v", step.name, check.IncidentID, check.AlertedAt, step.open)
+		}
+		if check.IncidentID != nil {
+			incidentID = *check.IncidentID
+		}
+	}
+
+	var incident model.Incident
+	if err := database.GetDB().First(&incident, incidentID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if incident.Status != model.Closed {
+		t.Errorf("incident status = %s after the recovery, want %s", incident.Status, model.Closed)
+	}
+}
+
+func TestRunCheckSuppressed(t *testing.T) {
+	setupTestDB(t)
+	target, failing := testCheckTarget(t)
+	failing.Store(true)
+	check := createTestCheck(t, target)
+
+	now := time.Now()
+	window := model.MaintenanceWindow{
+		Name:     "deploy",
+		Action:   model.MaintenanceSuppress,
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+		Services: []model.Service{{ServiceID: check.ServiceIDs[0]}},
+	}
+	if err := database.GetDB().Create(&window).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		check = runTestCheck(t, check)
+	}
+	if alerts := countTestAlerts(t, model.AlertSuppressed); alerts != 1 {
+		t.Errorf("suppressed alerts = %d during the window, want 1", alerts)
+	}
+	if check.SuppressedUntil == nil || !check.SuppressedUntil.Equal(window.EndsAt) {
+		t.Errorf("suppressed until = %v, want %v", check.SuppressedUntil, window.EndsAt)
+	}
+	if check.IncidentID != nil || check.AlertedAt != nil {
+		t.Errorf("incident = %v, alerted at = %v during the window, want none", check.IncidentID, check.AlertedAt)
+	}
+
+	// the window ended
+	ended := now.Add(-time.Minute)
+	if err := database.GetDB().Model(&window).Update("ends_at", ended).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := database.GetDB().Model(&check).Update("suppressed_until", ended).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	check = runTestCheck(t, check)
+	if alerts := countTestAlerts(t, model.AlertIncidentCreated); alerts != 1 {
+		t.Errorf("alerts which opened an incident = %d after the window, want 1", alerts)
+	}
+	if check.IncidentID == nil || check.SuppressedUntil != nil {
+		t.Errorf("incident = %v, suppressed until = %v after the window, want an incident only", check.IncidentID, check.SuppressedUntil)
+	}
+}
+
+// testCheckTarget serves 503 while failing is set, 200 else
+func testCheckTarget(t *testing.T) (string, *atomic.Bool) {
+	t.Helper()
+
+	failing := &atomic.Bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, failing
+}
+
+// createTestCheck stores an http check of a new service which alerts
+// after two failures
+func createTestCheck(t *testing.T, target string) model.Check {
+	t.Helper()
+	db := database.GetDB()
+
+	auth := model.Auth{EmailHash: "check", Password: "x"}
+	if err := db.Create(&auth).Error; err != nil {
+		t.Fatal(err)
+	}
+	svc := model.Service{Name: "api"}
+	if err := db.Create(&svc).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	check := model.Check{
+		Name:             "api health",
+		Type:             model.CheckHTTP,
+		Target:           target,
+		AuthID:           auth.AuthID,
+		Interval:         60,
+		Timeout:          5,
+		FailureThreshold: 2,
+		Severity:         model.High,
+		AssignedTo:       auth.AuthID,
+		ServiceIDs:       []uint64{svc.ServiceID},
+		NextCheckAt:      time.Now(),
+	}
+	if err := db.Create(&check).Error; err != nil {
+		t.Fatal(err)
+	}
+	return check
+}
+
+// runTestCheck runs a check and returns its stored state
+func runTestCheck(t *testing.T, check model.Check) model.Check {
+	t.Helper()
+
+	if _, err := runCheck(check); err != nil {
+		t.Fatal(err)
+	}
+	var stored model.Check
+	if err := database.GetDB().First(&stored, check.CheckID).Error; err != nil {
+		t.Fatal(err)
+	}
+	return stored
+}
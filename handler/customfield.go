@@ -0,0 +1,408 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	log "github.com/sirupsen/logrus"
+)
+
+var customFieldKey = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+func GetCustomFields() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	fields, err := customFields()
+	if err != nil {
+		log.WithError(err).Error("error code: 4301.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = fields
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func CreateCustomField(req model.CustomFieldReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if msg := validateCustomFieldReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	var count int64
+	if err := db.Model(&model.CustomField{}).Where("custom_fields.key = ?", req.Key).Count(&count).Error; err != nil {
+		log.WithError(err).Error("error code: 4302.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if count > 0 {
+		return setErrorMessage("custom field '"+req.Key+"' already exists", http.StatusConflict)
+	}
+
+	field := model.CustomField{
+		Key:         req.Key,
+		Label:       req.Label,
+		Description: req.Description,
+		Type:        req.Type,
+		Required:    req.Required,
+		Options:     req.Options,
+	}
+
+	if err := db.Create(&field).Error; err != nil {
+		log.WithError(err).Error("error code: 4302.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = field
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+func UpdateCustomField(id uint64, req model.CustomFieldReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if msg := validateCustomFieldReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	var field model.CustomField
+
+	if err := db.First(&field, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 4303.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("custom field not found", http.StatusNotFound)
+	}
+
+	// stored values depend on both
+	if req.Key != field.Key || req.Type != field.Type {
+		return setErrorMessage("key and type of a custom field cannot change", http.StatusBadRequest)
+	}
+
+	field.Label = req.Label
+	field.Description = req.Description
+	field.Required = req.Required
+	field.Options = req.Options
+
+	if err := db.Save(&field).Error; err != nil {
+		log.WithError(err).Error("error code: 4303.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = field
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DeleteCustomField removes the definition, values already stored
+// on incidents are kept until the incident's fields are replaced
+func DeleteCustomField(id, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	result := db.Delete(&model.CustomField{}, id)
+	if result.Error != nil {
+		log.WithError(result.Error).Error("error code: 4304.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if result.RowsAffected == 0 {
+		return setErrorMessage("custom field not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = "custom field deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetIncidentOpenAPI fills the custom field definitions into
+// the Incident schema of the incident API specification
+func GetIncidentOpenAPI(spec *openapi3.T) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	fields, err := customFields()
+	if err != nil {
+		log.WithError(err).Error("error code: 4305.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	values := openapi3.NewObjectSchema()
+	values.Description = "administrator defined fields, see /custom-fields"
+	for _, field := range fields {
+		values.WithProperty(field.Key, customFieldSchema(field))
+		if field.Required {
+			values.Required = append(values.Required, field.Key)
+		}
+	}
+
+	if incident := spec.Components.Schemas["Incident"]; incident != nil && incident.Value != nil {
+		incident.Value.WithProperty("custom_fields", values)
+	}
+
+	httpResponse.Message = spec
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func customFieldSchema(field model.CustomField) *openapi3.Schema {
+	var schema *openapi3.Schema
+
+	switch field.Type {
+	case model.FieldNumber:
+		schema = openapi3.NewFloat64Schema()
+	case model.FieldEnum:
+		schema = openapi3.NewStringSchema()
+		for _, option := range field.Options {
+			schema.Enum = append(schema.Enum, option)
+		}
+	case model.FieldDate:
+		schema = openapi3.NewStringSchema()
+		schema.Format = "date"
+	case model.FieldUser:
+		schema = openapi3.NewInt64Schema()
+		schema.Format = "uint64"
+		schema.Description = "auth ID of a user"
+	default:
+		schema = openapi3.NewStringSchema().WithMaxLength(model.CustomFieldTextMaxLength)
+	}
+
+	schema.Title = field.Label
+	if field.Description != "" {
+		schema.Description = field.Description
+	}
+	return schema
+}
+
+// customFieldValues validates the values of an incident
+// against the definitions, null values are dropped. Required
+// fields may only be missing when requireFields is not set.
+func customFieldValues(values map[string]any, requireFields bool) (model.CustomFieldValues, string, error) {
+	fields, err := customFields()
+	if err != nil {
+		return nil, "", err
+	}
+
+	byKey := map[string]model.CustomField{}
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	result := model.CustomFieldValues{}
+	for key, value := range values {
+		field, ok := byKey[key]
+		if !ok {
+			return nil, "unknown custom field: " + key, nil
+		}
+		if value == nil {
+			continue
+		}
+
+		normalized, msg, err := customFieldValue(field, value)
+		if err != nil || msg != "" {
+			return nil, msg, err
+		}
+		result[key] = normalized
+	}
+
+	for _, field := range fields {
+		if _, ok := result[field.Key]; requireFields && field.Required && !ok {
+			return nil, "custom field '" + field.Key + "' is required", nil
+		}
+	}
+
+	return result, "", nil
+}
+
+// customFieldValue checks one value, numbers come as float64
+// or json.Number and users as their auth ID
+func customFieldValue(field model.CustomField, value any) (normalized any, msg string, err error) {
+	invalid := "custom field '" + field.Key + "' must be "
+
+	switch field.Type {
+	case model.FieldText:
+		s, ok := value.(string)
+		if !ok || len([]rune(s)) > model.CustomFieldTextMaxLength {
+			return nil, invalid + "a text of up to " + strconv.Itoa(model.CustomFieldTextMaxLength) + " characters", nil
+		}
+		return s, "", nil
+
+	case model.FieldNumber:
+		f, ok := customFieldNumber(value)
+		if !ok {
+			return nil, invalid + "a number", nil
+		}
+		return f, "", nil
+
+	case model.FieldEnum:
+		s, ok := value.(string)
+		if !ok || !containsString(field.Options, s) {
+			return nil, invalid + "one of " + strings.Join(field.Options, ", "), nil
+		}
+		return s, "", nil
+
+	case model.FieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid + "a date (YYYY-MM-DD)", nil
+		}
+		date, err := time.Parse(model.CustomFieldDateLayout, s)
+		if err != nil {
+			return nil, invalid + "a date (YYYY-MM-DD)", nil
+		}
+		return date.Format(model.CustomFieldDateLayout), "", nil
+
+	case model.FieldUser:
+		f, ok := customFieldNumber(value)
+		if !ok || f < 1 || f != float64(uint64(f)) {
+			return nil, invalid + "the auth ID of a user", nil
+		}
+		if err := database.GetDB().First(&model.Auth{}, uint64(f)).Error; err != nil {
+			if err.Error() != database.RecordNotFound {
+				return nil, "", err
+			}
+			return nil, invalid + "the auth ID of a user", nil
+		}
+		return uint64(f), "", nil
+	}
+
+	return nil, "custom field '" + field.Key + "' has an unknown type", nil
+}
+
+// customFieldFilters validates the list filters and converts
+// the values to the text the database returns for them
+func customFieldFilters(filters map[string]string) (map[string]string, string, error) {
+	if len(filters) == 0 {
+		return filters, "", nil
+	}
+
+	fields, err := customFields()
+	if err != nil {
+		return nil, "", err
+	}
+
+	byKey := map[string]model.CustomField{}
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	result := map[string]string{}
+	for key, value := range filters {
+		field, ok := byKey[key]
+		if !ok {
+			return nil, "unknown custom field: " + key, nil
+		}
+
+		var raw any = value
+		if field.Type == model.FieldNumber || field.Type == model.FieldUser {
+			raw = json.Number(value)
+		}
+
+		normalized, msg, err := customFieldValue(field, raw)
+		if err != nil || msg != "" {
+			return nil, msg, err
+		}
+
+		switch v := normalized.(type) {
+		case float64:
+			result[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		case uint64:
+			result[key] = strconv.FormatUint(v, 10)
+		default:
+			result[key] = v.(string)
+		}
+	}
+
+	return result, "", nil
+}
+
+// customFieldColumn extracts one value as text, keys are
+// validated on definition so they are safe to inline
+func customFieldColumn(dialect, key string) string {
+	switch dialect {
+	case "postgres":
+		return "custom_fields->>'" + key + "'"
+	case "mysql":
+		return "JSON_UNQUOTE(JSON_EXTRACT(custom_fields, '$." + key + "'))"
+	default:
+		return "CAST(json_extract(custom_fields, '$." + key + "') AS TEXT)"
+	}
+}
+
+func customFieldNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func customFields() ([]model.CustomField, error) {
+	fields := []model.CustomField{}
+	err := database.GetDB().Order("custom_fields.key").Find(&fields).Error
+	return fields, err
+}
+
+func validateCustomFieldReq(req *model.CustomFieldReq) string {
+	req.Key = strings.TrimSpace(req.Key)
+	req.Label = strings.TrimSpace(req.Label)
+
+	if !customFieldKey.MatchString(req.Key) {
+		return "key must start with a lowercase letter followed by up to 63 lowercase letters, digits or _"
+	}
+	if req.Label == "" {
+		return "label is required"
+	}
+	if !req.Type.Valid() {
+		return "type must be text, number, enum, date or user"
+	}
+
+	if req.Type != model.FieldEnum {
+		if len(req.Options) > 0 {
+			return "only enum fields have options"
+		}
+		req.Options = nil
+		return ""
+	}
+
+	if len(req.Options) == 0 {
+		return "enum fields need at least one option"
+	}
+	seen := map[string]bool{}
+	for i, option := range req.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[option] {
+			return "enum options must be unique and not empty"
+		}
+		seen[option] = true
+		req.Options[i] = option
+	}
+
+	return ""
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
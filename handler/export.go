@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// exportFlushEvery - number of rows after which buffered output is sent
+const exportFlushEvery = 500
+
+// IncidentExport - a validated incident export ready to be streamed
+type IncidentExport struct {
+	Format  string
+	Columns []string
+	filter  model.IncidentFilter
+}
+
+// PrepareIncidentExport validates the format, the selected columns and
+// the list filters. An empty selection exports all columns.
+func PrepareIncidentExport(filter model.IncidentFilter, format, columns string) (export *IncidentExport, httpResponse model.HTTPResponse, httpStatusCode int) {
+	if format == "" {
+		format = model.ExportCSV
+	}
+	if format != model.ExportCSV && format != model.ExportJSONL {
+		httpResponse, httpStatusCode = setErrorMessage("format must be csv or jsonl", http.StatusBadRequest)
+		return
+	}
+
+	selected := model.IncidentExportColumns
+	if strings.TrimSpace(columns) != "" {
+		known := make(map[string]bool, len(model.IncidentExportColumns))
+		for _, column := range model.IncidentExportColumns {
+			known[column] = true
+		}
+
+		selected = []string{}
+		seen := map[string]bool{}
+		for _, column := range strings.Split(columns, ",") {
+			column = strings.TrimSpace(column)
+			if column == "" || seen[column] {
+				continue
+			}
+			if !known[column] {
+				httpResponse, httpStatusCode = setErrorMessage("unknown column: "+column, http.StatusBadRequest)
+				return
+			}
+			seen[column] = true
+			selected = append(selected, column)
+		}
+	}
+
+	customFields, msg, err := customFieldFilters(filter.CustomFields)
+	if err != nil {
+		log.WithError(err).Error("error code: 3802.1")
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
+	}
+	if msg != "" {
+		httpResponse, httpStatusCode = setErrorMessage(msg, http.StatusBadRequest)
+		return
+	}
+	filter.CustomFields = customFields
+
+	export = &IncidentExport{Format: format, Columns: selected, filter: filter}
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// ContentType returns the media type of the export
+func (e *IncidentExport) ContentType() string {
+	if e.Format == model.ExportJSONL {
+		return "application/x-ndjson; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8; header=present"
+}
+
+// Write streams the matching incidents row by row from a database
+// cursor, oldest first. Output is flushed periodically so memory use
+// does not grow with the size of the export.
+func (e *IncidentExport) Write(w io.Writer) error {
+	db := database.GetDB()
+
+	out, err := service.NewRecordWriter(w, e.Format, e.Columns)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Model(&model.Incident{}).
+		Scopes(incidentFilterScope(e.filter)).
+		Select(e.Columns).
+		Order("incident_id").
+		Rows()
+	if err != nil {
+		log.WithError(err).Error("error code: 3801.1")
+		return err
+	}
+	defer rows.Close()
+
+	flusher, _ := w.(http.Flusher)
+	values := make([]any, len(e.Columns))
+
+	for n := 1; rows.Next(); n++ {
+		var incident model.Incident
+		if err := db.ScanRows(rows, &incident); err != nil {
+			log.WithError(err).Error("error code: 3801.2")
+			return err
+		}
+
+		for i, column := range e.Columns {
+			values[i] = incidentColumnValue(incident, column)
+		}
+
+		if err := out.WriteRecord(values); err != nil {
+			return err
+		}
+
+		if n%exportFlushEvery == 0 {
+			if err := out.Flush(); err != nil {
+				return err
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Error("error code: 3801.3")
+		return err
+	}
+
+	return out.Flush()
+}
+
+// incidentColumnValue returns the value of one export column
+func incidentColumnValue(incident model.Incident, column string) any {
+	switch column {
+	case "incident_id":
+		return incident.IncidentID
+	case "title":
+		return incident.Title
+	case "description":
+		return incident.Description
+	case "status":
+		return string(incident.Status)
+	case "severity":
+		return string(incident.Severity)
+	case "impact":
+		return string(incident.Impact)
+	case "urgency":
+		return string(incident.Urgency)
+	case "priority":
+		return string(incident.Priority)
+	case "auth_id":
+		return incident.AuthID
+	case "assigned_to":
+		return incident.AssignedTo
+	case "team_id":
+		return incident.TeamID
+	case "created_at":
+		return incident.CreatedAt.UTC()
+	case "updated_at":
+		return incident.UpdatedAt.UTC()
+	case "acknowledged_at":
+		return utcOrNil(incident.AcknowledgedAt)
+	case "resolved_at":
+		return utcOrNil(incident.ResolvedAt)
+	}
+	return nil
+}
+
+// utcOrNil converts an optional timestamp to UTC
+func utcOrNil(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,418 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// StartHeartbeatChecker opens incidents for missed heartbeats at the
+// configured interval. It blocks, run it in its own goroutine.
+func StartHeartbeatChecker() {
+	interval := time.Duration(config.GetConfig().Monitor.HeartbeatCheckInterval) * time.Second
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := CheckHeartbeats(time.Now()); err != nil {
+			log.WithError(err).Error("error code: 4810.1")
+		}
+		<-ticker.C
+	}
+}
+
+// CheckHeartbeats raises an alert once per miss of a heartbeat, which
+// opens an incident unless a maintenance window suppresses it. A
+// suppressed miss is raised again once the window is over, so an
+// incident still opens if no ping came by then. It returns the number
+// of missed heartbeats.
+func CheckHeartbeats(now time.Time) (int, error) {
+	db := database.GetDB()
+
+	// the miss is only recorded if no ping came while raising it
+	missedQuery := "due_at < ? AND (missed_at IS NULL OR suppressed_until <= ?)"
+
+	var heartbeats []model.Heartbeat
+
+	if err := db.Where(missedQuery, now, now).Find(&heartbeats).Error; err != nil {
+		return 0, err
+	}
+
+	missed := 0
+	for _, heartbeat := range heartbeats {
+		description := "no ping since " + heartbeat.CreatedAt.UTC().Format(time.RFC3339)
+		if heartbeat.LastPingAt != nil {
+			description = "last ping at " + heartbeat.LastPingAt.UTC().Format(time.RFC3339)
+		}
+		description += ", expected every " + (time.Duration(heartbeat.Interval) * time.Second).String() +
+			" with " + (time.Duration(heartbeat.Grace) * time.Second).String() + " grace"
+
+		alert, err := ingestAlert(model.AlertReq{
+			Source:      "heartbeat",
+			Title:       "Heartbeat '" + heartbeat.Name + "' missed",
+			Description: description,
+			Severity:    heartbeat.Severity,
+			ServiceIDs:  heartbeat.ServiceIDs,
+			AssignedTo:  heartbeat.AssignedTo,
+		}, heartbeat.AuthID)
+		if err != nil {
+			log.WithError(err).WithField("heartbeatID", heartbeat.HeartbeatID).Error("error code: 4810.2")
+			continue
+		}
+
+		result := db.Model(&model.Heartbeat{HeartbeatID: heartbeat.HeartbeatID}).Where(missedQuery, now, now).UpdateColumns(map[string]any{
+			"missed_at":        now,
+			"incident_id":      alert.IncidentID,
+			"suppressed_until": alert.SuppressedUntil,
+		})
+		if result.Error != nil {
+			log.WithError(result.Error).Error("error code: 4810.3")
+			continue
+		}
+
+		// a ping came in the meantime, the incident is already over
+		if result.RowsAffected == 0 {
+			if alert.IncidentID != nil {
+				resolveMissedHeartbeat(heartbeat, *alert.IncidentID, now)
+			}
+			continue
+		}
+		missed++
+	}
+
+	return missed, nil
+}
+
+// resolveMissedHeartbeat closes the incident of a miss which a ping
+// overtook. The alert was already recorded, so failures are only logged.
+func resolveMissedHeartbeat(heartbeat model.Heartbeat, incidentID uint64, now time.Time) {
+	tx := database.GetDB().Begin()
+	if err := resolveMonitorIncident(tx, incidentID, "heartbeat '"+heartbeat.Name+"' pinged again", now); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4810.4")
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4810.5")
+	}
+}
+
+// PingHeartbeat records a ping by the heartbeat's secret and resolves
+// the incident of a miss
+func PingHeartbeat(token string) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	tokenHash, err := service.CalcHash([]byte(strings.TrimSpace(token)), config.GetConfig().Security.Blake2bSec)
+	if err != nil {
+		log.WithError(err).Error("error code: 4801.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	var heartbeat model.Heartbeat
+
+	if err := db.Where("token_hash = ?", hex.EncodeToString(tokenHash)).First(&heartbeat).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 4801.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("heartbeat not found", http.StatusNotFound)
+	}
+
+	now := time.Now()
+
+	tx := db.Begin()
+	err = tx.Model(&model.Heartbeat{HeartbeatID: heartbeat.HeartbeatID}).UpdateColumns(map[string]any{
+		"last_ping_at":     now,
+		"due_at":           heartbeatDue(heartbeat, now),
+		"missed_at":        nil,
+		"incident_id":      nil,
+		"suppressed_until": nil,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4801.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if heartbeat.IncidentID != nil {
+		if err := resolveMonitorIncident(tx, *heartbeat.IncidentID, "heartbeat '"+heartbeat.Name+"' pinged again", now); err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 4801.4")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4801.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "heartbeat received"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func GetHeartbeats() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	heartbeats := []model.Heartbeat{}
+
+	if err := db.Order("heartbeats.name").Find(&heartbeats).Error; err != nil {
+		log.WithError(err).Error("error code: 4802.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	now := time.Now()
+	for i := range heartbeats {
+		heartbeats[i].FillStatus(now)
+	}
+
+	httpResponse.Message = heartbeats
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func GetHeartbeat(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	heartbeat, resp, code, ok := findHeartbeat(id, "4803.1")
+	if !ok {
+		return resp, code
+	}
+
+	heartbeat.FillStatus(time.Now())
+
+	httpResponse.Message = heartbeat
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// CreateHeartbeat adds a heartbeat, the first ping is due within its
+// interval and grace period. The secret to ping with is only returned
+// here and when it is rotated.
+func CreateHeartbeat(req model.HeartbeatReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if req.AssignedTo == 0 {
+		req.AssignedTo = authID
+	}
+	if resp, code, ok := validateHeartbeatReq(&req, "4804.1"); !ok {
+		return resp, code
+	}
+
+	token, tokenHash, err := heartbeatSecret()
+	if err != nil {
+		log.WithError(err).Error("error code: 4804.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	now := time.Now()
+	heartbeat := model.Heartbeat{
+		Name:       req.Name,
+		TokenHash:  tokenHash,
+		AuthID:     authID,
+		Interval:   req.Interval,
+		Grace:      req.Grace,
+		Severity:   req.Severity,
+		AssignedTo: req.AssignedTo,
+		ServiceIDs: req.ServiceIDs,
+	}
+	heartbeat.DueAt = heartbeatDue(heartbeat, now)
+
+	if err := db.Create(&heartbeat).Error; err != nil {
+		log.WithError(err).Error("error code: 4804.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	heartbeat.FillStatus(now)
+	heartbeat.Token = token
+
+	httpResponse.Message = heartbeat
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// UpdateHeartbeat replaces the settings of a heartbeat, a pending
+// deadline moves with the new interval and grace period
+func UpdateHeartbeat(id uint64, req model.HeartbeatReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	heartbeat, resp, code, ok := findHeartbeat(id, "4805.1")
+	if !ok {
+		return resp, code
+	}
+	if resp, code, ok := requireHeartbeatOwner(heartbeat, authID); !ok {
+		return resp, code
+	}
+
+	if req.AssignedTo == 0 {
+		req.AssignedTo = heartbeat.AssignedTo
+	}
+	if resp, code, ok := validateHeartbeatReq(&req, "4805.2"); !ok {
+		return resp, code
+	}
+
+	heartbeat.Name = req.Name
+	heartbeat.Interval = req.Interval
+	heartbeat.Grace = req.Grace
+	heartbeat.Severity = req.Severity
+	heartbeat.AssignedTo = req.AssignedTo
+	heartbeat.ServiceIDs = req.ServiceIDs
+
+	if heartbeat.MissedAt == nil {
+		from := heartbeat.CreatedAt
+		if heartbeat.LastPingAt != nil {
+			from = *heartbeat.LastPingAt
+		}
+		heartbeat.DueAt = heartbeatDue(heartbeat, from)
+	}
+
+	if err := db.Save(&heartbeat).Error; err != nil {
+		log.WithError(err).Error("error code: 4805.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	heartbeat.FillStatus(time.Now())
+
+	httpResponse.Message = heartbeat
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// RotateHeartbeatToken issues a new secret, the previous one stops working
+func RotateHeartbeatToken(id uint64, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	heartbeat, resp, code, ok := findHeartbeat(id, "4806.1")
+	if !ok {
+		return resp, code
+	}
+	if resp, code, ok := requireHeartbeatOwner(heartbeat, authID); !ok {
+		return resp, code
+	}
+
+	token, tokenHash, err := heartbeatSecret()
+	if err != nil {
+		log.WithError(err).Error("error code: 4806.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := db.Model(&model.Heartbeat{HeartbeatID: id}).UpdateColumn("token_hash", tokenHash).Error; err != nil {
+		log.WithError(err).Error("error code: 4806.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	heartbeat.FillStatus(time.Now())
+	heartbeat.Token = token
+
+	httpResponse.Message = heartbeat
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func DeleteHeartbeat(id uint64, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	heartbeat, resp, code, ok := findHeartbeat(id, "4807.2")
+	if !ok {
+		return resp, code
+	}
+	if resp, code, ok := requireHeartbeatOwner(heartbeat, authID); !ok {
+		return resp, code
+	}
+
+	if err := db.Delete(&heartbeat).Error; err != nil {
+		log.WithError(err).Error("error code: 4807.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "heartbeat deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// heartbeatDue - deadline of the next ping after from
+func heartbeatDue(heartbeat model.Heartbeat, from time.Time) time.Time {
+	return from.Add(time.Duration(heartbeat.Interval+heartbeat.Grace) * time.Second)
+}
+
+// heartbeatSecret returns a new ping secret and its stored hash
+func heartbeatSecret() (token, tokenHash string, err error) {
+	secret := make([]byte, 32)
+	if _, err = rand.Read(secret); err != nil {
+		return
+	}
+	token = hex.EncodeToString(secret)
+
+	hash, err := service.CalcHash([]byte(token), config.GetConfig().Security.Blake2bSec)
+	if err != nil {
+		return
+	}
+	tokenHash = hex.EncodeToString(hash)
+	return
+}
+
+// requireHeartbeatOwner lets the creator of a heartbeat or an
+// administrator change it
+func requireHeartbeatOwner(heartbeat model.Heartbeat, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	if heartbeat.AuthID != authID && !config.IsAdmin(authID) {
+		httpResponse, httpStatusCode = setErrorMessage("only the creator of the heartbeat or an administrator can change it", http.StatusForbidden)
+		return
+	}
+
+	ok = true
+	return
+}
+
+func findHeartbeat(id uint64, errCode string) (heartbeat model.Heartbeat, httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	db := database.GetDB()
+
+	if err := db.First(&heartbeat, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("heartbeat not found", http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
+
+// validateHeartbeatReq checks the settings and that the assignee and
+// services of the incident exist
+func validateHeartbeatReq(req *model.HeartbeatReq, errCode string) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Severity == "" {
+		req.Severity = model.SeverityType(config.GetConfig().Monitor.HeartbeatSeverity)
+	}
+	if req.ServiceIDs == nil {
+		req.ServiceIDs = []uint64{}
+	}
+
+	msg := ""
+	switch {
+	case req.Name == "":
+		msg = "name is required"
+	case req.Interval < model.HeartbeatIntervalMin || req.Interval > model.HeartbeatIntervalMax:
+		msg = "interval must be 10 seconds to 31 days"
+	case req.Grace < 0 || req.Grace > model.HeartbeatIntervalMax:
+		msg = "grace must be 0 seconds to 31 days"
+	case !req.Severity.Valid():
+		msg = "severity must be low, medium, high or critical"
+	}
+	if msg != "" {
+		httpResponse, httpStatusCode = setErrorMessage(msg, http.StatusBadRequest)
+		return
+	}
+
+	return monitorTargetsExist(req.AssignedTo, req.ServiceIDs, errCode)
+}
@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// importTimeLayouts - accepted timestamp formats, without a zone UTC is assumed
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	time.DateOnly,
+}
+
+// ImportIncidents loads historical incidents from CSV or JSON. Every row
+// is validated first; rows are only inserted when all of them are valid,
+// in batches within one transaction. A dry run only validates.
+func ImportIncidents(body io.Reader, format, mapping string, dryRun bool, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	columnMapping, err := service.ParseImportMapping(mapping)
+	if err != nil {
+		return setErrorMessage(err.Error(), http.StatusBadRequest)
+	}
+	for _, target := range columnMapping {
+		if !isImportField(target) {
+			return setErrorMessage("unknown import field: "+target, http.StatusBadRequest)
+		}
+	}
+
+	records, err := service.ParseImportRecords(body, format, columnMapping, model.ImportMaxRows)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return setErrorMessage("import is limited to 32 MiB", http.StatusRequestEntityTooLarge)
+		}
+		return setErrorMessage(err.Error(), http.StatusBadRequest)
+	}
+	if len(records) == 0 {
+		return setErrorMessage("nothing to import", http.StatusBadRequest)
+	}
+
+	importer := newIncidentImporter(authID)
+	incidents := make([]model.Incident, 0, len(records))
+
+	for i, record := range records {
+		incident, rowErrors := importer.incident(i+1, record)
+		if len(rowErrors) > 0 {
+			importer.errors = append(importer.errors, rowErrors...)
+			continue
+		}
+		incidents = append(incidents, incident)
+	}
+	if importer.failed {
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	result := model.ImportResult{
+		DryRun: dryRun,
+		Total:  len(records),
+		Valid:  len(incidents),
+		Errors: importer.errors,
+	}
+
+	if len(result.Errors) > 0 {
+		httpResponse.Message = result
+		httpStatusCode = http.StatusUnprocessableEntity
+		return
+	}
+
+	if dryRun {
+		httpResponse.Message = result
+		httpStatusCode = http.StatusOK
+		return
+	}
+
+	tx := db.Begin()
+	if err := tx.CreateInBatches(&incidents, model.ImportBatchSize).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3901.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	events := make([]model.IncidentEvent, 0, len(incidents))
+	for _, incident := range incidents {
+		events = append(events, model.IncidentEvent{
+			CreatedAt:  incident.CreatedAt,
+			IncidentID: incident.IncidentID,
+			AuthID:     authID,
+			Type:       model.EventImported,
+			Message:    "incident imported",
+		})
+	}
+	if err := tx.CreateInBatches(&events, model.ImportBatchSize).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3901.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3901.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	result.Imported = len(incidents)
+
+	httpResponse.Message = result
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// incidentImporter validates rows and caches lookups across rows
+type incidentImporter struct {
+	authID uint64
+	now    time.Time
+	users  map[string]uint64 // email => auth ID, 0 when unknown
+	ids    map[uint64]bool   // auth ID => exists
+	teams  map[uint64]string // team ID => error message, empty when allowed
+	errors []model.ImportRowError
+	failed bool // database error
+}
+
+func newIncidentImporter(authID uint64) *incidentImporter {
+	return &incidentImporter{
+		authID: authID,
+		now:    time.Now(),
+		users:  map[string]uint64{},
+		ids:    map[uint64]bool{},
+		teams:  map[uint64]string{},
+		errors: []model.ImportRowError{},
+	}
+}
+
+// incident converts one record into an incident
+func (im *incidentImporter) incident(row int, record map[string]string) (model.Incident, []model.ImportRowError) {
+	rowErrors := []model.ImportRowError{}
+	fail := func(field, message string) {
+		rowErrors = append(rowErrors, model.ImportRowError{Row: row, Field: field, Message: message})
+	}
+	value := func(field string) string {
+		return strings.TrimSpace(record[field])
+	}
+
+	incident := model.Incident{
+		Title:       value("title"),
+		Description: record["description"],
+		Status:      model.StatusType(strings.ToLower(value("status"))),
+		Severity:    model.SeverityType(strings.ToLower(value("severity"))),
+		AuthID:      im.authID,
+	}
+
+	if incident.Title == "" {
+		fail("title", "title is required")
+	}
+
+	if incident.Status == "" {
+		incident.Status = model.Open
+	}
+	if incident.Status != model.Open && incident.Status != model.Acknowledged && incident.Status != model.Closed {
+		fail("status", "status must be open, acknowledged or closed")
+	}
+
+	if incident.Severity == "" {
+		incident.Severity = model.Medium
+	}
+	if incident.Severity != model.Low && incident.Severity != model.Medium &&
+		incident.Severity != model.High && incident.Severity != model.Critical {
+		fail("severity", "severity must be low, medium, high or critical")
+	}
+
+	// assignee by ID or by email
+	switch {
+	case value("assigned_to") != "":
+		id, err := strconv.ParseUint(value("assigned_to"), 10, 64)
+		if err != nil || !im.userExists(id) {
+			fail("assigned_to", "assigned user not found")
+		}
+		incident.AssignedTo = id
+	case value("assignee_email") != "":
+		incident.AssignedTo = im.userByEmail(value("assignee_email"))
+		if incident.AssignedTo == 0 {
+			fail("assignee_email", "no user with this email")
+		}
+	default:
+		fail("assigned_to", "assigned_to or assignee_email is required")
+	}
+
+	if email := value("creator_email"); email != "" {
+		incident.AuthID = im.userByEmail(email)
+		if incident.AuthID == 0 {
+			fail("creator_email", "no user with this email")
+		}
+	}
+
+	if value("team_id") != "" {
+		teamID, err := strconv.ParseUint(value("team_id"), 10, 64)
+		if err != nil {
+			fail("team_id", "invalid team ID")
+		} else if msg := im.teamAllowed(teamID); msg != "" {
+			fail("team_id", msg)
+		} else {
+			incident.TeamID = &teamID
+		}
+	}
+
+	// original timestamps are preserved
+	timestamps := map[string]*time.Time{}
+	for _, field := range []string{"created_at", "updated_at", "acknowledged_at", "resolved_at"} {
+		if value(field) == "" {
+			continue
+		}
+		t, ok := parseImportTime(value(field))
+		if !ok {
+			fail(field, "invalid timestamp")
+			continue
+		}
+		timestamps[field] = &t
+	}
+
+	incident.CreatedAt = im.now
+	if t := timestamps["created_at"]; t != nil {
+		incident.CreatedAt = *t
+	}
+	incident.UpdatedAt = incident.CreatedAt
+	if t := timestamps["updated_at"]; t != nil {
+		incident.UpdatedAt = *t
+	}
+	incident.AcknowledgedAt = timestamps["acknowledged_at"]
+	incident.ResolvedAt = timestamps["resolved_at"]
+
+	if incident.AcknowledgedAt != nil && incident.AcknowledgedAt.Before(incident.CreatedAt) {
+		fail("acknowledged_at", "acknowledged before creation")
+	}
+	if incident.ResolvedAt != nil {
+		if incident.ResolvedAt.Before(incident.CreatedAt) {
+			fail("resolved_at", "resolved before creation")
+		}
+		if incident.Status != model.Closed {
+			fail("resolved_at", "only closed incidents can be resolved")
+		}
+	}
+
+	return incident, rowErrors
+}
+
+// userByEmail resolves an email, honouring encryption at rest
+func (im *incidentImporter) userByEmail(email string) uint64 {
+	if id, ok := im.users[email]; ok {
+		return id
+	}
+
+	var id uint64
+	auth, err := service.GetUserByEmail(email, false)
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3902.1")
+		im.failed = true
+	}
+	if err == nil && auth != nil {
+		id = auth.AuthID
+	}
+
+	im.users[email] = id
+	return id
+}
+
+// userExists checks an auth ID
+func (im *incidentImporter) userExists(id uint64) bool {
+	if exists, ok := im.ids[id]; ok {
+		return exists
+	}
+
+	err := database.GetDB().First(&model.Auth{}, id).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3902.2")
+		im.failed = true
+	}
+
+	im.ids[id] = err == nil
+	return err == nil
+}
+
+// teamAllowed checks the team exists and the importer may file
+// incidents for it, returning an error message otherwise
+func (im *incidentImporter) teamAllowed(teamID uint64) string {
+	if msg, ok := im.teams[teamID]; ok {
+		return msg
+	}
+
+	msg := ""
+	if err := database.GetDB().First(&model.Team{}, teamID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3902.3")
+			im.failed = true
+		}
+		msg = "team not found"
+	} else {
+		allowed, err := hasTeamPermission(im.authID, teamID, model.PermIncidentEdit)
+		if err != nil {
+			log.WithError(err).Error("error code: 3902.4")
+			im.failed = true
+		}
+		if !allowed {
+			msg = "permission '" + string(model.PermIncidentEdit) + "' required in this team"
+		}
+	}
+
+	im.teams[teamID] = msg
+	return msg
+}
+
+// parseImportTime parses a timestamp in one of the accepted layouts
+func parseImportTime(s string) (time.Time, bool) {
+	for _, layout := range importTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isImportField returns true for a known import field
+func isImportField(field string) bool {
+	for _, f := range model.ImportFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
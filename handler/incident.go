@@ -2,15 +2,43 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Dhar01/incident_resp/internal/database"
 	"github.com/Dhar01/incident_resp/internal/model"
 
 	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 func CreateIncident(incident model.IncidentReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	newIncident, resp, code, ok := buildIncident(incident, authID, true)
+	if !ok {
+		return resp, code
+	}
+
+	tx := database.GetDB().Begin()
+	if resp, code, ok := insertIncident(tx, &newIncident, authID); !ok {
+		tx.Rollback()
+		return resp, code
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 2001.12")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	incidentCreated(&newIncident, authID)
+
+	httpResponse.Message = newIncident
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// buildIncident validates a new incident. Incidents the system opens on
+// its own skip the required custom fields, nobody is there to fill them.
+func buildIncident(incident model.IncidentReq, authID uint64, requireFields bool) (newIncident model.Incident, httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
 	db := database.GetDB()
 
 	if incident.Title == "" || incident.Status == "" || incident.Severity == "" {
@@ -22,10 +50,52 @@ func CreateIncident(incident model.IncidentReq, authID uint64) (httpResponse mod
 	// check if assignee exists
 	if err := db.First(&model.Auth{}, incident.AssignedTo).Error; err != nil {
 		log.WithError(err).Error("error code: 2001.1")
-		return setErrorMessage("assigned user not found", http.StatusNotFound)
+		httpResponse, httpStatusCode = setErrorMessage("assigned user not found", http.StatusNotFound)
+		return
 	}
 
-	newIncident := model.Incident{
+	// only members allowed to edit the team's incidents may file for it
+	if incident.TeamID != 0 {
+		if err := db.First(&model.Team{}, incident.TeamID).Error; err != nil {
+			log.WithError(err).Error("error code: 2001.4")
+			httpResponse, httpStatusCode = setErrorMessage("team not found", http.StatusNotFound)
+			return
+		}
+		if httpResponse, httpStatusCode, ok = requireTeamPermission(authID, incident.TeamID, model.PermIncidentEdit, "2001.5"); !ok {
+			return
+		}
+	}
+
+	// check if impacted services exist
+	services, err := findServices(incident.ServiceIDs)
+	if err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 2001.3")
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("impacted service not found", http.StatusNotFound)
+		return
+	}
+
+	tags, msg := parseTags(incident.Tags)
+	if msg != "" {
+		httpResponse, httpStatusCode = setErrorMessage(msg, http.StatusBadRequest)
+		return
+	}
+
+	customFields, msg, err := customFieldValues(incident.CustomFields, requireFields)
+	if err != nil {
+		log.WithError(err).Error("error code: 2001.8")
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
+	}
+	if msg != "" {
+		httpResponse, httpStatusCode = setErrorMessage(msg, http.StatusBadRequest)
+		return
+	}
+
+	newIncident = model.Incident{
 		Title:       incident.Title,
 		Description: incident.Description,
 		Status:      incident.Status,
@@ -33,19 +103,79 @@ func CreateIncident(incident model.IncidentReq, authID uint64) (httpResponse mod
 		AssignedTo:  incident.AssignedTo,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
+		Services:    services,
+		Tags:        tags,
+
+		CustomFields: customFields,
+	}
+	newIncident.TrackStatus(newIncident.CreatedAt)
+
+	if msg := setPriority(&newIncident, incident.Impact, incident.Urgency, incident.Priority); msg != "" {
+		httpResponse, httpStatusCode = setErrorMessage(msg, http.StatusBadRequest)
+		return
 	}
 
-	if err := db.Create(&newIncident).Error; err != nil {
+	policies, err := slaPolicies(db)
+	if err != nil {
+		log.WithError(err).Error("error code: 2001.10")
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
+	}
+	if _, err := applySLA(&newIncident, policies); err != nil {
+		log.WithError(err).Error("error code: 2001.11")
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
+	}
+
+	if incident.TeamID != 0 {
+		newIncident.TeamID = &incident.TeamID
+	}
+
+	ok = true
+	return
+}
+
+// insertIncident stores a new incident with its tags and timeline
+// within the transaction of the caller
+func insertIncident(tx *gorm.DB, newIncident *model.Incident, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	if err := saveTags(tx, newIncident.Tags); err != nil {
+		log.WithError(err).Error("error code: 2001.7")
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Create(newIncident).Error; err != nil {
 		log.WithError(err).Error("error code: 2001.2")
-		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
 	}
 
-	httpResponse.Message = newIncident
-	httpStatusCode = http.StatusOK
+	if err := recordEvent(tx, newIncident.IncidentID, authID, model.EventCreated, "incident created"); err != nil {
+		log.WithError(err).Error("error code: 2001.6")
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
+	}
+	if message := priorityMessage("", false, *newIncident); message != "" {
+		if err := recordEvent(tx, newIncident.IncidentID, authID, model.EventPriorityChanged, message); err != nil {
+			log.WithError(err).Error("error code: 2001.9")
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	ok = true
 	return
 }
 
-func UpdateIncident(incident model.IncidentUpdate, incidentID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+// incidentCreated runs the automation of a committed new incident
+func incidentCreated(newIncident *model.Incident, authID uint64) {
+	automateIncident(newIncident, model.AutomationCreated, authID)
+	attachMatchingPlaybooks(newIncident.IncidentID)
+
+	newIncident.FillSLA(time.Now())
+}
+
+func UpdateIncident(incident model.IncidentUpdate, incidentID, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
 	db := database.GetDB()
 
 	if incident.IncidentID == 0 {
@@ -59,50 +189,308 @@ func UpdateIncident(incident model.IncidentUpdate, incidentID uint64) (httpRespo
 		return setErrorMessage("incident not found", http.StatusNotFound)
 	}
 
+	if existing.DuplicateOf != nil {
+		return setErrorMessage("incident was merged into incident #"+strconv.FormatUint(*existing.DuplicateOf, 10), http.StatusConflict)
+	}
+
+	// a team can only edit its own incidents
+	if existing.TeamID != nil {
+		if resp, code, ok := requireTeamPermission(authID, *existing.TeamID, model.PermIncidentEdit, "2002.5"); !ok {
+			return resp, code
+		}
+	}
+
+	// handing over to another team requires edit permission there too
+	if incident.TeamID != nil && *incident.TeamID != 0 && (existing.TeamID == nil || *existing.TeamID != *incident.TeamID) {
+		if err := db.First(&model.Team{}, *incident.TeamID).Error; err != nil {
+			log.WithError(err).Error("error code: 2002.6")
+			return setErrorMessage("team not found", http.StatusNotFound)
+		}
+		if resp, code, ok := requireTeamPermission(authID, *incident.TeamID, model.PermIncidentEdit, "2002.7"); !ok {
+			return resp, code
+		}
+	}
+
+	var tags []model.Tag
+	if incident.Tags != nil {
+		var msg string
+		if tags, msg = parseTags(incident.Tags); msg != "" {
+			return setErrorMessage(msg, http.StatusBadRequest)
+		}
+	}
+
+	// replace custom fields only when they are provided
+	if incident.CustomFields != nil {
+		customFields, msg, err := customFieldValues(incident.CustomFields, true)
+		if err != nil {
+			log.WithError(err).Error("error code: 2002.11")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		if msg != "" {
+			return setErrorMessage(msg, http.StatusBadRequest)
+		}
+		existing.CustomFields = customFields
+	}
+
+	previousPriority, wasOverride := existing.Priority, existing.PriorityOverride
+
+	// keep the priority when none of its inputs is provided,
+	// and impact and urgency when only the priority is
+	if incident.Impact != "" || incident.Urgency != "" || incident.Priority != "" {
+		impact, urgency := incident.Impact, incident.Urgency
+		if impact == "" && urgency == "" {
+			impact, urgency = existing.Impact, existing.Urgency
+		}
+		if msg := setPriority(&existing, impact, urgency, incident.Priority); msg != "" {
+			return setErrorMessage(msg, http.StatusBadRequest)
+		}
+	}
+
+	previousStatus := existing.Status
+
 	// Update fields
 	existing.Title = incident.Title
 	existing.Description = incident.Description
 	existing.Status = incident.Status
 	existing.Severity = incident.Severity
 	existing.AssignedTo = incident.AssignedTo
+	// change the owning team only when it is provided, 0 removes it
+	if incident.TeamID != nil {
+		existing.TeamID = nil
+		if *incident.TeamID != 0 {
+			existing.TeamID = incident.TeamID
+		}
+	}
 	existing.UpdatedAt = time.Now()
+	existing.TrackStatus(existing.UpdatedAt)
+
+	// severity and priority pick the SLA policy
+	policies, err := slaPolicies(db)
+	if err != nil {
+		log.WithError(err).Error("error code: 2002.13")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if _, err := applySLA(&existing, policies); err != nil {
+		log.WithError(err).Error("error code: 2002.14")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	// replace impacted services only when they are provided
+	var services []model.Service
+	if incident.ServiceIDs != nil {
+		if services, err = findServices(incident.ServiceIDs); err != nil {
+			if err.Error() != database.RecordNotFound {
+				log.WithError(err).Error("error code: 2002.3")
+				return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			}
+			return setErrorMessage("impacted service not found", http.StatusNotFound)
+		}
+	}
 
-	if err := db.Save(&existing).Error; err != nil {
+	tx := db.Begin()
+	if err := tx.Save(&existing).Error; err != nil {
+		tx.Rollback()
 		log.WithError(err).Error("error code: 2002.2")
 		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
 	}
 
+	event, message := model.EventUpdated, "incident updated"
+	if existing.Status != previousStatus {
+		event, message = model.EventStatusChanged, "status changed from "+string(previousStatus)+" to "+string(existing.Status)
+	}
+	if err := recordEvent(tx, existing.IncidentID, authID, event, message); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 2002.8")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if message := priorityMessage(previousPriority, wasOverride, existing); message != "" {
+		if err := recordEvent(tx, existing.IncidentID, authID, model.EventPriorityChanged, message); err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 2002.12")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+
+	if incident.ServiceIDs != nil {
+		if err := tx.Model(&existing).Association("Services").Replace(services); err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 2002.4")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+
+	// replace tags only when they are provided
+	if incident.Tags != nil {
+		if err := replaceIncidentTags(tx, existing.IncidentID, tags, authID); err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 2002.9")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+
+		var err error
+		if existing.Tags, err = incidentTags(tx, existing.IncidentID); err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 2002.10")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 2002.15")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	automateIncident(&existing, model.AutomationUpdated, authID)
+	attachMatchingPlaybooks(existing.IncidentID)
+
+	existing.FillSLA(time.Now())
+
 	httpResponse.Message = existing
 	httpStatusCode = http.StatusOK
 	return
 }
 
+// GetIncidentByID returns the incident, a merged duplicate
+// comes back with 301 Moved Permanently
 func GetIncidentByID(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
 	db := database.GetDB()
 
 	var incident model.Incident
 
-	if err := db.First(&incident, id).Error; err != nil {
+	if err := db.Preload("Services").Preload("Participants").Preload("Links").Preload("LinkedFrom").Preload("Tags").First(&incident, id).Error; err != nil {
 		log.WithError(err).Error("error code: 2003.1")
 		return setErrorMessage("incident not found", http.StatusNotFound)
 	}
 
+	incident.FillSLA(time.Now())
+
 	httpResponse.Message = incident
 	httpStatusCode = http.StatusOK
+	if incident.DuplicateOf != nil {
+		httpStatusCode = http.StatusMovedPermanently
+	}
 	return
 }
 
-func GetAllIncidents() (httpResponse model.HTTPResponse, httpStatusCode int) {
+func GetAllIncidents(filter model.IncidentFilter) (httpResponse model.HTTPResponse, httpStatusCode int) {
 	db := database.GetDB()
 
 	var incidents []model.Incident
 
-	if err := db.Find(&incidents).Error; err != nil {
+	if !validIncidentSort(filter.Sort) {
+		return setErrorMessage("sort must be priority, -priority, created or -created", http.StatusBadRequest)
+	}
+
+	customFields, msg, err := customFieldFilters(filter.CustomFields)
+	if err != nil {
+		log.WithError(err).Error("error code: 2004.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+	filter.CustomFields = customFields
+
+	query := db.Preload("Services").Preload("Tags").Scopes(incidentFilterScope(filter), incidentOrderScope(filter.Sort))
+
+	if err := query.Find(&incidents).Error; err != nil {
 		log.WithError(err).Error("error code: 2004.1")
 		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
 	}
 
+	now := time.Now()
+	for i := range incidents {
+		incidents[i].FillSLA(now)
+	}
+
 	httpResponse.Message = incidents
 	httpStatusCode = http.StatusOK
 	return
 }
+
+// incidentFilterScope applies the list filters to an incident query
+func incidentFilterScope(filter model.IncidentFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.TeamID != 0 {
+			db = db.Where("team_id = ?", filter.TeamID)
+		}
+
+		if filter.Status != "" {
+			db = db.Where("status = ?", filter.Status)
+		}
+
+		if filter.ServiceID != 0 {
+			db = db.Where(
+				"incident_id IN (?)",
+				database.GetDB().Table("incident_services").Select("incident_id").Where("service_id = ?", filter.ServiceID),
+			)
+		}
+
+		// every tag and label has to match
+		for _, name := range filter.Tags {
+			db = db.Where("incident_id IN (?)", taggedIncidents("tags.name = ?", strings.ToLower(strings.TrimSpace(name))))
+		}
+
+		for _, label := range filter.Labels {
+			key, value, hasValue := strings.Cut(strings.ToLower(label), ":")
+			if hasValue {
+				db = db.Where("incident_id IN (?)", taggedIncidents("tags.key = ? AND tags.value = ?", strings.TrimSpace(key), strings.TrimSpace(value)))
+			} else {
+				db = db.Where("incident_id IN (?)", taggedIncidents("tags.key = ?", strings.TrimSpace(key)))
+			}
+		}
+
+		// custom field values have to be validated by customFieldFilters
+		for key, value := range filter.CustomFields {
+			db = db.Where(customFieldColumn(db.Dialector.Name(), key)+" = ?", value)
+		}
+
+		return db
+	}
+}
+
+// taggedIncidents selects the IDs of incidents with a matching tag
+func taggedIncidents(query string, args ...any) *gorm.DB {
+	return database.GetDB().Table("incident_tags").
+		Select("incident_tags.incident_id").
+		Joins("JOIN tags ON tags.tag_id = incident_tags.tag_id").
+		Where(query, args...)
+}
+
+// GetIncidentBlastRadius computes the services transitively affected
+// by an incident: every service which (directly or indirectly)
+// depends on one of the impacted services
+func GetIncidentBlastRadius(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var incident model.Incident
+
+	if err := db.Preload("Services").First(&incident, id).Error; err != nil {
+		log.WithError(err).Error("error code: 2005.1")
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	graph, nodes, err := loadServiceGraph()
+	if err != nil {
+		log.WithError(err).Error("error code: 2005.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	impacted := []model.DependencyNode{}
+	start := []uint64{}
+	for _, svc := range incident.Services {
+		impacted = append(impacted, model.DependencyNode{
+			ServiceID: svc.ServiceID,
+			Name:      svc.Name,
+			Tier:      svc.Tier,
+		})
+		start = append(start, svc.ServiceID)
+	}
+
+	httpResponse.Message = model.BlastRadius{
+		IncidentID: incident.IncidentID,
+		Impacted:   impacted,
+		Affected:   describeNodes(graph.Walk(start, model.DependencyDownstream, 0), nodes),
+	}
+	httpStatusCode = http.StatusOK
+	return
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetIncidentIndicators lists the indicators of compromise of an incident
+func GetIncidentIndicators(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Incident{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5201.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	indicators := []model.IncidentIndicator{}
+
+	if err := db.Preload("Indicator").Where("incident_id = ?", id).Order("id").Find(&indicators).Error; err != nil {
+		log.WithError(err).Error("error code: 5201.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = indicators
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// AddIncidentIndicator adds one indicator to an incident, its type is
+// detected from the value when not given
+func AddIncidentIndicator(id uint64, req model.IndicatorReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	indicator, msg := service.ParseIndicator(req.Type, req.Value)
+	if msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	incident, resp, code, ok := editableIncident(id, authID, "5202.1")
+	if !ok {
+		return resp, code
+	}
+
+	tx := db.Begin()
+	added, existing, err := addIndicators(tx, incident.IncidentID, []model.Indicator{indicator}, strings.TrimSpace(req.Note), authID)
+	if err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5202.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if len(existing) > 0 {
+		tx.Rollback()
+		return setErrorMessage("indicator already added to the incident", http.StatusConflict)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 5202.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = added[0]
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// AddIncidentIndicatorsFromText extracts the indicators of pasted text,
+// such as an alert or a threat report, and adds the new ones
+func AddIncidentIndicatorsFromText(id uint64, req model.IndicatorBulkReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if len(req.Text) > model.IndicatorTextMax {
+		return setErrorMessage("text must be at most "+strconv.Itoa(model.IndicatorTextMax>>10)+" KiB", http.StatusBadRequest)
+	}
+
+	indicators := service.ExtractIndicators(req.Text)
+	if len(indicators) == 0 {
+		return setErrorMessage("no indicators found in the text", http.StatusBadRequest)
+	}
+	if len(indicators) > model.IndicatorBulkMax {
+		return setErrorMessage("text contains more than "+strconv.Itoa(model.IndicatorBulkMax)+" indicators", http.StatusBadRequest)
+	}
+
+	incident, resp, code, ok := editableIncident(id, authID, "5203.1")
+	if !ok {
+		return resp, code
+	}
+
+	tx := db.Begin()
+	added, existing, err := addIndicators(tx, incident.IncidentID, indicators, strings.TrimSpace(req.Note), authID)
+	if err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5203.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 5203.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = model.IndicatorBulkResult{Added: added, Existing: existing}
+	httpStatusCode = http.StatusOK
+	if len(added) > 0 {
+		httpStatusCode = http.StatusCreated
+	}
+	return
+}
+
+// RemoveIncidentIndicator removes an indicator from an incident, other
+// incidents keep it
+func RemoveIncidentIndicator(id, indicatorID, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	incident, resp, code, ok := editableIncident(id, authID, "5204.1")
+	if !ok {
+		return resp, code
+	}
+
+	var link model.IncidentIndicator
+
+	if err := db.Preload("Indicator").Where("incident_id = ? AND indicator_id = ?", incident.IncidentID, indicatorID).First(&link).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5204.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("indicator not found on the incident", http.StatusNotFound)
+	}
+
+	tx := db.Begin()
+	if err := tx.Delete(&link).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5204.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	message := "indicator removed: " + indicatorName(link.Indicator)
+	if err := recordEvent(tx, incident.IncidentID, authID, model.EventIndicatorRemoved, message); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5204.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 5204.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "indicator removed"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetIndicatorIncidents lists the incidents an indicator was seen in,
+// newest first, to spot recurring attackers. The value is normalized
+// the same way as when it was added.
+func GetIndicatorIncidents(value string) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	parsed, msg := service.ParseIndicator("", value)
+	if msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	var indicator model.Indicator
+
+	if err := db.Where("type = ? AND value = ?", parsed.Type, parsed.Value).First(&indicator).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5205.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("indicator not found", http.StatusNotFound)
+	}
+
+	lookup := model.IndicatorLookup{Indicator: indicator, Incidents: []model.IndicatorIncident{}}
+
+	err := db.Model(&model.Incident{}).
+		Select("incidents.incident_id, incidents.title, incidents.status, incidents.severity, incidents.created_at, incident_indicators.created_at AS added_at").
+		Joins("JOIN incident_indicators ON incident_indicators.incident_id = incidents.incident_id").
+		Where("incident_indicators.indicator_id = ?", indicator.IndicatorID).
+		Order("incidents.created_at DESC").
+		Scan(&lookup.Incidents).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 5205.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = lookup
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// addIndicators links the indicators to the incident, creating the ones
+// never seen before, and records one timeline entry. existing are the
+// indicators the incident already had.
+func addIndicators(tx *gorm.DB, incidentID uint64, indicators []model.Indicator, note string, authID uint64) (added []model.IncidentIndicator, existing []model.Indicator, err error) {
+	added = []model.IncidentIndicator{}
+	existing = []model.Indicator{}
+
+	names := []string{}
+	for _, indicator := range indicators {
+		if err = tx.Where(model.Indicator{Type: indicator.Type, Value: indicator.Value}).FirstOrCreate(&indicator).Error; err != nil {
+			return
+		}
+
+		var count int64
+		if err = tx.Model(&model.IncidentIndicator{}).Where("incident_id = ? AND indicator_id = ?", incidentID, indicator.IndicatorID).Count(&count).Error; err != nil {
+			return
+		}
+		if count > 0 {
+			existing = append(existing, indicator)
+			continue
+		}
+
+		link := model.IncidentIndicator{
+			IncidentID:  incidentID,
+			IndicatorID: indicator.IndicatorID,
+			AuthID:      authID,
+			Note:        note,
+			Indicator:   indicator,
+		}
+		if err = tx.Omit("Indicator").Create(&link).Error; err != nil {
+			return
+		}
+		added = append(added, link)
+		names = append(names, indicatorName(indicator))
+	}
+
+	if len(added) == 0 {
+		return
+	}
+
+	message := "indicator added: " + names[0]
+	if len(names) > 1 {
+		// keep the entry readable for large pastes
+		const listed = 10
+		message = strconv.Itoa(len(names)) + " indicators added: " + strings.Join(names[:min(len(names), listed)], ", ")
+		if len(names) > listed {
+			message += " and " + strconv.Itoa(len(names)-listed) + " more"
+		}
+	}
+	err = recordEvent(tx, incidentID, authID, model.EventIndicatorAdded, message)
+	return
+}
+
+func indicatorName(indicator model.Indicator) string {
+	return string(indicator.Type) + " " + indicator.Value
+}
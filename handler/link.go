@@ -0,0 +1,425 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetIncidentLinks lists the links from and to an incident
+func GetIncidentLinks(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Incident{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 4101.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	links := []model.IncidentLink{}
+
+	if err := db.Where("incident_id = ? OR linked_id = ?", id, id).Order("id").Find(&links).Error; err != nil {
+		log.WithError(err).Error("error code: 4101.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = links
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// CreateIncidentLink links an incident to another one
+func CreateIncidentLink(id uint64, req model.LinkReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if !req.Type.Valid() {
+		return setErrorMessage("type must be duplicates, caused-by or related-to", http.StatusBadRequest)
+	}
+	if req.LinkedID == id {
+		return setErrorMessage("an incident cannot be linked to itself", http.StatusBadRequest)
+	}
+
+	if _, resp, code, ok := editableIncident(id, authID, "4102.1"); !ok {
+		return resp, code
+	}
+
+	if err := db.First(&model.Incident{}, req.LinkedID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 4102.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("linked incident not found", http.StatusNotFound)
+	}
+
+	// related-to reads the same from both sides
+	query := db.Where("incident_id = ? AND linked_id = ? AND type = ?", id, req.LinkedID, req.Type)
+	if req.Type.Symmetric() {
+		query = query.Or("incident_id = ? AND linked_id = ? AND type = ?", req.LinkedID, id, req.Type)
+	}
+
+	var count int64
+	if err := query.Model(&model.IncidentLink{}).Count(&count).Error; err != nil {
+		log.WithError(err).Error("error code: 4102.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if count > 0 {
+		return setErrorMessage("incidents are already linked", http.StatusConflict)
+	}
+
+	link := model.IncidentLink{
+		IncidentID: id,
+		LinkedID:   req.LinkedID,
+		Type:       req.Type,
+		CreatedBy:  authID,
+	}
+
+	tx := db.Begin()
+	if err := tx.Create(&link).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4102.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := recordLinkEvents(tx, link, authID, model.EventLinked); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4102.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4102.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = link
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// DeleteIncidentLink removes a link from or to an incident
+func DeleteIncidentLink(id, linkID, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if _, resp, code, ok := editableIncident(id, authID, "4103.1"); !ok {
+		return resp, code
+	}
+
+	var link model.IncidentLink
+
+	if err := db.Where("incident_id = ? OR linked_id = ?", id, id).First(&link, linkID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 4103.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("link not found", http.StatusNotFound)
+	}
+
+	tx := db.Begin()
+	if err := tx.Delete(&link).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4103.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := recordLinkEvents(tx, link, authID, model.EventUnlinked); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4103.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4103.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "link removed"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// MergeIncident merges a duplicate incident into the target
+func MergeIncident(id uint64, req model.MergeReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if req.TargetID == id {
+		return setErrorMessage("an incident cannot be merged into itself", http.StatusBadRequest)
+	}
+
+	source, resp, code, ok := editableIncident(id, authID, "4104.1")
+	if !ok {
+		return resp, code
+	}
+	if source.DuplicateOf != nil {
+		return setErrorMessage("incident was already merged into incident #"+strconv.FormatUint(*source.DuplicateOf, 10), http.StatusConflict)
+	}
+
+	target, resp, code, ok := mergeTarget(req.TargetID, authID, "4104.2")
+	if !ok {
+		return resp, code
+	}
+
+	tx := db.Begin()
+	if err := mergeIncident(tx, &source, target.IncidentID, authID, time.Now()); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4104.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4104.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	return GetIncidentByID(target.IncidentID)
+}
+
+// mergeTarget loads an incident other incidents can be merged into
+func mergeTarget(id, authID uint64, errCode string) (target model.Incident, httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	target, httpResponse, httpStatusCode, ok = editableIncident(id, authID, errCode)
+	if !ok {
+		if httpStatusCode == http.StatusNotFound {
+			httpResponse, httpStatusCode = setErrorMessage("target incident not found", http.StatusNotFound)
+		}
+		return
+	}
+
+	if target.DuplicateOf != nil {
+		httpResponse, httpStatusCode = setErrorMessage("target incident was merged into incident #"+strconv.FormatUint(*target.DuplicateOf, 10), http.StatusConflict)
+		ok = false
+	}
+	return
+}
+
+// mergeIncident moves the timeline, tasks, responders, impacted services,
+// tags, links, alerts, monitors, indicators, techniques and playbooks of
+// the source to the target, then closes the source as a duplicate of the
+// target. Further incident data belongs here too.
+func mergeIncident(tx *gorm.DB, source *model.Incident, targetID, authID uint64, now time.Time) error {
+	sourceID := source.IncidentID
+
+	// heartbeats and checks resolve the target once they recover
+	for _, table := range []any{&model.IncidentEvent{}, &model.Task{}, &model.Alert{}, &model.Heartbeat{}, &model.Check{}} {
+		if err := tx.Model(table).Where("incident_id = ?", sourceID).Update("incident_id", targetID).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, merge := range []struct {
+		table  any
+		column string
+	}{
+		{&model.IncidentIndicator{}, "indicator_id"},
+		{&model.IncidentTechnique{}, "technique_id"},
+	} {
+		if err := mergeUnique(tx, merge.table, merge.column, sourceID, targetID); err != nil {
+			return err
+		}
+	}
+
+	if err := mergePlaybooks(tx, sourceID, targetID, now); err != nil {
+		return err
+	}
+
+	if err := mergeParticipants(tx, sourceID, targetID); err != nil {
+		return err
+	}
+
+	var services []model.Service
+	if err := tx.Model(&model.Incident{IncidentID: sourceID}).Association("Services").Find(&services); err != nil {
+		return err
+	}
+	if len(services) > 0 {
+		if err := tx.Model(&model.Incident{IncidentID: targetID}).Association("Services").Append(services); err != nil {
+			return err
+		}
+	}
+
+	var tags []model.Tag
+	if err := tx.Model(&model.Incident{IncidentID: sourceID}).Association("Tags").Find(&tags); err != nil {
+		return err
+	}
+	if len(tags) > 0 {
+		if err := tx.Model(&model.Incident{IncidentID: targetID}).Association("Tags").Append(tags); err != nil {
+			return err
+		}
+	}
+
+	if err := mergeLinks(tx, sourceID, targetID); err != nil {
+		return err
+	}
+
+	// earlier duplicates of the source now redirect to the target
+	if err := tx.Model(&model.Incident{}).Where("duplicate_of = ?", sourceID).Update("duplicate_of", targetID).Error; err != nil {
+		return err
+	}
+
+	source.Status = model.Closed
+	source.DuplicateOf = &targetID
+	source.UpdatedAt = now
+	source.TrackStatus(now)
+
+	if err := tx.Model(&model.Incident{IncidentID: sourceID}).
+		Select("status", "duplicate_of", "acknowledged_at", "resolved_at", "updated_at").
+		Updates(source).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Create(&model.IncidentLink{
+		IncidentID: sourceID,
+		LinkedID:   targetID,
+		Type:       model.LinkDuplicates,
+		CreatedBy:  authID,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := recordEvent(tx, sourceID, authID, model.EventMerged, "merged into incident #"+strconv.FormatUint(targetID, 10)); err != nil {
+		return err
+	}
+	return recordEvent(tx, targetID, authID, model.EventMerged, "incident #"+strconv.FormatUint(sourceID, 10)+" merged into this incident")
+}
+
+// mergeParticipants moves the responders to the target,
+// roles the target already has filled are dropped
+func mergeParticipants(tx *gorm.DB, sourceID, targetID uint64) error {
+	var participants []model.IncidentParticipant
+	if err := tx.Where("incident_id IN ?", []uint64{sourceID, targetID}).Find(&participants).Error; err != nil {
+		return err
+	}
+
+	held := map[model.ParticipantRole]bool{}
+	holders := map[model.ParticipantRole]map[uint64]bool{}
+	for _, p := range participants {
+		if p.IncidentID != targetID {
+			continue
+		}
+		held[p.Role] = true
+		if holders[p.Role] == nil {
+			holders[p.Role] = map[uint64]bool{}
+		}
+		holders[p.Role][p.AuthID] = true
+	}
+
+	for _, p := range participants {
+		if p.IncidentID != sourceID {
+			continue
+		}
+
+		if holders[p.Role][p.AuthID] || (p.Role.Exclusive() && held[p.Role]) {
+			if err := tx.Delete(&p).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := tx.Model(&p).Update("incident_id", targetID).Error; err != nil {
+			return err
+		}
+		held[p.Role] = true
+		if holders[p.Role] == nil {
+			holders[p.Role] = map[uint64]bool{}
+		}
+		holders[p.Role][p.AuthID] = true
+	}
+
+	return nil
+}
+
+// mergeUnique moves the rows of a table the target may have only once
+// per column value, rows of values the target already has are dropped
+func mergeUnique(tx *gorm.DB, table any, column string, sourceID, targetID uint64) error {
+	held := tx.Model(table).Select(column).Where("incident_id = ?", targetID)
+	if err := tx.Where("incident_id = ? AND "+column+" IN (?)", sourceID, held).Delete(table).Error; err != nil {
+		return err
+	}
+	return tx.Model(table).Where("incident_id = ?", sourceID).Update("incident_id", targetID).Error
+}
+
+// mergePlaybooks moves the attached playbooks of the source, those the
+// target already has are detached
+func mergePlaybooks(tx *gorm.DB, sourceID, targetID uint64, now time.Time) error {
+	held := []uint64{}
+	if err := tx.Model(&model.IncidentPlaybook{}).Where("incident_id = ?", targetID).Pluck("playbook_id", &held).Error; err != nil {
+		return err
+	}
+	if len(held) > 0 {
+		if err := detachPlaybooks(tx.Where("incident_id = ? AND playbook_id IN ?", sourceID, held), now); err != nil {
+			return err
+		}
+	}
+	return tx.Model(&model.IncidentPlaybook{}).Where("incident_id = ?", sourceID).Update("incident_id", targetID).Error
+}
+
+// mergeLinks points the links of the source at the target,
+// links which would duplicate or loop are dropped
+func mergeLinks(tx *gorm.DB, sourceID, targetID uint64) error {
+	var links []model.IncidentLink
+	if err := tx.Where("incident_id IN ? OR linked_id IN ?", []uint64{sourceID, targetID}, []uint64{sourceID, targetID}).
+		Order("id").Find(&links).Error; err != nil {
+		return err
+	}
+
+	type linkKey struct {
+		from, to uint64
+		kind     model.LinkType
+	}
+	existing := map[linkKey]bool{}
+	for _, link := range links {
+		if link.IncidentID != sourceID && link.LinkedID != sourceID {
+			existing[linkKey{link.IncidentID, link.LinkedID, link.Type}] = true
+		}
+	}
+
+	for _, link := range links {
+		if link.IncidentID != sourceID && link.LinkedID != sourceID {
+			continue
+		}
+
+		moved := link
+		if moved.IncidentID == sourceID {
+			moved.IncidentID = targetID
+		}
+		if moved.LinkedID == sourceID {
+			moved.LinkedID = targetID
+		}
+
+		key := linkKey{moved.IncidentID, moved.LinkedID, moved.Type}
+		reverse := linkKey{moved.LinkedID, moved.IncidentID, moved.Type}
+		if moved.IncidentID == moved.LinkedID || existing[key] || (moved.Type.Symmetric() && existing[reverse]) {
+			if err := tx.Delete(&link).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := tx.Model(&link).Updates(map[string]any{
+			"incident_id": moved.IncidentID,
+			"linked_id":   moved.LinkedID,
+		}).Error; err != nil {
+			return err
+		}
+		existing[key] = true
+	}
+
+	return nil
+}
+
+// recordLinkEvents adds a timeline entry on both linked incidents
+func recordLinkEvents(tx *gorm.DB, link model.IncidentLink, authID uint64, eventType model.EventType) error {
+	verb := "linked to"
+	if eventType == model.EventUnlinked {
+		verb = "unlinked from"
+	}
+
+	if err := recordEvent(tx, link.IncidentID, authID, eventType,
+		verb+" incident #"+strconv.FormatUint(link.LinkedID, 10)+" ("+string(link.Type)+")"); err != nil {
+		return err
+	}
+	return recordEvent(tx, link.LinkedID, authID, eventType,
+		verb+" incident #"+strconv.FormatUint(link.IncidentID, 10)+" ("+string(link.Type)+")")
+}
@@ -0,0 +1,376 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// maintenanceLookahead - how far ahead the next occurrence of a
+// maintenance window is searched
+const maintenanceLookahead = 10 * 366 * 24 * time.Hour
+
+// GetMaintenanceWindows lists the maintenance windows, latest first
+func GetMaintenanceWindows() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	windows := []model.MaintenanceWindow{}
+
+	if err := db.Preload("Services").Order("starts_at DESC").Find(&windows).Error; err != nil {
+		log.WithError(err).Error("error code: 4601.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	now := time.Now()
+	for i := range windows {
+		if err := fillMaintenanceNext(&windows[i], now); err != nil {
+			log.WithError(err).Error("error code: 4601.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+
+	httpResponse.Message = windows
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func GetMaintenanceWindow(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	window, resp, code, ok := maintenanceWindow(id, "4602.1")
+	if !ok {
+		return resp, code
+	}
+
+	if err := fillMaintenanceNext(&window, time.Now()); err != nil {
+		log.WithError(err).Error("error code: 4602.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = window
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// CreateMaintenanceWindow schedules maintenance on services, only
+// administrators can as windows silence the alerts of the services
+func CreateMaintenanceWindow(req model.MaintenanceWindowReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if msg := validateMaintenanceWindowReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	services, err := findServices(req.ServiceIDs)
+	if err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 4603.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("service not found", http.StatusNotFound)
+	}
+
+	window := model.MaintenanceWindow{
+		Name:        req.Name,
+		Description: req.Description,
+		Action:      req.Action,
+		AuthID:      authID,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		RRule:       req.RRule,
+		TimeZone:    req.TimeZone,
+		Services:    services,
+	}
+
+	if err := db.Create(&window).Error; err != nil {
+		log.WithError(err).Error("error code: 4603.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := fillMaintenanceNext(&window, time.Now()); err != nil {
+		log.WithError(err).Error("error code: 4603.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = window
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// UpdateMaintenanceWindow replaces a window, alerts it already
+// applied to are not evaluated again
+func UpdateMaintenanceWindow(id uint64, req model.MaintenanceWindowReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if msg := validateMaintenanceWindowReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	window, resp, code, ok := maintenanceWindow(id, "4604.1")
+	if !ok {
+		return resp, code
+	}
+
+	services, err := findServices(req.ServiceIDs)
+	if err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 4604.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("service not found", http.StatusNotFound)
+	}
+
+	window.Name = req.Name
+	window.Description = req.Description
+	window.Action = req.Action
+	window.StartsAt = req.StartsAt
+	window.EndsAt = req.EndsAt
+	window.RRule = req.RRule
+	window.TimeZone = req.TimeZone
+
+	tx := db.Begin()
+	if err := tx.Omit("Services").Save(&window).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4604.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Model(&window).Association("Services").Replace(services); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4604.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4604.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	window.Services = services
+	if err := fillMaintenanceNext(&window, time.Now()); err != nil {
+		log.WithError(err).Error("error code: 4604.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = window
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DeleteMaintenanceWindow removes a window, alerts it applied to
+// keep its ID
+func DeleteMaintenanceWindow(id, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	window, resp, code, ok := maintenanceWindow(id, "4605.1")
+	if !ok {
+		return resp, code
+	}
+
+	tx := db.Begin()
+	if err := tx.Model(&window).Association("Services").Clear(); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4605.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Delete(&model.MaintenanceWindow{}, id).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4605.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4605.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "maintenance window deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetMaintenanceOccurrences lists the occurrences of a window between
+// from (default now) and to (default 30 days after from)
+func GetMaintenanceOccurrences(id uint64, from, to time.Time) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, 30)
+	}
+	if !to.After(from) {
+		return setErrorMessage("to must be after from", http.StatusBadRequest)
+	}
+
+	window, resp, code, ok := maintenanceWindow(id, "4606.1")
+	if !ok {
+		return resp, code
+	}
+
+	occurrences, err := service.MaintenanceOccurrences(window, from, to, model.MaintenanceOccurrencesMax)
+	if err != nil {
+		log.WithError(err).Error("error code: 4606.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = occurrences
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// activeMaintenance returns the maintenance action for an alert on the
+// given services, the window which decided it and, for a suppressed
+// alert, until when the suppressing windows cover every service. An
+// alert is covered only when every one of its services is: it is
+// suppressed when every service has a suppressing window, else
+// downgraded.
+func activeMaintenance(db *gorm.DB, serviceIDs []uint64, now time.Time) (model.MaintenanceAction, *uint64, time.Time, error) {
+	var until time.Time
+
+	if len(serviceIDs) == 0 {
+		return "", nil, until, nil
+	}
+
+	windows := []model.MaintenanceWindow{}
+	err := db.Preload("Services").
+		Where("window_id IN (?)", db.Table("maintenance_window_services").Select("window_id").Where("service_id IN ?", serviceIDs)).
+		Where("starts_at <= ? AND (ends_at > ? OR rrule <> '')", now, now).
+		Order("window_id").
+		Find(&windows).Error
+	if err != nil {
+		return "", nil, until, err
+	}
+
+	// strongest action of the active windows by service, and the end
+	// of the latest suppressing one
+	covered := map[uint64]model.MaintenanceAction{}
+	deciding := map[uint64]uint64{}
+	suppressedUntil := map[uint64]time.Time{}
+	for _, window := range windows {
+		occurrences, err := service.MaintenanceOccurrences(window, now, now.Add(time.Nanosecond), 1)
+		if err != nil {
+			return "", nil, until, err
+		}
+		if len(occurrences) == 0 {
+			continue
+		}
+		for _, svc := range window.Services {
+			if covered[svc.ServiceID] != model.MaintenanceSuppress {
+				covered[svc.ServiceID] = window.Action
+				deciding[svc.ServiceID] = window.WindowID
+			}
+			if window.Action == model.MaintenanceSuppress && occurrences[0].End.After(suppressedUntil[svc.ServiceID]) {
+				suppressedUntil[svc.ServiceID] = occurrences[0].End
+			}
+		}
+	}
+
+	action := model.MaintenanceSuppress
+	windowID := deciding[serviceIDs[0]]
+	for _, serviceID := range serviceIDs {
+		switch covered[serviceID] {
+		case "":
+			return "", nil, until, nil
+		case model.MaintenanceDowngrade:
+			if action == model.MaintenanceSuppress {
+				action = model.MaintenanceDowngrade
+				windowID = deciding[serviceID]
+			}
+		}
+		if until.IsZero() || suppressedUntil[serviceID].Before(until) {
+			until = suppressedUntil[serviceID]
+		}
+	}
+	if action != model.MaintenanceSuppress {
+		until = time.Time{}
+	}
+	return action, &windowID, until, nil
+}
+
+// maintenanceWindow loads a window with its services
+func maintenanceWindow(id uint64, errCode string) (window model.MaintenanceWindow, httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	db := database.GetDB()
+
+	if err := db.Preload("Services").First(&window, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("maintenance window not found", http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
+
+// fillMaintenanceNext sets the current or upcoming occurrence
+func fillMaintenanceNext(window *model.MaintenanceWindow, now time.Time) error {
+	occurrences, err := service.MaintenanceOccurrences(*window, now, now.Add(maintenanceLookahead), 1)
+	if err != nil {
+		return err
+	}
+
+	window.Next = nil
+	if len(occurrences) > 0 {
+		window.Next = &occurrences[0]
+	}
+	return nil
+}
+
+func validateMaintenanceWindowReq(req *model.MaintenanceWindowReq) string {
+	req.Name = strings.TrimSpace(req.Name)
+	req.RRule = strings.TrimPrefix(strings.TrimSpace(req.RRule), "RRULE:")
+	req.TimeZone = strings.TrimSpace(req.TimeZone)
+
+	if req.Name == "" {
+		return "name is required"
+	}
+	if req.Action == "" {
+		req.Action = model.MaintenanceSuppress
+	}
+	if !req.Action.Valid() {
+		return "action must be suppress or downgrade"
+	}
+	if req.StartsAt.IsZero() || req.EndsAt.IsZero() {
+		return "startsAt and endsAt are required"
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return "endsAt must be after startsAt"
+	}
+	if req.EndsAt.Sub(req.StartsAt) > model.MaintenanceDurationMax {
+		return "a maintenance window may last 31 days at most"
+	}
+	if len(req.ServiceIDs) == 0 {
+		return "at least one service is required"
+	}
+
+	if req.TimeZone == "" {
+		req.TimeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(req.TimeZone); err != nil {
+		return "unknown time zone: " + req.TimeZone
+	}
+	if req.RRule != "" {
+		if _, err := service.ParseRRule(req.RRule); err != nil {
+			return err.Error()
+		}
+	}
+
+	return ""
+}
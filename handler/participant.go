@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetIncidentParticipants lists the responders holding a role in an incident
+func GetIncidentParticipants(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Incident{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3301.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	participants := []model.IncidentParticipant{}
+
+	if err := db.Where("incident_id = ?", id).Order("role, created_at").Find(&participants).Error; err != nil {
+		log.WithError(err).Error("error code: 3301.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = participants
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// AddIncidentParticipant gives a responder a role in an incident.
+// Exclusive roles which are already held must be handed off instead.
+func AddIncidentParticipant(id uint64, req model.ParticipantReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if !req.Role.Valid() {
+		return setErrorMessage("unknown participant role", http.StatusBadRequest)
+	}
+
+	incident, resp, code, ok := editableIncident(id, authID, "3302.1")
+	if !ok {
+		return resp, code
+	}
+
+	if err := db.First(&model.Auth{}, req.AuthID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3302.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("user not found", http.StatusNotFound)
+	}
+
+	query := db.Where("incident_id = ? AND role = ?", incident.IncidentID, req.Role)
+	if !req.Role.Exclusive() {
+		query = query.Where("auth_id = ?", req.AuthID)
+	}
+
+	var holder model.IncidentParticipant
+	err := query.First(&holder).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3302.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err == nil {
+		if holder.AuthID == req.AuthID {
+			return setErrorMessage("user already holds this role", http.StatusConflict)
+		}
+		return setErrorMessage(req.Role.String()+" is already assigned, hand the role off instead", http.StatusConflict)
+	}
+
+	participant := model.IncidentParticipant{
+		IncidentID: incident.IncidentID,
+		AuthID:     req.AuthID,
+		Role:       req.Role,
+	}
+
+	tx := db.Begin()
+	if err := tx.Create(&participant).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3302.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	message := fmt.Sprintf("user %d assigned as %s", req.AuthID, req.Role)
+	if err := recordEvent(tx, incident.IncidentID, authID, model.EventRoleAssigned, message); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3302.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3302.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = participant
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// RemoveIncidentParticipant takes a role away from a responder
+func RemoveIncidentParticipant(id, participantID, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	incident, resp, code, ok := editableIncident(id, authID, "3303.1")
+	if !ok {
+		return resp, code
+	}
+
+	var participant model.IncidentParticipant
+
+	err := db.Where("incident_id = ?", incident.IncidentID).First(&participant, participantID).Error
+	if err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3303.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("participant not found", http.StatusNotFound)
+	}
+
+	tx := db.Begin()
+	if err := tx.Delete(&participant).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3303.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	message := fmt.Sprintf("user %d removed as %s", participant.AuthID, participant.Role)
+	if err := recordEvent(tx, incident.IncidentID, authID, model.EventRoleRemoved, message); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3303.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3303.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "participant removed"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// HandoffIncidentRole passes an exclusive role to another responder
+// and records the handoff in the incident timeline
+func HandoffIncidentRole(id uint64, req model.HandoffReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if !req.Role.Valid() {
+		return setErrorMessage("unknown participant role", http.StatusBadRequest)
+	}
+	if !req.Role.Exclusive() {
+		return setErrorMessage(req.Role.String()+" is not an exclusive role, add a participant instead", http.StatusBadRequest)
+	}
+
+	incident, resp, code, ok := editableIncident(id, authID, "3304.1")
+	if !ok {
+		return resp, code
+	}
+
+	if err := db.First(&model.Auth{}, req.AuthID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3304.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("user not found", http.StatusNotFound)
+	}
+
+	var holder model.IncidentParticipant
+
+	err := db.Where("incident_id = ? AND role = ?", incident.IncidentID, req.Role).First(&holder).Error
+	if err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3304.3")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage(req.Role.String()+" is not assigned", http.StatusNotFound)
+	}
+
+	if holder.AuthID == req.AuthID {
+		return setErrorMessage("user already holds this role", http.StatusConflict)
+	}
+
+	previous := holder.AuthID
+	holder.AuthID = req.AuthID
+
+	tx := db.Begin()
+	if err := tx.Save(&holder).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3304.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	message := fmt.Sprintf("%s handed off from user %d to user %d", req.Role, previous, req.AuthID)
+	if req.Note != "" {
+		message += ": " + req.Note
+	}
+	if err := recordEvent(tx, incident.IncidentID, authID, model.EventRoleHandoff, message); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3304.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3304.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = holder
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// editableIncident loads an incident and checks the user may edit it.
+// Incidents without a team are editable by everyone.
+func editableIncident(id, authID uint64, errCode string) (incident model.Incident, httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	if err := database.GetDB().First(&incident, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("incident not found", http.StatusNotFound)
+		return
+	}
+
+	if incident.TeamID != nil {
+		httpResponse, httpStatusCode, ok = requireTeamPermission(authID, *incident.TeamID, model.PermIncidentEdit, errCode)
+		return
+	}
+
+	ok = true
+	return
+}
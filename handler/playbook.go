@@ -0,0 +1,550 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetPlaybooks lists all playbooks
+func GetPlaybooks() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	playbooks := []model.Playbook{}
+
+	if err := db.Order("name").Find(&playbooks).Error; err != nil {
+		log.WithError(err).Error("error code: 5101.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = playbooks
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func GetPlaybook(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var playbook model.Playbook
+
+	if err := db.First(&playbook, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5102.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("playbook not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = playbook
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// CreatePlaybook adds a new playbook
+func CreatePlaybook(req model.PlaybookReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if resp, code, ok := validatePlaybookReq(&req, 0, "5103.1"); !ok {
+		return resp, code
+	}
+
+	playbook := model.Playbook{}
+	applyPlaybookReq(&playbook, req)
+
+	if err := db.Create(&playbook).Error; err != nil {
+		log.WithError(err).Error("error code: 5103.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = playbook
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// UpdatePlaybook replaces a playbook. Incidents keep the steps of the
+// playbooks already attached to them.
+func UpdatePlaybook(id uint64, req model.PlaybookReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	var playbook model.Playbook
+
+	if err := db.First(&playbook, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5104.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("playbook not found", http.StatusNotFound)
+	}
+
+	if resp, code, ok := validatePlaybookReq(&req, id, "5104.2"); !ok {
+		return resp, code
+	}
+
+	applyPlaybookReq(&playbook, req)
+
+	if err := db.Save(&playbook).Error; err != nil {
+		log.WithError(err).Error("error code: 5104.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = playbook
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DeletePlaybook removes a playbook, incidents keep their copies
+func DeletePlaybook(id, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	result := db.Delete(&model.Playbook{}, id)
+	if result.Error != nil {
+		log.WithError(result.Error).Error("error code: 5105.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected == 0 {
+		return setErrorMessage("playbook not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = "playbook deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetIncidentPlaybooks lists the playbooks attached to an incident with
+// the progress of their steps
+func GetIncidentPlaybooks(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Incident{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5106.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	playbooks := []model.IncidentPlaybook{}
+
+	if err := incidentPlaybookQuery(db).Where("incident_id = ?", id).Order("id").Find(&playbooks).Error; err != nil {
+		log.WithError(err).Error("error code: 5106.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	for i := range playbooks {
+		playbooks[i].FillProgress()
+	}
+
+	httpResponse.Message = playbooks
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// AttachPlaybook copies the steps of a playbook to an incident
+func AttachPlaybook(id uint64, req model.PlaybookAttachReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	incident, resp, code, ok := editableIncident(id, authID, "5107.1")
+	if !ok {
+		return resp, code
+	}
+
+	var playbook model.Playbook
+
+	if err := db.First(&playbook, req.PlaybookID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5107.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("playbook not found", http.StatusNotFound)
+	}
+
+	err := db.Where("incident_id = ? AND playbook_id = ?", incident.IncidentID, playbook.PlaybookID).First(&model.IncidentPlaybook{}).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 5107.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err == nil {
+		return setErrorMessage("playbook already attached", http.StatusConflict)
+	}
+
+	tx := db.Begin()
+	attached, err := attachPlaybook(tx, incident.IncidentID, playbook, authID)
+	if err != nil {
+		tx.Rollback()
+		// attached at the same time by matching
+		if duplicateKey(db, err) {
+			return setErrorMessage("playbook already attached", http.StatusConflict)
+		}
+		log.WithError(err).Error("error code: 5107.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 5107.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = attached
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// DetachPlaybook removes a playbook from an incident, matching does not
+// attach it again
+func DetachPlaybook(id, playbookID, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	incident, resp, code, ok := editableIncident(id, authID, "5108.1")
+	if !ok {
+		return resp, code
+	}
+
+	attached, resp, code, ok := findIncidentPlaybook(incident.IncidentID, playbookID, "5108.2")
+	if !ok {
+		return resp, code
+	}
+
+	tx := db.Begin()
+	if err := detachPlaybooks(tx.Where("id = ?", attached.ID), time.Now()); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5108.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := recordEvent(tx, incident.IncidentID, authID, model.EventPlaybookDetached, "playbook detached: "+attached.Name); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5108.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 5108.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "playbook detached"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// UpdatePlaybookStep completes or reopens a step of a playbook attached
+// to an incident. A step with a required role is only for its holders.
+func UpdatePlaybookStep(id, playbookID, stepID uint64, req model.PlaybookStepReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	incident, resp, code, ok := editableIncident(id, authID, "5109.1")
+	if !ok {
+		return resp, code
+	}
+
+	attached, resp, code, ok := findIncidentPlaybook(incident.IncidentID, playbookID, "5109.2")
+	if !ok {
+		return resp, code
+	}
+
+	i := slices.IndexFunc(attached.Steps, func(step model.IncidentPlaybookStep) bool { return step.StepID == stepID })
+	if i < 0 {
+		return setErrorMessage("step not found", http.StatusNotFound)
+	}
+	step := &attached.Steps[i]
+
+	if step.RequiredRole != "" {
+		err := db.Where("incident_id = ? AND auth_id = ? AND role = ?", incident.IncidentID, authID, step.RequiredRole).First(&model.IncidentParticipant{}).Error
+		if err != nil {
+			if err.Error() != database.RecordNotFound {
+				log.WithError(err).Error("error code: 5109.3")
+				return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			}
+			return setErrorMessage("step requires the "+step.RequiredRole.String()+" role", http.StatusForbidden)
+		}
+	}
+
+	// nothing changes
+	if req.Completed == (step.CompletedAt != nil) {
+		attached.FillProgress()
+		httpResponse.Message = attached
+		httpStatusCode = http.StatusOK
+		return
+	}
+
+	event, verb := model.EventPlaybookStepReopened, "reopened"
+	step.CompletedAt = nil
+	step.CompletedBy = 0
+	if req.Completed {
+		now := time.Now()
+		event, verb = model.EventPlaybookStepCompleted, "completed"
+		step.CompletedAt = &now
+		step.CompletedBy = authID
+	}
+
+	tx := db.Begin()
+	if err := tx.Model(step).Select("completed_at", "completed_by").Updates(step).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5109.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	message := fmt.Sprintf("step %d of playbook '%s' %s: %s", step.Position, attached.Name, verb, step.Title)
+	if err := recordEvent(tx, incident.IncidentID, authID, event, message); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 5109.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 5109.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	attached.FillProgress()
+
+	httpResponse.Message = attached
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// attachMatchingPlaybooks attaches the playbooks whose services and
+// severities match the incident and which were never attached to it.
+// The incident was already saved, so failures are only logged.
+func attachMatchingPlaybooks(incidentID uint64) {
+	db := database.GetDB()
+
+	playbooks := []model.Playbook{}
+
+	if err := db.Order("name").Find(&playbooks).Error; err != nil {
+		log.WithError(err).Error("error code: 5110.1")
+		return
+	}
+	playbooks = slices.DeleteFunc(playbooks, func(playbook model.Playbook) bool { return !playbook.Automatic() })
+	if len(playbooks) == 0 {
+		return
+	}
+
+	var incident model.Incident
+
+	if err := db.Preload("Services").First(&incident, incidentID).Error; err != nil {
+		log.WithError(err).Error("error code: 5110.2")
+		return
+	}
+
+	// detached playbooks count too
+	attached := []uint64{}
+
+	if err := db.Unscoped().Model(&model.IncidentPlaybook{}).Where("incident_id = ?", incidentID).Pluck("playbook_id", &attached).Error; err != nil {
+		log.WithError(err).Error("error code: 5110.3")
+		return
+	}
+
+	for _, playbook := range playbooks {
+		if slices.Contains(attached, playbook.PlaybookID) || !matchPlaybook(playbook, incident) {
+			continue
+		}
+
+		tx := db.Begin()
+		if _, err := attachPlaybook(tx, incidentID, playbook, 0); err != nil {
+			tx.Rollback()
+			// attached at the same time by hand
+			if duplicateKey(db, err) {
+				continue
+			}
+			log.WithError(err).Error("error code: 5110.4")
+			return
+		}
+		if err := tx.Commit().Error; err != nil {
+			log.WithError(err).Error("error code: 5110.5")
+			return
+		}
+	}
+}
+
+// detachPlaybooks soft deletes the attached playbooks the query selects,
+// the deletion marker lets them be attached again
+func detachPlaybooks(query *gorm.DB, now time.Time) error {
+	return query.Model(&model.IncidentPlaybook{}).UpdateColumns(map[string]any{
+		"deleted_at":  now,
+		"detached_id": gorm.Expr("id"),
+	}).Error
+}
+
+// matchPlaybook reports whether the incident has one of the services
+// and one of the severities of the playbook
+func matchPlaybook(playbook model.Playbook, incident model.Incident) bool {
+	if len(playbook.Severities) > 0 && !slices.Contains(playbook.Severities, incident.Severity) {
+		return false
+	}
+	if len(playbook.ServiceIDs) == 0 {
+		return true
+	}
+	for _, service := range incident.Services {
+		if slices.Contains(playbook.ServiceIDs, service.ServiceID) {
+			return true
+		}
+	}
+	return false
+}
+
+// attachPlaybook copies the steps of the playbook to the incident,
+// authID is 0 when it matched
+func attachPlaybook(tx *gorm.DB, incidentID uint64, playbook model.Playbook, authID uint64) (model.IncidentPlaybook, error) {
+	attached := model.IncidentPlaybook{
+		IncidentID: incidentID,
+		PlaybookID: playbook.PlaybookID,
+		Name:       playbook.Name,
+		AttachedBy: authID,
+		Steps:      make([]model.IncidentPlaybookStep, 0, len(playbook.Steps)),
+	}
+	for i, step := range playbook.Steps {
+		attached.Steps = append(attached.Steps, model.IncidentPlaybookStep{
+			Position:     i + 1,
+			Title:        step.Title,
+			Instructions: step.Instructions,
+			RequiredRole: step.RequiredRole,
+		})
+	}
+
+	if err := tx.Create(&attached).Error; err != nil {
+		return attached, err
+	}
+
+	message := "playbook attached: " + playbook.Name
+	if authID == 0 {
+		message += " (matched the incident)"
+	}
+	if err := recordEvent(tx, incidentID, authID, model.EventPlaybookAttached, message); err != nil {
+		return attached, err
+	}
+
+	return attached, nil
+}
+
+func findIncidentPlaybook(incidentID, playbookID uint64, errCode string) (attached model.IncidentPlaybook, httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	db := database.GetDB()
+
+	if err := incidentPlaybookQuery(db).Where("incident_id = ? AND playbook_id = ?", incidentID, playbookID).First(&attached).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("playbook not attached to the incident", http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
+
+// incidentPlaybookQuery loads attached playbooks with their steps in order
+func incidentPlaybookQuery(db *gorm.DB) *gorm.DB {
+	return db.Preload("Steps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	})
+}
+
+func applyPlaybookReq(playbook *model.Playbook, req model.PlaybookReq) {
+	playbook.Name = req.Name
+	playbook.Description = req.Description
+	playbook.ServiceIDs = req.ServiceIDs
+	playbook.Severities = req.Severities
+	playbook.Steps = req.Steps
+}
+
+// validatePlaybookReq normalizes the payload and checks that the name is
+// free and the services exist, id is the playbook being updated
+func validatePlaybookReq(req *model.PlaybookReq, id uint64, errCode string) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	db := database.GetDB()
+
+	if msg := validatePlaybookFields(req); msg != "" {
+		httpResponse, httpStatusCode = setErrorMessage(msg, http.StatusBadRequest)
+		return
+	}
+
+	// playbook name must be unique
+	err := db.Where("name = ? AND playbook_id <> ?", req.Name, id).First(&model.Playbook{}).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: " + errCode)
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
+	}
+	if err == nil {
+		httpResponse, httpStatusCode = setErrorMessage("playbook name already exists", http.StatusConflict)
+		return
+	}
+
+	if _, err := findServices(req.ServiceIDs); err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("service not found", http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
+
+func validatePlaybookFields(req *model.PlaybookReq) string {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return "playbook name is required"
+	}
+
+	if len(req.Steps) == 0 || len(req.Steps) > model.PlaybookStepsMax {
+		return "a playbook needs 1 to " + strconv.Itoa(model.PlaybookStepsMax) + " steps"
+	}
+	for i := range req.Steps {
+		step := &req.Steps[i]
+		step.Title = strings.TrimSpace(step.Title)
+		if step.Title == "" {
+			return "step title is required"
+		}
+		if step.RequiredRole != "" && !step.RequiredRole.Valid() {
+			return "unknown participant role: " + string(step.RequiredRole)
+		}
+	}
+
+	req.ServiceIDs = uniqueIDs(req.ServiceIDs)
+
+	severities := []model.SeverityType{}
+	for _, severity := range req.Severities {
+		if !severity.Valid() {
+			return "severity must be low, medium, high or critical"
+		}
+		if !slices.Contains(severities, severity) {
+			severities = append(severities, severity)
+		}
+	}
+	req.Severities = severities
+
+	return ""
+}
+
+// duplicateKey reports whether err is a violation of a unique index
+func duplicateKey(db *gorm.DB, err error) bool {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
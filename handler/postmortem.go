@@ -0,0 +1,609 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CreatePostmortemTemplate adds a new postmortem template, templates
+// are shared by everyone so only administrators can change them
+func CreatePostmortemTemplate(req model.PostmortemTemplateReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if msg := validatePostmortemTemplateReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	// template name must be unique
+	err := db.Where("name = ?", req.Name).First(&model.PostmortemTemplate{}).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3601.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err == nil {
+		return setErrorMessage("template name already exists", http.StatusConflict)
+	}
+
+	template := model.PostmortemTemplate{
+		Name:     req.Name,
+		Sections: req.Sections,
+	}
+
+	if err := db.Create(&template).Error; err != nil {
+		log.WithError(err).Error("error code: 3601.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = template
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// GetPostmortemTemplates lists all postmortem templates
+func GetPostmortemTemplates() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	templates := []model.PostmortemTemplate{}
+
+	if err := db.Order("name").Find(&templates).Error; err != nil {
+		log.WithError(err).Error("error code: 3602.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = templates
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// UpdatePostmortemTemplate replaces the name and sections of a template.
+// Existing postmortems keep their sections.
+func UpdatePostmortemTemplate(id uint64, req model.PostmortemTemplateReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if msg := validatePostmortemTemplateReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	var template model.PostmortemTemplate
+
+	if err := db.First(&template, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3603.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("template not found", http.StatusNotFound)
+	}
+
+	// template name must be unique
+	err := db.Where("name = ? AND template_id <> ?", req.Name, id).First(&model.PostmortemTemplate{}).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3603.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err == nil {
+		return setErrorMessage("template name already exists", http.StatusConflict)
+	}
+
+	template.Name = req.Name
+	template.Sections = req.Sections
+
+	if err := db.Save(&template).Error; err != nil {
+		log.WithError(err).Error("error code: 3603.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = template
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DeletePostmortemTemplate removes a postmortem template
+func DeletePostmortemTemplate(id, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	result := db.Delete(&model.PostmortemTemplate{}, id)
+	if result.Error != nil {
+		log.WithError(result.Error).Error("error code: 3604.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected == 0 {
+		return setErrorMessage("template not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = "template deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// CreatePostmortem starts the postmortem of a closed incident. The
+// timeline and action items sections are filled in from the incident.
+func CreatePostmortem(incidentID uint64, req model.PostmortemReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	incident, resp, code, ok := editableIncident(incidentID, authID, "3605.1")
+	if !ok {
+		return resp, code
+	}
+
+	if incident.Status != model.Closed {
+		return setErrorMessage("incident must be closed before writing the postmortem", http.StatusConflict)
+	}
+
+	err := db.Where("incident_id = ?", incident.IncidentID).First(&model.Postmortem{}).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3605.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err == nil {
+		return setErrorMessage("incident already has a postmortem", http.StatusConflict)
+	}
+
+	definitions := model.DefaultPostmortemSections
+	var templateID *uint64
+
+	if req.TemplateID != 0 {
+		var template model.PostmortemTemplate
+		if err := db.First(&template, req.TemplateID).Error; err != nil {
+			if err.Error() != database.RecordNotFound {
+				log.WithError(err).Error("error code: 3605.3")
+				return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			}
+			return setErrorMessage("template not found", http.StatusNotFound)
+		}
+		definitions = template.Sections
+		templateID = &template.TemplateID
+	}
+
+	var events []model.IncidentEvent
+	if err := db.Where("incident_id = ?", incident.IncidentID).Order("created_at, id").Find(&events).Error; err != nil {
+		log.WithError(err).Error("error code: 3605.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	var tasks []model.Task
+	if err := db.Where("incident_id = ?", incident.IncidentID).Order("created_at").Find(&tasks).Error; err != nil {
+		log.WithError(err).Error("error code: 3605.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	sections := make([]model.PostmortemSection, 0, len(definitions))
+	for i, definition := range definitions {
+		content := definition.Prompt
+		switch definition.Key {
+		case model.SectionTimeline:
+			content = service.TimelineExcerpt(events)
+		case model.SectionActionItems:
+			content = service.ActionItemList(tasks)
+		}
+
+		sections = append(sections, model.PostmortemSection{
+			Key:      definition.Key,
+			Title:    definition.Title,
+			Position: i,
+			Content:  content,
+		})
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = "Postmortem: " + incident.Title
+	}
+
+	pm := model.Postmortem{
+		IncidentID: incident.IncidentID,
+		TemplateID: templateID,
+		Title:      title,
+		Status:     model.PostmortemDraft,
+		AuthorID:   authID,
+		Sections:   sections,
+		Reviews:    []model.PostmortemReview{},
+	}
+
+	tx := db.Begin()
+	if err := tx.Create(&pm).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3605.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := recordEvent(tx, incident.IncidentID, authID, model.EventPostmortemCreated, "postmortem draft started"); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3605.7")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3605.8")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = pm
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// GetIncidentPostmortem fetches the postmortem of an incident
+func GetIncidentPostmortem(incidentID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	var pm model.Postmortem
+
+	if err := postmortemQuery().Where("incident_id = ?", incidentID).First(&pm).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3606.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("postmortem not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = pm
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetPostmortemByID fetches one postmortem with its sections and reviews
+func GetPostmortemByID(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	var pm model.Postmortem
+
+	if err := postmortemQuery().First(&pm, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3607.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("postmortem not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = pm
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// UpdatePostmortem edits the title and section contents of a draft
+func UpdatePostmortem(id uint64, req model.PostmortemUpdate, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	pm, resp, code, ok := editablePostmortem(id, authID, "3608.1")
+	if !ok {
+		return resp, code
+	}
+
+	if pm.Status != model.PostmortemDraft {
+		return setErrorMessage("only drafts can be edited", http.StatusConflict)
+	}
+
+	index := make(map[string]int, len(pm.Sections))
+	for i, section := range pm.Sections {
+		index[section.Key] = i
+	}
+
+	changed := []int{}
+	for _, section := range req.Sections {
+		i, ok := index[section.Key]
+		if !ok {
+			return setErrorMessage("unknown section: "+section.Key, http.StatusBadRequest)
+		}
+		pm.Sections[i].Content = section.Content
+		changed = append(changed, i)
+	}
+
+	if title := strings.TrimSpace(req.Title); title != "" {
+		pm.Title = title
+	}
+
+	tx := db.Begin()
+	if err := tx.Model(&model.Postmortem{PostmortemID: pm.PostmortemID}).Update("title", pm.Title).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3608.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	for _, i := range changed {
+		if err := tx.Model(&pm.Sections[i]).Update("content", pm.Sections[i].Content).Error; err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 3608.3")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3608.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = pm
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// SubmitPostmortem sends a draft to the given reviewers. Earlier
+// reviews are discarded.
+func SubmitPostmortem(id uint64, req model.PostmortemSubmitReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	pm, resp, code, ok := editablePostmortem(id, authID, "3609.1")
+	if !ok {
+		return resp, code
+	}
+
+	if pm.Status != model.PostmortemDraft {
+		return setErrorMessage("only drafts can be submitted for review", http.StatusConflict)
+	}
+
+	reviewers := uniqueIDs(req.Reviewers)
+	if len(reviewers) == 0 {
+		return setErrorMessage("at least one reviewer is required", http.StatusBadRequest)
+	}
+	for _, reviewer := range reviewers {
+		if reviewer == authID {
+			return setErrorMessage("submitter cannot review the postmortem", http.StatusBadRequest)
+		}
+	}
+
+	var found int64
+	if err := db.Model(&model.Auth{}).Where("auth_id IN ?", reviewers).Count(&found).Error; err != nil {
+		log.WithError(err).Error("error code: 3609.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if int(found) != len(reviewers) {
+		return setErrorMessage("reviewer not found", http.StatusNotFound)
+	}
+
+	reviews := make([]model.PostmortemReview, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		reviews = append(reviews, model.PostmortemReview{PostmortemID: pm.PostmortemID, AuthID: reviewer})
+	}
+
+	tx := db.Begin()
+	if err := tx.Where("postmortem_id = ?", pm.PostmortemID).Delete(&model.PostmortemReview{}).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3609.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := tx.Create(&reviews).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3609.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := tx.Model(&model.Postmortem{PostmortemID: pm.PostmortemID}).Update("status", model.PostmortemInReview).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3609.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3609.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	pm.Reviews = reviews
+
+	httpResponse.Message = pm
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// ReviewPostmortem records the verdict of a requested reviewer.
+// Requesting changes sends the postmortem back to draft.
+func ReviewPostmortem(id uint64, req model.PostmortemReviewReq, approve bool, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var pm model.Postmortem
+
+	if err := postmortemQuery().First(&pm, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3610.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("postmortem not found", http.StatusNotFound)
+	}
+
+	if pm.Status != model.PostmortemInReview {
+		return setErrorMessage("postmortem is not in review", http.StatusConflict)
+	}
+
+	reviewIndex := -1
+	for i, review := range pm.Reviews {
+		if review.AuthID == authID {
+			reviewIndex = i
+		}
+	}
+	if reviewIndex < 0 {
+		return setErrorMessage("only requested reviewers can review the postmortem", http.StatusForbidden)
+	}
+
+	review := &pm.Reviews[reviewIndex]
+	review.Approved = approve
+	review.ApprovedAt = nil
+	review.Comment = req.Comment
+	if approve {
+		now := time.Now()
+		review.ApprovedAt = &now
+	}
+
+	tx := db.Begin()
+	if err := tx.Save(review).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3610.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if !approve {
+		pm.Status = model.PostmortemDraft
+		if err := tx.Model(&model.Postmortem{PostmortemID: pm.PostmortemID}).Update("status", pm.Status).Error; err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 3610.3")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3610.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = pm
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// PublishPostmortem publishes a postmortem approved by all reviewers
+func PublishPostmortem(id, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	pm, resp, code, ok := editablePostmortem(id, authID, "3611.1")
+	if !ok {
+		return resp, code
+	}
+
+	if pm.Status != model.PostmortemInReview {
+		return setErrorMessage("only postmortems in review can be published", http.StatusConflict)
+	}
+
+	pending := 0
+	for _, review := range pm.Reviews {
+		if !review.Approved {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return setErrorMessage(fmt.Sprintf("waiting for approval of %d reviewer(s)", pending), http.StatusConflict)
+	}
+
+	now := time.Now()
+	pm.Status = model.PostmortemPublished
+	pm.PublishedAt = &now
+
+	tx := db.Begin()
+	if err := tx.Model(&model.Postmortem{PostmortemID: pm.PostmortemID}).Updates(map[string]interface{}{"status": pm.Status, "published_at": now}).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3611.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := recordEvent(tx, pm.IncidentID, authID, model.EventPostmortemPublished, "postmortem published"); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3611.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3611.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = pm
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// ExportPostmortem renders a postmortem as a Markdown document
+func ExportPostmortem(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var pm model.Postmortem
+
+	if err := postmortemQuery().First(&pm, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3612.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("postmortem not found", http.StatusNotFound)
+	}
+
+	var incident model.Incident
+	if err := db.First(&incident, pm.IncidentID).Error; err != nil {
+		log.WithError(err).Error("error code: 3612.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = service.RenderPostmortemMarkdown(pm, incident)
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// postmortemQuery preloads sections in document order and reviews
+func postmortemQuery() *gorm.DB {
+	return database.GetDB().
+		Preload("Sections", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Reviews")
+}
+
+// editablePostmortem loads a postmortem and checks the user may
+// edit its incident
+func editablePostmortem(id, authID uint64, errCode string) (pm model.Postmortem, httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	if err := postmortemQuery().First(&pm, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("postmortem not found", http.StatusNotFound)
+		return
+	}
+
+	_, httpResponse, httpStatusCode, ok = editableIncident(pm.IncidentID, authID, errCode)
+	return
+}
+
+// validatePostmortemTemplateReq normalizes the payload and returns
+// an error message when it is not acceptable
+func validatePostmortemTemplateReq(req *model.PostmortemTemplateReq) string {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return "template name is required"
+	}
+
+	if len(req.Sections) == 0 {
+		return "a template needs at least one section"
+	}
+
+	seen := make(map[string]bool, len(req.Sections))
+	for i := range req.Sections {
+		section := &req.Sections[i]
+		section.Key = strings.TrimSpace(section.Key)
+		if section.Key == "" {
+			return "section key is required"
+		}
+		if seen[section.Key] {
+			return "duplicate section key: " + section.Key
+		}
+		seen[section.Key] = true
+
+		section.Title = strings.TrimSpace(section.Title)
+		if section.Title == "" {
+			section.Title = section.Key
+		}
+	}
+
+	return ""
+}
+
+// uniqueIDs removes duplicates and zero values, keeping the order
+func uniqueIDs(ids []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(ids))
+	unique := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
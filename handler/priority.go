@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// GetPriorityMatrix returns the configured matrix and SLA targets
+func GetPriorityMatrix() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	conf := config.GetConfig().Priority
+
+	matrix := model.PriorityMatrix{}
+	for _, impact := range model.Levels {
+		for _, urgency := range model.Levels {
+			matrix.Rules = append(matrix.Rules, model.PriorityRule{
+				Impact:   impact,
+				Urgency:  urgency,
+				Priority: matrixPriority(impact, urgency),
+			})
+		}
+	}
+	for i, priority := range model.Priorities {
+		matrix.Targets = append(matrix.Targets, model.PriorityTarget{
+			Priority:   priority,
+			Response:   conf.ResponseTargets[i].Seconds(),
+			Resolution: conf.ResolutionTargets[i].Seconds(),
+		})
+	}
+
+	httpResponse.Message = matrix
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// matrixPriority looks up the priority of valid levels
+func matrixPriority(impact, urgency model.LevelType) model.PriorityType {
+	return model.PriorityType(config.GetConfig().Priority.Matrix[levelIndex(impact)][levelIndex(urgency)])
+}
+
+func levelIndex(level model.LevelType) int {
+	for i, l := range model.Levels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// setPriority sets impact, urgency and priority of an incident,
+// a priority differing from the matrix is kept as an override
+func setPriority(incident *model.Incident, impact, urgency model.LevelType, priority model.PriorityType) string {
+	if impact != "" && !impact.Valid() {
+		return "impact must be high, medium or low"
+	}
+	if urgency != "" && !urgency.Valid() {
+		return "urgency must be high, medium or low"
+	}
+	if (impact == "") != (urgency == "") {
+		return "impact and urgency must be set together"
+	}
+	if priority != "" && !priority.Valid() {
+		return "priority must be P1, P2, P3, P4 or P5"
+	}
+
+	var derived model.PriorityType
+	if impact != "" {
+		derived = matrixPriority(impact, urgency)
+	}
+
+	incident.Impact = impact
+	incident.Urgency = urgency
+	incident.Priority = derived
+	incident.PriorityOverride = false
+	if priority != "" && priority != derived {
+		incident.Priority = priority
+		incident.PriorityOverride = true
+	}
+	return ""
+}
+
+// priorityMessage describes a priority change for the timeline,
+// empty when nothing changed
+func priorityMessage(previous model.PriorityType, wasOverride bool, incident model.Incident) string {
+	if incident.Priority == previous && incident.PriorityOverride == wasOverride {
+		return ""
+	}
+	if incident.Priority == "" {
+		return "priority removed"
+	}
+
+	message := "priority set to " + string(incident.Priority)
+	if previous != "" {
+		message = "priority changed from " + string(previous) + " to " + string(incident.Priority)
+	}
+
+	switch {
+	case incident.PriorityOverride && incident.Impact != "":
+		return message + " manually, matrix gives " + string(matrixPriority(incident.Impact, incident.Urgency))
+	case incident.PriorityOverride:
+		return message + " manually"
+	}
+	return message + " by impact " + string(incident.Impact) + " and urgency " + string(incident.Urgency)
+}
+
+// incidentOrderScope sorts an incident list, validated by validIncidentSort
+func incidentOrderScope(sort string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		// P1 sorts before P5 as text
+		unset := "CASE WHEN incidents.priority IS NULL OR incidents.priority = '' THEN 1 ELSE 0 END"
+
+		switch sort {
+		case model.SortPriority:
+			return db.Order(unset).Order("incidents.priority").Order("incidents.created_at DESC")
+		case model.SortPriorityDesc:
+			return db.Order(unset).Order("incidents.priority DESC").Order("incidents.created_at DESC")
+		case model.SortCreated:
+			return db.Order("incidents.created_at")
+		case model.SortCreatedDesc:
+			return db.Order("incidents.created_at DESC")
+		}
+		return db
+	}
+}
+
+func validIncidentSort(sort string) bool {
+	return sort == "" || sort == model.SortPriority || sort == model.SortPriorityDesc ||
+		sort == model.SortCreated || sort == model.SortCreatedDesc
+}
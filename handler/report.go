@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetMetrics computes response KPIs of the incidents created in a date
+// range. All aggregation is done by the database.
+func GetMetrics(filter model.MetricsFilter) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.AddDate(0, 0, -model.MetricsDefaultDays)
+	}
+	if !filter.From.Before(filter.To) {
+		return setErrorMessage("from must be before to", http.StatusBadRequest)
+	}
+	if filter.To.Sub(filter.From) > model.MetricsMaxDays*24*time.Hour {
+		return setErrorMessage(fmt.Sprintf("date range must not exceed %d days", model.MetricsMaxDays), http.StatusBadRequest)
+	}
+
+	dialect := db.Dialector.Name()
+	scope := metricsScope(filter)
+	incidents := func() *gorm.DB { return db.Model(&model.Incident{}).Scopes(scope) }
+
+	report := model.MetricsReport{From: filter.From, To: filter.To}
+
+	if err := incidents().Count(&report.Total).Error; err != nil {
+		log.WithError(err).Error("error code: 3701.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	var err error
+	tta := secondsBetween(dialect, "incidents.created_at", "incidents.acknowledged_at")
+	if report.TimeToAcknowledge, err = durationStats(incidents, dialect, "incidents.acknowledged_at", tta); err != nil {
+		log.WithError(err).Error("error code: 3701.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	ttr := secondsBetween(dialect, "incidents.created_at", "incidents.resolved_at")
+	if report.TimeToResolve, err = durationStats(incidents, dialect, "incidents.resolved_at", ttr); err != nil {
+		log.WithError(err).Error("error code: 3701.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	now := time.Now()
+	if report.SLA.Acknowledge, err = slaCompliance(incidents, "incidents.acknowledge_due_at", "incidents.acknowledged_at", now); err != nil {
+		log.WithError(err).Error("error code: 3701.9")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if report.SLA.Resolve, err = slaCompliance(incidents, "incidents.resolve_due_at", "incidents.resolved_at", now); err != nil {
+		log.WithError(err).Error("error code: 3701.10")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	report.BySeverity = []model.CountBucket{}
+	err = incidents().Select("incidents.severity AS label, COUNT(*) AS count").
+		Group("incidents.severity").Order("count DESC").Scan(&report.BySeverity).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 3701.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	report.ByStatus = []model.CountBucket{}
+	err = incidents().Select("incidents.status AS label, COUNT(*) AS count").
+		Group("incidents.status").Order("count DESC").Scan(&report.ByStatus).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 3701.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	report.ByService = []model.CountBucket{}
+	err = incidents().
+		Joins("JOIN incident_services ON incident_services.incident_id = incidents.incident_id").
+		Joins("JOIN services ON services.service_id = incident_services.service_id").
+		Select("services.name AS label, COUNT(*) AS count").
+		Group("services.service_id, services.name").Order("count DESC").Scan(&report.ByService).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 3701.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	var assignees []struct {
+		AssignedTo uint64
+		Count      int64
+	}
+	err = incidents().Select("incidents.assigned_to, COUNT(*) AS count").
+		Group("incidents.assigned_to").Order("count DESC").Scan(&assignees).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 3701.7")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	report.ByAssignee = make([]model.CountBucket, 0, len(assignees))
+	for _, assignee := range assignees {
+		report.ByAssignee = append(report.ByAssignee, model.CountBucket{
+			Label: fmt.Sprintf("%d", assignee.AssignedTo),
+			Count: assignee.Count,
+		})
+	}
+
+	var weeks []model.WeeklyMetrics
+	week := weekStart(dialect, "incidents.created_at")
+	err = incidents().
+		Select(
+			week + " AS week_start, COUNT(*) AS incidents, " +
+				"COALESCE(AVG(" + tta + "), 0) AS mtta, COALESCE(AVG(" + ttr + "), 0) AS mttr",
+		).
+		Group(week).Order(week).Scan(&weeks).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 3701.8")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	report.Weekly = weeklyTrend(weeks, filter.From, filter.To)
+
+	httpResponse.Message = report
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// metricsScope restricts a query to the incidents of a report,
+// merged duplicates only count through the incident they went into
+func metricsScope(filter model.MetricsFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("incidents.created_at >= ? AND incidents.created_at < ?", filter.From, filter.To).
+			Where("incidents.duplicate_of IS NULL")
+		if filter.TeamID != 0 {
+			db = db.Where("incidents.team_id = ?", filter.TeamID)
+		}
+		return db
+	}
+}
+
+// durationStats aggregates mean, median and p90 of a duration in
+// seconds. Incidents which did not reach the milestone are skipped.
+// Percentiles use the nearest-rank method on every driver.
+func durationStats(incidents func() *gorm.DB, dialect, milestone, seconds string) (model.DurationStats, error) {
+	stats := model.DurationStats{}
+
+	var row struct {
+		Count int64
+		Mean  *float64
+	}
+	err := incidents().Where(milestone + " IS NOT NULL").
+		Select("COUNT(*) AS count, AVG(" + seconds + ") AS mean").
+		Scan(&row).Error
+	if err != nil || row.Count == 0 {
+		return stats, err
+	}
+
+	stats.Count = row.Count
+	if row.Mean != nil {
+		stats.Mean = *row.Mean
+	}
+
+	if dialect == "postgres" {
+		var percentiles struct {
+			Median float64
+			P90    float64
+		}
+		err := incidents().Where(milestone + " IS NOT NULL").
+			Select(
+				"percentile_disc(0.5) WITHIN GROUP (ORDER BY " + seconds + ") AS median, " +
+					"percentile_disc(0.9) WITHIN GROUP (ORDER BY " + seconds + ") AS p90",
+			).
+			Scan(&percentiles).Error
+		stats.Median, stats.P90 = percentiles.Median, percentiles.P90
+		return roundStats(stats), err
+	}
+
+	// no percentile functions: pick the ranked row
+	percentile := func(p float64) (float64, error) {
+		var value float64
+		rank := int(math.Ceil(p*float64(row.Count))) - 1
+		err := incidents().Where(milestone + " IS NOT NULL").
+			Select(seconds + " AS value").
+			Order(seconds).Offset(rank).Limit(1).
+			Scan(&value).Error
+		return value, err
+	}
+
+	if stats.Median, err = percentile(0.5); err != nil {
+		return stats, err
+	}
+	stats.P90, err = percentile(0.9)
+	return roundStats(stats), err
+}
+
+// slaCompliance counts the met, breached and running targets,
+// an unmet target counts as breached once it is overdue
+func slaCompliance(incidents func() *gorm.DB, due, reached string, now time.Time) (model.SLACompliance, error) {
+	compliance := model.SLACompliance{}
+
+	err := incidents().Where(due+" IS NOT NULL").
+		Select(
+			"COALESCE(SUM(CASE WHEN "+reached+" IS NOT NULL AND "+reached+" <= "+due+" THEN 1 ELSE 0 END), 0) AS met, "+
+				"COALESCE(SUM(CASE WHEN "+reached+" > "+due+" OR ("+reached+" IS NULL AND "+due+" < ?) THEN 1 ELSE 0 END), 0) AS breached, "+
+				"COALESCE(SUM(CASE WHEN "+reached+" IS NULL AND "+due+" >= ? THEN 1 ELSE 0 END), 0) AS pending",
+			now, now,
+		).
+		Scan(&compliance).Error
+	if err != nil {
+		return compliance, err
+	}
+
+	if decided := compliance.Met + compliance.Breached; decided > 0 {
+		percent := math.Round(float64(compliance.Met)/float64(decided)*1000) / 10
+		compliance.Percent = &percent
+	}
+	return compliance, nil
+}
+
+// roundStats rounds to whole seconds
+func roundStats(stats model.DurationStats) model.DurationStats {
+	stats.Mean = math.Round(stats.Mean)
+	stats.Median = math.Round(stats.Median)
+	stats.P90 = math.Round(stats.P90)
+	return stats
+}
+
+// secondsBetween returns the SQL expression for the seconds
+// elapsed between two timestamp columns
+func secondsBetween(dialect, start, end string) string {
+	switch dialect {
+	case "postgres":
+		return "EXTRACT(EPOCH FROM (" + end + " - " + start + "))"
+	case "mysql":
+		return "TIMESTAMPDIFF(SECOND, " + start + ", " + end + ")"
+	default:
+		return "((julianday(" + end + ") - julianday(" + start + ")) * 86400.0)"
+	}
+}
+
+// weekStart returns the SQL expression for the Monday of the
+// week of a timestamp column, formatted as YYYY-MM-DD
+func weekStart(dialect, column string) string {
+	switch dialect {
+	case "postgres":
+		return "to_char(date_trunc('week', " + column + "), 'YYYY-MM-DD')"
+	case "mysql":
+		return "DATE_FORMAT(DATE_SUB(DATE(" + column + "), INTERVAL WEEKDAY(" + column + ") DAY), '%Y-%m-%d')"
+	default:
+		return "date(" + column + ", 'weekday 0', '-6 days')"
+	}
+}
+
+// weeklyTrend fills in the weeks without incidents and computes the
+// week-over-week change of the incident volume
+func weeklyTrend(weeks []model.WeeklyMetrics, from, to time.Time) []model.WeeklyMetrics {
+	byStart := make(map[string]model.WeeklyMetrics, len(weeks))
+	for _, week := range weeks {
+		byStart[week.WeekStart] = week
+	}
+
+	// Monday of the first week
+	day := from.UTC().Truncate(24 * time.Hour)
+	day = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+
+	trend := []model.WeeklyMetrics{}
+	for ; day.Before(to); day = day.AddDate(0, 0, 7) {
+		start := day.Format(time.DateOnly)
+
+		week, ok := byStart[start]
+		if !ok {
+			week = model.WeeklyMetrics{WeekStart: start}
+		}
+		week.MTTA = math.Round(week.MTTA)
+		week.MTTR = math.Round(week.MTTR)
+
+		if n := len(trend); n > 0 && trend[n-1].Incidents > 0 {
+			change := float64(week.Incidents-trend[n-1].Incidents) / float64(trend[n-1].Incidents) * 100
+			change = math.Round(change*10) / 10
+			week.Change = &change
+		}
+
+		trend = append(trend, week)
+	}
+
+	return trend
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateSchedule creates a new on-call rotation
+func CreateSchedule(req model.ScheduleReq) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return setErrorMessage("schedule name is required", http.StatusBadRequest)
+	}
+
+	if len(req.Members) == 0 {
+		return setErrorMessage("at least one member is required", http.StatusBadRequest)
+	}
+
+	if req.TimeZone == "" {
+		req.TimeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(req.TimeZone); err != nil {
+		return setErrorMessage("unknown time zone", http.StatusBadRequest)
+	}
+
+	if req.ShiftHours == 0 {
+		req.ShiftHours = 168
+	}
+
+	if req.RotationStart.IsZero() {
+		req.RotationStart = time.Now().UTC().Truncate(time.Hour)
+	}
+
+	schedule := model.Schedule{
+		Name:          req.Name,
+		Description:   req.Description,
+		TimeZone:      req.TimeZone,
+		RotationStart: req.RotationStart,
+		ShiftHours:    req.ShiftHours,
+	}
+
+	for i, authID := range req.Members {
+		// check if member exists
+		if err := db.First(&model.Auth{}, authID).Error; err != nil {
+			if err.Error() != database.RecordNotFound {
+				log.WithError(err).Error("error code: 3001.1")
+				return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			}
+			return setErrorMessage("schedule member not found", http.StatusNotFound)
+		}
+
+		schedule.Members = append(schedule.Members, model.ScheduleMember{
+			AuthID:   authID,
+			Position: uint32(i),
+		})
+	}
+
+	if err := db.Create(&schedule).Error; err != nil {
+		log.WithError(err).Error("error code: 3001.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = schedule
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// GetSchedules lists all on-call schedules with their members
+func GetSchedules() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var schedules []model.Schedule
+
+	if err := db.Preload("Members").Find(&schedules).Error; err != nil {
+		log.WithError(err).Error("error code: 3002.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = schedules
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetScheduleByID fetches one on-call schedule
+func GetScheduleByID(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var schedule model.Schedule
+
+	if err := db.Preload("Members").First(&schedule, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3003.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("schedule not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = schedule
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetScheduleCalendar renders the rotation of one schedule for
+// the next N weeks as an iCalendar document
+func GetScheduleCalendar(id uint64, weeks int) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var schedule model.Schedule
+
+	if err := db.Preload("Members").First(&schedule, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3004.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("schedule not found", http.StatusNotFound)
+	}
+
+	from, to := calendarWindow(weeks)
+	shifts := service.ComputeShifts(schedule, from, to)
+
+	httpResponse.Message = service.RenderICalendar(schedule.Name, shifts)
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// CreateCalendarFeed issues a new on-call feed secret for the user.
+// Any previously issued secret stops working.
+func CreateCalendarFeed(authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.WithError(err).Error("error code: 3005.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	token := hex.EncodeToString(secret)
+
+	tokenHash, err := service.CalcHash([]byte(token), config.GetConfig().Security.Blake2bSec)
+	if err != nil {
+		log.WithError(err).Error("error code: 3005.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	tx := db.Begin()
+	if err := tx.Where("id_auth = ?", authID).Delete(&model.CalendarFeed{}).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3005.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	feed := model.CalendarFeed{
+		TokenHash: hex.EncodeToString(tokenHash),
+		IDAuth:    authID,
+	}
+	if err := tx.Create(&feed).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3005.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3005.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = model.CalendarFeedRes{Token: token}
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// RevokeCalendarFeed deletes the user's on-call feed secret
+func RevokeCalendarFeed(authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	result := db.Where("id_auth = ?", authID).Delete(&model.CalendarFeed{})
+	if result.Error != nil {
+		log.WithError(result.Error).Error("error code: 3006.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected == 0 {
+		return setErrorMessage("no calendar feed found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = "calendar feed revoked"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetOnCallCalendar authenticates the request by the feed secret and
+// renders all shifts of its owner across schedules for the next N weeks
+func GetOnCallCalendar(token string, weeks int) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return setErrorMessage("feed token missing", http.StatusUnauthorized)
+	}
+
+	tokenHash, err := service.CalcHash([]byte(token), config.GetConfig().Security.Blake2bSec)
+	if err != nil {
+		log.WithError(err).Error("error code: 3007.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	var feed model.CalendarFeed
+	if err := db.Where("token_hash = ?", hex.EncodeToString(tokenHash)).First(&feed).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3007.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("invalid feed token", http.StatusUnauthorized)
+	}
+
+	var schedules []model.Schedule
+	err = db.Preload("Members").
+		Where("schedule_id IN (?)", db.Model(&model.ScheduleMember{}).Select("schedule_id").Where("auth_id = ?", feed.IDAuth)).
+		Find(&schedules).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 3007.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	from, to := calendarWindow(weeks)
+	shifts := []model.Shift{}
+	for _, schedule := range schedules {
+		for _, shift := range service.ComputeShifts(schedule, from, to) {
+			if shift.AuthID == feed.IDAuth {
+				shifts = append(shifts, shift)
+			}
+		}
+	}
+
+	httpResponse.Message = service.RenderICalendar("My on-call shifts", shifts)
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// calendarWindow returns the export window starting now
+func calendarWindow(weeks int) (from, to time.Time) {
+	if weeks <= 0 {
+		weeks = model.CalendarDefaultWeeks
+	}
+	if weeks > model.CalendarMaxWeeks {
+		weeks = model.CalendarMaxWeeks
+	}
+
+	from = time.Now().UTC()
+	to = from.AddDate(0, 0, 7*weeks)
+	return
+}
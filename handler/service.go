@@ -0,0 +1,385 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateService adds a new service to the catalog
+func CreateService(req model.ServiceReq) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if msg := validateServiceReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	// service name must be unique
+	err := db.Where("name = ?", req.Name).First(&model.Service{}).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3101.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err == nil {
+		return setErrorMessage("service name already exists", http.StatusConflict)
+	}
+
+	dependencies, err := findServices(req.Dependencies)
+	if err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3101.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("dependency not found", http.StatusNotFound)
+	}
+
+	svc := model.Service{
+		Name:             req.Name,
+		Description:      req.Description,
+		OwnerTeam:        req.OwnerTeam,
+		Tier:             req.Tier,
+		RunbookURL:       req.RunbookURL,
+		EscalationPolicy: req.EscalationPolicy,
+		Dependencies:     dependencies,
+	}
+
+	if err := db.Create(&svc).Error; err != nil {
+		log.WithError(err).Error("error code: 3101.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = svc
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// GetServices lists the service catalog
+func GetServices() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var services []model.Service
+
+	if err := db.Preload("Dependencies").Order("name").Find(&services).Error; err != nil {
+		log.WithError(err).Error("error code: 3102.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = services
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetServiceByID fetches one service with its direct dependencies
+func GetServiceByID(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var svc model.Service
+
+	if err := db.Preload("Dependencies").First(&svc, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3103.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("service not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = svc
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// UpdateService replaces the attributes and dependencies of a service
+func UpdateService(id uint64, req model.ServiceReq) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if msg := validateServiceReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	var existing model.Service
+
+	if err := db.First(&existing, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3104.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("service not found", http.StatusNotFound)
+	}
+
+	// service name must be unique
+	err := db.Where("name = ? AND service_id <> ?", req.Name, id).First(&model.Service{}).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3104.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err == nil {
+		return setErrorMessage("service name already exists", http.StatusConflict)
+	}
+
+	for _, dependencyID := range req.Dependencies {
+		if dependencyID == id {
+			return setErrorMessage("service cannot depend on itself", http.StatusBadRequest)
+		}
+	}
+
+	dependencies, err := findServices(req.Dependencies)
+	if err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3104.3")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("dependency not found", http.StatusNotFound)
+	}
+
+	// cycle detection: none of the new dependencies may
+	// (transitively) depend on this service already
+	graph, nodes, err := loadServiceGraph()
+	if err != nil {
+		log.WithError(err).Error("error code: 3104.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	for _, dependency := range dependencies {
+		if path := graph.PathTo(dependency.ServiceID, id); path != nil {
+			names := []string{existing.Name}
+			for _, serviceID := range path {
+				names = append(names, nodes[serviceID].Name)
+			}
+			return setErrorMessage(
+				"dependency cycle detected: "+strings.Join(names, " -> "),
+				http.StatusConflict,
+			)
+		}
+	}
+
+	existing.Name = req.Name
+	existing.Description = req.Description
+	existing.OwnerTeam = req.OwnerTeam
+	existing.Tier = req.Tier
+	existing.RunbookURL = req.RunbookURL
+	existing.EscalationPolicy = req.EscalationPolicy
+
+	tx := db.Begin()
+	if err := tx.Save(&existing).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3104.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := tx.Model(&existing).Association("Dependencies").Replace(dependencies); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3104.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3104.7")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	existing.Dependencies = dependencies
+
+	httpResponse.Message = existing
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DeleteService removes a service from the catalog
+func DeleteService(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	// the deletion marker frees the name for new services
+	result := db.Model(&model.Service{ServiceID: id}).UpdateColumns(map[string]any{
+		"deleted_at": time.Now(),
+		"deleted_id": id,
+	})
+	if result.Error != nil {
+		log.WithError(result.Error).Error("error code: 3105.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected == 0 {
+		return setErrorMessage("service not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = "service deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetServiceIncidents returns the incident history of a service,
+// newest first
+func GetServiceIncidents(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Service{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3106.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("service not found", http.StatusNotFound)
+	}
+
+	var incidents []model.Incident
+
+	err := db.Preload("Services").
+		Where("incident_id IN (?)", db.Table("incident_services").Select("incident_id").Where("service_id = ?", id)).
+		Order("created_at DESC").
+		Find(&incidents).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 3106.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = incidents
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetServiceDependencies walks the dependency graph from one service.
+// A depth of 0 returns the complete transitive closure.
+func GetServiceDependencies(id uint64, depth int, direction string) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	if depth < 0 {
+		return setErrorMessage("depth must not be negative", http.StatusBadRequest)
+	}
+
+	if direction == "" {
+		direction = model.DependencyBoth
+	}
+	if direction != model.DependencyUpstream &&
+		direction != model.DependencyDownstream &&
+		direction != model.DependencyBoth {
+		return setErrorMessage("direction must be upstream, downstream or both", http.StatusBadRequest)
+	}
+
+	graph, nodes, err := loadServiceGraph()
+	if err != nil {
+		log.WithError(err).Error("error code: 3107.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	root, ok := nodes[id]
+	if !ok {
+		return setErrorMessage("service not found", http.StatusNotFound)
+	}
+
+	result := model.DependencyGraph{
+		ServiceID:  root.ServiceID,
+		Name:       root.Name,
+		Upstream:   []model.DependencyNode{},
+		Downstream: []model.DependencyNode{},
+	}
+
+	if direction != model.DependencyDownstream {
+		result.Upstream = describeNodes(graph.Walk([]uint64{id}, model.DependencyUpstream, depth), nodes)
+	}
+	if direction != model.DependencyUpstream {
+		result.Downstream = describeNodes(graph.Walk([]uint64{id}, model.DependencyDownstream, depth), nodes)
+	}
+
+	httpResponse.Message = result
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// loadServiceGraph loads all live services and the dependency
+// edges between them
+func loadServiceGraph() (*service.ServiceGraph, map[uint64]model.Service, error) {
+	db := database.GetDB()
+
+	var services []model.Service
+	if err := db.Select("service_id", "name", "tier").Find(&services).Error; err != nil {
+		return nil, nil, err
+	}
+
+	nodes := make(map[uint64]model.Service, len(services))
+	for _, svc := range services {
+		nodes[svc.ServiceID] = svc
+	}
+
+	var edges []model.ServiceDependency
+	if err := db.Table("service_dependencies").Find(&edges).Error; err != nil {
+		return nil, nil, err
+	}
+
+	// skip edges of deleted services
+	live := edges[:0]
+	for _, edge := range edges {
+		_, okService := nodes[edge.ServiceID]
+		_, okDependency := nodes[edge.DependencyID]
+		if okService && okDependency {
+			live = append(live, edge)
+		}
+	}
+
+	return service.NewServiceGraph(live), nodes, nil
+}
+
+// describeNodes fills in service names and tiers of walked nodes
+func describeNodes(walked []model.DependencyNode, nodes map[uint64]model.Service) []model.DependencyNode {
+	for i := range walked {
+		walked[i].Name = nodes[walked[i].ServiceID].Name
+		walked[i].Tier = nodes[walked[i].ServiceID].Tier
+	}
+	return walked
+}
+
+// validateServiceReq normalizes the payload and returns
+// an error message when it is not acceptable
+func validateServiceReq(req *model.ServiceReq) string {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return "service name is required"
+	}
+
+	if req.Tier == 0 {
+		req.Tier = 3
+	}
+	if req.Tier < model.ServiceTierMin || req.Tier > model.ServiceTierMax {
+		return "service tier must be between 1 and 4"
+	}
+
+	req.RunbookURL = strings.TrimSpace(req.RunbookURL)
+	if req.RunbookURL != "" {
+		if _, err := url.ParseRequestURI(req.RunbookURL); err != nil {
+			return "invalid runbook URL"
+		}
+	}
+
+	return ""
+}
+
+// findServices loads the services with the given IDs. It returns
+// a record not found error when any of them does not exist.
+func findServices(ids []uint64) ([]model.Service, error) {
+	services := []model.Service{}
+	if len(ids) == 0 {
+		return services, nil
+	}
+
+	// remove duplicates
+	seen := make(map[uint64]bool, len(ids))
+	unique := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	if err := database.GetDB().Where("service_id IN ?", unique).Find(&services).Error; err != nil {
+		return nil, err
+	}
+
+	if len(services) != len(unique) {
+		return nil, errors.New(database.RecordNotFound)
+	}
+
+	return services, nil
+}
@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func GetSLAPolicies() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	policies, err := slaPolicies(database.GetDB())
+	if err != nil {
+		log.WithError(err).Error("error code: 4401.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = policies
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// CreateSLAPolicy adds a policy and applies it to the open incidents
+func CreateSLAPolicy(req model.SLAPolicyReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if msg := validateSLAPolicyReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	if msg, err := slaPolicyConflict(db, req, 0); err != nil {
+		log.WithError(err).Error("error code: 4402.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	} else if msg != "" {
+		return setErrorMessage(msg, http.StatusConflict)
+	}
+
+	if resp, code, ok := slaPolicyCalendar(db, req, "4402.4"); !ok {
+		return resp, code
+	}
+
+	policy := model.SLAPolicy{
+		Name:              req.Name,
+		Priority:          req.Priority,
+		Severity:          req.Severity,
+		AcknowledgeWithin: req.AcknowledgeWithin,
+		ResolveWithin:     req.ResolveWithin,
+		CalendarID:        req.CalendarID,
+	}
+
+	tx := db.Begin()
+	if err := tx.Create(&policy).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4402.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := refreshSLAs(tx); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4402.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4402.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = policy
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// UpdateSLAPolicy replaces a policy, the due times of the open
+// incidents are computed again
+func UpdateSLAPolicy(id uint64, req model.SLAPolicyReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	if msg := validateSLAPolicyReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	var policy model.SLAPolicy
+
+	if err := db.First(&policy, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 4403.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("SLA policy not found", http.StatusNotFound)
+	}
+
+	if msg, err := slaPolicyConflict(db, req, id); err != nil {
+		log.WithError(err).Error("error code: 4403.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	} else if msg != "" {
+		return setErrorMessage(msg, http.StatusConflict)
+	}
+
+	if resp, code, ok := slaPolicyCalendar(db, req, "4403.5"); !ok {
+		return resp, code
+	}
+
+	policy.Name = req.Name
+	policy.Priority = req.Priority
+	policy.Severity = req.Severity
+	policy.AcknowledgeWithin = req.AcknowledgeWithin
+	policy.ResolveWithin = req.ResolveWithin
+	policy.CalendarID = req.CalendarID
+
+	tx := db.Begin()
+	if err := tx.Save(&policy).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4403.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := refreshSLAs(tx); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4403.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4403.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = policy
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func DeleteSLAPolicy(id, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if resp, code, ok := requireAdmin(authID); !ok {
+		return resp, code
+	}
+
+	tx := db.Begin()
+	result := tx.Delete(&model.SLAPolicy{}, id)
+	if result.Error != nil {
+		tx.Rollback()
+		log.WithError(result.Error).Error("error code: 4404.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return setErrorMessage("SLA policy not found", http.StatusNotFound)
+	}
+	if err := refreshSLAs(tx); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4404.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4404.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "SLA policy deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func slaPolicies(tx *gorm.DB) ([]model.SLAPolicy, error) {
+	policies := []model.SLAPolicy{}
+	err := tx.Preload("Calendar.Holidays").Order("policy_id").Find(&policies).Error
+	return policies, err
+}
+
+// applySLA computes the due times of an incident from the policy of
+// its priority, else of its severity, else from the priority targets.
+// It reports whether anything changed.
+func applySLA(incident *model.Incident, policies []model.SLAPolicy) (bool, error) {
+	var policy *model.SLAPolicy
+	for i := range policies {
+		if incident.Priority != "" && policies[i].Priority == incident.Priority {
+			policy = &policies[i]
+			break
+		}
+		if policies[i].Severity != "" && policies[i].Severity == incident.Severity && policy == nil {
+			policy = &policies[i]
+		}
+	}
+
+	var policyID *uint64
+	var acknowledge, resolve *time.Time
+	var err error
+
+	switch {
+	case policy != nil:
+		policyID = &policy.PolicyID
+		if acknowledge, err = slaDue(incident.CreatedAt, policy.AcknowledgeWithin, policy.Calendar); err != nil {
+			return false, err
+		}
+		if resolve, err = slaDue(incident.CreatedAt, policy.ResolveWithin, policy.Calendar); err != nil {
+			return false, err
+		}
+
+	case incident.Priority != "":
+		conf := config.GetConfig().Priority
+		i := int(incident.Priority[1] - '1')
+		acknowledge, _ = slaDue(incident.CreatedAt, int64(conf.ResponseTargets[i].Seconds()), nil)
+		resolve, _ = slaDue(incident.CreatedAt, int64(conf.ResolutionTargets[i].Seconds()), nil)
+	}
+
+	changed := !sameID(policyID, incident.SLAPolicyID)
+
+	// alerts start over once a due time moves
+	if !sameTime(acknowledge, incident.AcknowledgeDueAt) {
+		incident.SLAAlerts &^= model.SLAAcknowledgeAtRisk | model.SLAAcknowledgeBreached
+		changed = true
+	}
+	if !sameTime(resolve, incident.ResolveDueAt) {
+		incident.SLAAlerts &^= model.SLAResolveAtRisk | model.SLAResolveBreached
+		changed = true
+	}
+
+	incident.SLAPolicyID = policyID
+	incident.AcknowledgeDueAt = acknowledge
+	incident.ResolveDueAt = resolve
+	return changed, nil
+}
+
+// slaDue returns the due time of a target, nil without a target
+func slaDue(start time.Time, seconds int64, calendar *model.BusinessCalendar) (*time.Time, error) {
+	if seconds <= 0 {
+		return nil, nil
+	}
+
+	d := time.Duration(seconds) * time.Second
+	if calendar == nil {
+		due := start.Add(d)
+		return &due, nil
+	}
+
+	businessTime, err := service.NewBusinessTime(*calendar)
+	if err != nil {
+		return nil, err
+	}
+	due, err := businessTime.Add(start, d)
+	if err != nil {
+		return nil, err
+	}
+	due = due.In(start.Location())
+	return &due, nil
+}
+
+// refreshSLAs applies the current policies to the open incidents
+func refreshSLAs(tx *gorm.DB) error {
+	policies, err := slaPolicies(tx)
+	if err != nil {
+		return err
+	}
+
+	var incidents []model.Incident
+	if err := tx.Where("status <> ? AND duplicate_of IS NULL", model.Closed).Find(&incidents).Error; err != nil {
+		return err
+	}
+
+	for i := range incidents {
+		changed, err := applySLA(&incidents[i], policies)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		if err := tx.Model(&model.Incident{IncidentID: incidents[i].IncidentID}).UpdateColumns(slaColumns(incidents[i])).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// slaColumns - the SLA columns of an incident for UpdateColumns
+func slaColumns(incident model.Incident) map[string]any {
+	return map[string]any{
+		"sla_policy_id":      incident.SLAPolicyID,
+		"acknowledge_due_at": incident.AcknowledgeDueAt,
+		"resolve_due_at":     incident.ResolveDueAt,
+		"sla_alerts":         incident.SLAAlerts,
+	}
+}
+
+// slaPolicyCalendar checks that the calendar of a policy exists
+func slaPolicyCalendar(db *gorm.DB, req model.SLAPolicyReq, errCode string) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	if req.CalendarID == nil {
+		ok = true
+		return
+	}
+
+	if err := db.First(&model.BusinessCalendar{}, *req.CalendarID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("business calendar not found", http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
+
+// slaPolicyConflict reports another policy for the same priority or severity
+func slaPolicyConflict(db *gorm.DB, req model.SLAPolicyReq, id uint64) (string, error) {
+	query := db.Model(&model.SLAPolicy{}).Where("policy_id <> ?", id)
+	if req.Priority != "" {
+		query = query.Where("priority = ?", req.Priority)
+	} else {
+		query = query.Where("severity = ?", req.Severity)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return "", err
+	}
+	if count == 0 {
+		return "", nil
+	}
+	if req.Priority != "" {
+		return "an SLA policy for priority " + string(req.Priority) + " already exists", nil
+	}
+	return "an SLA policy for severity " + string(req.Severity) + " already exists", nil
+}
+
+func validateSLAPolicyReq(req *model.SLAPolicyReq) string {
+	req.Name = strings.TrimSpace(req.Name)
+
+	if req.Name == "" {
+		return "name is required"
+	}
+	if (req.Priority == "") == (req.Severity == "") {
+		return "either priority or severity is required"
+	}
+	if req.Priority != "" && !req.Priority.Valid() {
+		return "priority must be P1, P2, P3, P4 or P5"
+	}
+	if req.Severity != "" && !req.Severity.Valid() {
+		return "severity must be low, medium, high or critical"
+	}
+	if req.AcknowledgeWithin < 0 || req.ResolveWithin < 0 {
+		return "targets must not be negative"
+	}
+	if req.AcknowledgeWithin == 0 && req.ResolveWithin == 0 {
+		return "at least one target is required"
+	}
+
+	return ""
+}
+
+func sameID(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
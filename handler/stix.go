@@ -0,0 +1,320 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/Dhar01/incident_resp/service"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ExportIncidentStix builds a STIX 2.1 bundle of an incident to share
+// with partners: the incident, its ATT&CK techniques as attack patterns,
+// its indicators of compromise with the observed data they are based on
+// and the relationships between them, all marked with a TLP level. The
+// objects keep their IDs from one export to the next.
+func ExportIncidentStix(id uint64, tlp string) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if tlp == "" {
+		tlp = model.TLPAmber
+	}
+	marking, ok := service.StixTLPMarking(tlp)
+	if !ok {
+		return setErrorMessage("tlp must be white, green, amber or red", http.StatusBadRequest)
+	}
+
+	var incident model.Incident
+
+	if err := db.First(&incident, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 5401.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	indicators := []model.IncidentIndicator{}
+	if err := db.Preload("Indicator").Where("incident_id = ?", id).Order("id").Find(&indicators).Error; err != nil {
+		log.WithError(err).Error("error code: 5401.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	techniques := []model.IncidentTechnique{}
+	if err := db.Where("incident_id = ?", id).Order("id").Find(&techniques).Error; err != nil {
+		log.WithError(err).Error("error code: 5401.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	// the objects are modified by the latest change of what they are
+	// built from, removals leave only their timeline entry behind
+	indicatorsChanged, err := lastIncidentEvent(db, id, model.EventIndicatorAdded, model.EventIndicatorRemoved, model.EventMerged)
+	if err != nil {
+		log.WithError(err).Error("error code: 5401.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	techniquesChanged, err := lastIncidentEvent(db, id, model.EventTechniqueTagged, model.EventTechniqueUntagged, model.EventMerged)
+	if err != nil {
+		log.WithError(err).Error("error code: 5401.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	for _, link := range indicators {
+		indicatorsChanged = later(indicatorsChanged, link.CreatedAt)
+	}
+	for _, tagged := range techniques {
+		techniquesChanged = later(techniquesChanged, tagged.CreatedAt)
+	}
+	modified := later(incident.UpdatedAt, later(indicatorsChanged, techniquesChanged))
+
+	markings := []string{marking.ID}
+	// key identifies the record an object is built from
+	common := func(objectType, key string, created, modified time.Time) model.StixCommon {
+		return model.StixCommon{
+			Type:              objectType,
+			SpecVersion:       service.StixSpecVersion,
+			ID:                service.StixID(objectType, key),
+			Created:           service.StixTime(created),
+			Modified:          service.StixTime(modified),
+			ObjectMarkingRefs: markings,
+		}
+	}
+	relationship := func(relationshipType, key, source, target string, created time.Time) model.StixRelationship {
+		return model.StixRelationship{
+			StixCommon:       common("relationship", relationshipType+":"+key, created, created),
+			RelationshipType: relationshipType,
+			SourceRef:        source,
+			TargetRef:        target,
+		}
+	}
+
+	stixIncident := model.StixIncident{
+		StixCommon:  common("incident", strconv.FormatUint(incident.IncidentID, 10), incident.CreatedAt, modified),
+		Name:        incident.Title,
+		Description: incident.Description,
+	}
+	objects := []any{marking}
+	relationships := []any{}
+
+	for _, tagged := range techniques {
+		key := "technique:" + strconv.FormatUint(tagged.ID, 10)
+		technique, _ := service.AttackTechnique(tagged.TechniqueID)
+		served := technique.Tactics
+		if tagged.TacticID != "" {
+			served = []string{tagged.TacticID}
+		}
+
+		phases := []model.StixKillChainPhase{}
+		for _, tacticID := range served {
+			tactic, _ := service.AttackTactic(tacticID)
+			phase := model.StixKillChainPhase{KillChainName: "mitre-attack", PhaseName: tactic.ShortName}
+			phases = append(phases, phase)
+			if !slices.Contains(stixIncident.KillChainPhases, phase) {
+				stixIncident.KillChainPhases = append(stixIncident.KillChainPhases, phase)
+			}
+		}
+
+		pattern := model.StixAttackPattern{
+			StixCommon: common("attack-pattern", key, tagged.CreatedAt, tagged.CreatedAt),
+			Name:       technique.Name,
+			ExternalReferences: []model.StixExternalReference{{
+				SourceName: "mitre-attack",
+				ExternalID: tagged.TechniqueID,
+				URL:        "https://attack.mitre.org/techniques/" + strings.ReplaceAll(tagged.TechniqueID, ".", "/") + "/",
+			}},
+			KillChainPhases: phases,
+		}
+		objects = append(objects, pattern)
+		relationships = append(relationships, relationship("related-to", key, stixIncident.ID, pattern.ID, tagged.CreatedAt))
+	}
+
+	if len(indicators) > 0 {
+		observed := model.StixObservedData{
+			FirstObserved:  service.StixTime(indicators[0].CreatedAt),
+			LastObserved:   service.StixTime(indicators[len(indicators)-1].CreatedAt),
+			NumberObserved: 1,
+			ObjectRefs:     []string{},
+		}
+		observed.StixCommon = common("observed-data", "incident:"+strconv.FormatUint(incident.IncidentID, 10), incident.CreatedAt, later(incident.CreatedAt, indicatorsChanged))
+
+		for _, link := range indicators {
+			key := "indicator:" + strconv.FormatUint(link.ID, 10)
+			observable := service.StixObservable(link.Indicator)
+			observable.ObjectMarkingRefs = markings
+			observed.ObjectRefs = append(observed.ObjectRefs, observable.ID)
+
+			indicator := model.StixIndicator{
+				StixCommon:     common("indicator", key, link.CreatedAt, link.CreatedAt),
+				Name:           link.Indicator.Value,
+				Description:    link.Note,
+				IndicatorTypes: []string{"malicious-activity"},
+				Pattern:        service.StixPattern(link.Indicator),
+				PatternType:    "stix",
+				ValidFrom:      service.StixTime(link.CreatedAt),
+			}
+			objects = append(objects, indicator, observable)
+			relationships = append(relationships,
+				relationship("based-on", key, indicator.ID, observed.ID, link.CreatedAt),
+				relationship("related-to", key, indicator.ID, stixIncident.ID, link.CreatedAt),
+			)
+		}
+		objects = append(objects, observed)
+	}
+
+	objects = append(objects, stixIncident)
+	objects = append(objects, relationships...)
+
+	httpResponse.Message = model.StixBundle{
+		Type:    "bundle",
+		ID:      service.StixBundleID(),
+		Objects: objects,
+	}
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// lastIncidentEvent returns when the latest timeline entry of the given
+// types was recorded, zero when there is none
+func lastIncidentEvent(db *gorm.DB, incidentID uint64, types ...model.EventType) (time.Time, error) {
+	events := []model.IncidentEvent{}
+
+	err := db.Where("incident_id = ? AND type IN ?", incidentID, types).Order("created_at DESC").Limit(1).Find(&events).Error
+	if err != nil || len(events) == 0 {
+		return time.Time{}, err
+	}
+	return events[0].CreatedAt, nil
+}
+
+// later returns the later of two times
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// ImportIncidentStix adds the indicators of compromise of a STIX 2.1
+// bundle to an incident. Every object must follow the rules of the
+// specification, nothing is imported otherwise. Indicators which are
+// revoked, use another pattern language or observe nothing this server
+// tracks are skipped.
+func ImportIncidentStix(id uint64, body io.Reader, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	incident, resp, code, ok := editableIncident(id, authID, "5402.1")
+	if !ok {
+		return resp, code
+	}
+
+	bundleID, objects, err := service.ParseStixBundle(body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return setErrorMessage("bundle is limited to "+strconv.Itoa(model.StixImportMaxBytes>>20)+" MiB", http.StatusRequestEntityTooLarge)
+		}
+		return setErrorMessage(err.Error(), http.StatusBadRequest)
+	}
+
+	result := model.StixImportResult{
+		Added:    []model.IncidentIndicator{},
+		Existing: []model.Indicator{},
+		Skipped:  []model.StixImportIssue{},
+		Errors:   []model.StixImportIssue{},
+	}
+	fail := func(id, msg string) {
+		result.Errors = append(result.Errors, model.StixImportIssue{ID: id, Message: msg})
+	}
+	skip := func(id, msg string) {
+		result.Skipped = append(result.Skipped, model.StixImportIssue{ID: id, Message: msg})
+	}
+
+	found := []model.Indicator{}
+	for i, raw := range objects {
+		object, msg := service.ValidateStixObject(raw)
+		if msg != "" {
+			if object.ID == "" {
+				object.ID = "objects[" + strconv.Itoa(i) + "]"
+			}
+			fail(object.ID, msg)
+			continue
+		}
+
+		switch object.Type {
+		case "relationship":
+			if msg := service.ValidateStixRelationship(raw); msg != "" {
+				fail(object.ID, msg)
+			}
+		case "indicator":
+			result.Total++
+
+			indicator, msg := service.ValidateStixIndicator(raw)
+			if msg != "" {
+				fail(object.ID, msg)
+				continue
+			}
+			if indicator.PatternType != "stix" {
+				skip(object.ID, "pattern type "+indicator.PatternType+" is not supported")
+				continue
+			}
+			observed, msg := service.ParseStixPattern(indicator.Pattern)
+			if msg != "" {
+				fail(object.ID, "pattern: "+msg)
+				continue
+			}
+			if object.Revoked {
+				skip(object.ID, "indicator is revoked")
+				continue
+			}
+			if len(observed) == 0 {
+				skip(object.ID, "pattern observes no IP, domain, URL, file hash or email address")
+				continue
+			}
+			for _, indicator := range observed {
+				if !slices.Contains(found, indicator) {
+					found = append(found, indicator)
+				}
+			}
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		httpResponse.Message = result
+		httpStatusCode = http.StatusUnprocessableEntity
+		return
+	}
+	if result.Total == 0 {
+		return setErrorMessage("bundle has no indicators", http.StatusBadRequest)
+	}
+	if len(found) > model.IndicatorBulkMax {
+		return setErrorMessage("bundle contains more than "+strconv.Itoa(model.IndicatorBulkMax)+" indicators", http.StatusBadRequest)
+	}
+
+	if len(found) > 0 {
+		tx := db.Begin()
+		result.Added, result.Existing, err = addIndicators(tx, incident.IncidentID, found, "imported from "+bundleID, authID)
+		if err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 5402.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		if err := tx.Commit().Error; err != nil {
+			log.WithError(err).Error("error code: 5402.3")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+
+	httpResponse.Message = result
+	httpStatusCode = http.StatusOK
+	if len(result.Added) > 0 {
+		httpStatusCode = http.StatusCreated
+	}
+	return
+}
@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetTagSuggestions autocompletes tag names, most used first
+func GetTagSuggestions(prefix string, limit int) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if limit == 0 {
+		limit = model.TagSuggestLimit
+	}
+	if limit < 0 || limit > model.TagSuggestMaxLimit {
+		return setErrorMessage("limit must be between 1 and "+strconv.Itoa(model.TagSuggestMaxLimit), http.StatusBadRequest)
+	}
+
+	query := db.Model(&model.Tag{}).
+		Select("tags.*, COUNT(incident_tags.incident_id) AS incidents").
+		Joins("LEFT JOIN incident_tags ON incident_tags.tag_id = tags.tag_id").
+		Group("tags.tag_id")
+
+	// SUBSTR instead of LIKE, '_' is a valid tag character
+	if prefix = strings.ToLower(strings.TrimSpace(prefix)); prefix != "" {
+		query = query.Where("SUBSTR(tags.name, 1, ?) = ?", len([]rune(prefix)), prefix)
+	}
+
+	suggestions := []model.TagSuggestion{}
+
+	if err := query.Order("incidents DESC, tags.name").Limit(limit).Scan(&suggestions).Error; err != nil {
+		log.WithError(err).Error("error code: 4201.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = suggestions
+	httpStatusCode = http.StatusOK
+	return
+}
+
+func GetIncidentTags(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Incident{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 4202.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	tags, err := incidentTags(db, id)
+	if err != nil {
+		log.WithError(err).Error("error code: 4202.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = tags
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// AddIncidentTags tags an incident, tags it already has are ignored
+func AddIncidentTags(id uint64, req model.TagReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	tags, msg := parseTags(req.Tags)
+	if msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	if _, resp, code, ok := editableIncident(id, authID, "4203.1"); !ok {
+		return resp, code
+	}
+
+	current, err := incidentTags(db, id)
+	if err != nil {
+		log.WithError(err).Error("error code: 4203.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	tx := db.Begin()
+	if err := replaceIncidentTags(tx, id, append(current, tags...), authID); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4203.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4203.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	return GetIncidentTags(id)
+}
+
+// SetIncidentTags replaces all tags of an incident
+func SetIncidentTags(id uint64, req model.TagReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	tags, msg := parseTags(req.Tags)
+	if msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	if _, resp, code, ok := editableIncident(id, authID, "4204.1"); !ok {
+		return resp, code
+	}
+
+	tx := db.Begin()
+	if err := replaceIncidentTags(tx, id, tags, authID); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4204.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4204.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	return GetIncidentTags(id)
+}
+
+// RemoveIncidentTag removes one tag from an incident
+func RemoveIncidentTag(id uint64, name string, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if _, resp, code, ok := editableIncident(id, authID, "4205.1"); !ok {
+		return resp, code
+	}
+
+	current, err := incidentTags(db, id)
+	if err != nil {
+		log.WithError(err).Error("error code: 4205.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	remaining := []model.Tag{}
+	for _, tag := range current {
+		if tag.Name != name {
+			remaining = append(remaining, tag)
+		}
+	}
+	if len(remaining) == len(current) {
+		return setErrorMessage("incident is not tagged "+name, http.StatusNotFound)
+	}
+
+	tx := db.Begin()
+	if err := replaceIncidentTags(tx, id, remaining, authID); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 4205.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 4205.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "tag removed"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// incidentTags loads the tags of an incident by name
+func incidentTags(tx *gorm.DB, id uint64) ([]model.Tag, error) {
+	tags := []model.Tag{}
+	err := tx.Model(&model.Incident{IncidentID: id}).Order("name").Association("Tags").Find(&tags)
+	return tags, err
+}
+
+// replaceIncidentTags sets the tags of an incident and records
+// the added and removed ones in the timeline
+func replaceIncidentTags(tx *gorm.DB, id uint64, tags []model.Tag, authID uint64) error {
+	current, err := incidentTags(tx, id)
+	if err != nil {
+		return err
+	}
+
+	tags = uniqueTags(tags)
+	if err := saveTags(tx, tags); err != nil {
+		return err
+	}
+
+	had := map[string]bool{}
+	for _, tag := range current {
+		had[tag.Name] = true
+	}
+	keep := map[string]bool{}
+	added := []string{}
+	for _, tag := range tags {
+		keep[tag.Name] = true
+		if !had[tag.Name] {
+			added = append(added, tag.Name)
+		}
+	}
+	removed := []string{}
+	for _, tag := range current {
+		if !keep[tag.Name] {
+			removed = append(removed, tag.Name)
+		}
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	if err := tx.Model(&model.Incident{IncidentID: id}).Association("Tags").Replace(tags); err != nil {
+		return err
+	}
+
+	if len(added) > 0 {
+		if err := recordEvent(tx, id, authID, model.EventTagged, "tagged "+strings.Join(added, ", ")); err != nil {
+			return err
+		}
+	}
+	if len(removed) > 0 {
+		if err := recordEvent(tx, id, authID, model.EventUntagged, "untagged "+strings.Join(removed, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveTags fills in the IDs, creating the tags which do not exist yet
+func saveTags(tx *gorm.DB, tags []model.Tag) error {
+	for i := range tags {
+		if tags[i].TagID != 0 {
+			continue
+		}
+		if err := tx.Where(model.Tag{Name: tags[i].Name}).FirstOrCreate(&tags[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTags normalizes tag names, duplicates are dropped
+func parseTags(names []string) ([]model.Tag, string) {
+	tags := []model.Tag{}
+	for _, name := range names {
+		tag, msg := parseTag(name)
+		if msg != "" {
+			return nil, msg
+		}
+		tags = append(tags, tag)
+	}
+	return uniqueTags(tags), ""
+}
+
+// parseTag normalizes a tag name, key:value makes a label
+func parseTag(name string) (tag model.Tag, msg string) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return tag, "tag must not be empty"
+	}
+
+	key, value, isLabel := strings.Cut(name, ":")
+	if !isLabel {
+		if !validTagPart(name) {
+			return tag, "invalid tag '" + name + "': up to " + strconv.Itoa(model.TagMaxLength) + " letters, digits or - _ . /"
+		}
+		tag.Name = name
+		return
+	}
+
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if !validTagPart(key) || !validTagPart(value) {
+		return tag, "invalid label '" + name + "': key:value of up to " + strconv.Itoa(model.TagMaxLength) + " letters, digits or - _ . / each"
+	}
+
+	tag.Name = key + ":" + value
+	tag.Key = key
+	tag.Value = value
+	return
+}
+
+func validTagPart(s string) bool {
+	if s == "" || len([]rune(s)) > model.TagMaxLength {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_./", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func uniqueTags(tags []model.Tag) []model.Tag {
+	seen := map[string]bool{}
+	unique := []model.Tag{}
+	for _, tag := range tags {
+		if !seen[tag.Name] {
+			seen[tag.Name] = true
+			unique = append(unique, tag)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetIncidentTasks lists the follow-up tasks of an incident
+func GetIncidentTasks(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Incident{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3501.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	tasks := []model.Task{}
+
+	if err := db.Where("incident_id = ?", id).Order("created_at").Find(&tasks).Error; err != nil {
+		log.WithError(err).Error("error code: 3501.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = markOverdue(tasks)
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// CreateTask attaches a new follow-up task to an incident
+func CreateTask(id uint64, req model.TaskReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if msg := validateTaskReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	incident, resp, code, ok := editableIncident(id, authID, "3502.1")
+	if !ok {
+		return resp, code
+	}
+
+	if resp, code, ok := requireTaskAssignee(req.AssignedTo, "3502.2"); !ok {
+		return resp, code
+	}
+
+	task := model.Task{
+		IncidentID:  incident.IncidentID,
+		Title:       req.Title,
+		Description: req.Description,
+		AssignedTo:  req.AssignedTo,
+		DueAt:       req.DueAt,
+		Priority:    req.Priority,
+		Status:      req.Status,
+		CreatedBy:   authID,
+	}
+	if task.Status == model.TaskStatusDone {
+		now := time.Now()
+		task.CompletedAt = &now
+		task.CompletedBy = authID
+	}
+
+	tx := db.Begin()
+	if err := tx.Create(&task).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3502.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := recordEvent(tx, incident.IncidentID, authID, model.EventTaskCreated, "task created: "+task.Title); err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3502.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3502.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	task.Overdue = task.IsOverdue(time.Now())
+
+	httpResponse.Message = task
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// UpdateTask replaces the attributes of a task and tracks its completion
+func UpdateTask(id, taskID uint64, req model.TaskReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if msg := validateTaskReq(&req); msg != "" {
+		return setErrorMessage(msg, http.StatusBadRequest)
+	}
+
+	incident, resp, code, ok := editableIncident(id, authID, "3503.1")
+	if !ok {
+		return resp, code
+	}
+
+	var task model.Task
+
+	if err := db.Where("incident_id = ?", incident.IncidentID).First(&task, taskID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3503.2")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("task not found", http.StatusNotFound)
+	}
+
+	if resp, code, ok := requireTaskAssignee(req.AssignedTo, "3503.3"); !ok {
+		return resp, code
+	}
+
+	completed := task.Status != model.TaskStatusDone && req.Status == model.TaskStatusDone
+
+	// a new assignee or due date deserves a fresh reminder
+	if task.AssignedTo != req.AssignedTo || !sameTime(task.DueAt, req.DueAt) {
+		task.RemindedAt = nil
+	}
+
+	task.Title = req.Title
+	task.Description = req.Description
+	task.AssignedTo = req.AssignedTo
+	task.DueAt = req.DueAt
+	task.Priority = req.Priority
+	task.Status = req.Status
+
+	switch {
+	case completed:
+		now := time.Now()
+		task.CompletedAt = &now
+		task.CompletedBy = authID
+	case req.Status != model.TaskStatusDone:
+		// reopened
+		task.CompletedAt = nil
+		task.CompletedBy = 0
+	}
+
+	tx := db.Begin()
+	if err := tx.Save(&task).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3503.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if completed {
+		if err := recordEvent(tx, incident.IncidentID, authID, model.EventTaskCompleted, "task completed: "+task.Title); err != nil {
+			tx.Rollback()
+			log.WithError(err).Error("error code: 3503.5")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3503.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	task.Overdue = task.IsOverdue(time.Now())
+
+	httpResponse.Message = task
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DeleteTask removes a task from an incident
+func DeleteTask(id, taskID, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	incident, resp, code, ok := editableIncident(id, authID, "3504.1")
+	if !ok {
+		return resp, code
+	}
+
+	result := db.Where("incident_id = ?", incident.IncidentID).Delete(&model.Task{}, taskID)
+	if result.Error != nil {
+		log.WithError(result.Error).Error("error code: 3504.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if result.RowsAffected == 0 {
+		return setErrorMessage("task not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = "task deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetUserTasks lists the tasks assigned to the user across all
+// incidents, the most urgent due date first
+func GetUserTasks(authID uint64, filter model.TaskFilter) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if filter.Status != "" && !filter.Status.Valid() {
+		return setErrorMessage("status must be open, in_progress or done", http.StatusBadRequest)
+	}
+
+	query := db.Where("assigned_to = ?", authID)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if filter.Overdue {
+		query = query.Where("status <> ? AND due_at < ?", model.TaskStatusDone, time.Now())
+	}
+
+	tasks := []model.Task{}
+
+	// tasks without a due date go last
+	err := query.Order("CASE WHEN due_at IS NULL THEN 1 ELSE 0 END, due_at, created_at").Find(&tasks).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 3505.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = markOverdue(tasks)
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// validateTaskReq normalizes the payload and returns
+// an error message when it is not acceptable
+func validateTaskReq(req *model.TaskReq) string {
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		return "task title is required"
+	}
+
+	if req.Priority == "" {
+		req.Priority = model.TaskPriorityMedium
+	}
+	if !req.Priority.Valid() {
+		return "priority must be low, medium or high"
+	}
+
+	if req.Status == "" {
+		req.Status = model.TaskStatusOpen
+	}
+	if !req.Status.Valid() {
+		return "status must be open, in_progress or done"
+	}
+
+	return ""
+}
+
+// requireTaskAssignee prepares a 404 response when the assignee
+// does not exist; tasks may be left unassigned
+func requireTaskAssignee(assignee uint64, errCode string) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	if assignee == 0 {
+		ok = true
+		return
+	}
+
+	if err := database.GetDB().First(&model.Auth{}, assignee).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: " + errCode)
+			httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+			return
+		}
+		httpResponse, httpStatusCode = setErrorMessage("assigned user not found", http.StatusNotFound)
+		return
+	}
+
+	ok = true
+	return
+}
+
+// markOverdue flags the overdue tasks in a list
+func markOverdue(tasks []model.Task) []model.Task {
+	now := time.Now()
+	for i := range tasks {
+		tasks[i].Overdue = tasks[i].IsOverdue(now)
+	}
+	return tasks
+}
+
+// sameTime compares two optional timestamps
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,381 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateTeam creates a new team, the creator becomes its lead
+func CreateTeam(req model.TeamReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return setErrorMessage("team name is required", http.StatusBadRequest)
+	}
+
+	// team name must be unique
+	err := db.Where("name = ?", req.Name).First(&model.Team{}).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3201.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err == nil {
+		return setErrorMessage("team name already exists", http.StatusConflict)
+	}
+
+	team := model.Team{
+		Name:        req.Name,
+		Description: req.Description,
+		Members: []model.TeamMember{
+			{AuthID: authID, Role: model.TeamRoleLead},
+		},
+	}
+
+	if err := db.Create(&team).Error; err != nil {
+		log.WithError(err).Error("error code: 3201.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = team
+	httpStatusCode = http.StatusCreated
+	return
+}
+
+// GetTeams lists all teams
+func GetTeams() (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var teams []model.Team
+
+	if err := db.Order("name").Find(&teams).Error; err != nil {
+		log.WithError(err).Error("error code: 3202.1")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = teams
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetTeamByID fetches one team with its members
+func GetTeamByID(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var team model.Team
+
+	if err := db.Preload("Members").First(&team, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3203.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("team not found", http.StatusNotFound)
+	}
+
+	httpResponse.Message = team
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// UpdateTeam changes name and description of a team
+func UpdateTeam(id uint64, req model.TeamReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return setErrorMessage("team name is required", http.StatusBadRequest)
+	}
+
+	var team model.Team
+
+	if err := db.First(&team, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3204.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("team not found", http.StatusNotFound)
+	}
+
+	if resp, code, ok := requireTeamPermission(authID, id, model.PermTeamManage, "3204.2"); !ok {
+		return resp, code
+	}
+
+	// team name must be unique
+	err := db.Where("name = ? AND team_id <> ?", req.Name, id).First(&model.Team{}).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3204.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err == nil {
+		return setErrorMessage("team name already exists", http.StatusConflict)
+	}
+
+	team.Name = req.Name
+	team.Description = req.Description
+
+	if err := db.Save(&team).Error; err != nil {
+		log.WithError(err).Error("error code: 3204.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = team
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// DeleteTeam removes a team. Incidents owned by the team keep
+// their individual assignee but lose the team ownership.
+func DeleteTeam(id uint64, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Team{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3205.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("team not found", http.StatusNotFound)
+	}
+
+	if resp, code, ok := requireTeamPermission(authID, id, model.PermTeamManage, "3205.2"); !ok {
+		return resp, code
+	}
+
+	tx := db.Begin()
+	if err := tx.Model(&model.Incident{}).Where("team_id = ?", id).Update("team_id", nil).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3205.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	if err := tx.Where("team_id = ?", id).Delete(&model.TeamMember{}).Error; err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3205.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	// the deletion marker frees the name for new teams
+	err := tx.Model(&model.Team{TeamID: id}).UpdateColumns(map[string]any{
+		"deleted_at": time.Now(),
+		"deleted_id": id,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		log.WithError(err).Error("error code: 3205.5")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.WithError(err).Error("error code: 3205.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "team deleted"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// SetTeamMember adds a user to a team or changes the role of a member
+func SetTeamMember(id uint64, req model.TeamMemberReq, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if req.Role == "" {
+		req.Role = model.TeamRoleResponder
+	}
+	if !req.Role.Valid() {
+		return setErrorMessage("role must be lead, responder or viewer", http.StatusBadRequest)
+	}
+
+	if err := db.First(&model.Team{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3206.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("team not found", http.StatusNotFound)
+	}
+
+	if resp, code, ok := requireTeamPermission(authID, id, model.PermTeamManage, "3206.2"); !ok {
+		return resp, code
+	}
+
+	// check if user exists
+	if err := db.First(&model.Auth{}, req.AuthID).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3206.3")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("user not found", http.StatusNotFound)
+	}
+
+	var member model.TeamMember
+
+	err := db.Where("team_id = ? AND auth_id = ?", id, req.AuthID).First(&member).Error
+	if err != nil && err.Error() != database.RecordNotFound {
+		log.WithError(err).Error("error code: 3206.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	// a team must keep at least one lead
+	if err == nil && member.Role == model.TeamRoleLead && req.Role != model.TeamRoleLead {
+		if resp, code, ok := requireOtherLead(id, req.AuthID, "3206.5"); !ok {
+			return resp, code
+		}
+	}
+
+	member.TeamID = id
+	member.AuthID = req.AuthID
+	member.Role = req.Role
+
+	if err := db.Save(&member).Error; err != nil {
+		log.WithError(err).Error("error code: 3206.6")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = member
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// RemoveTeamMember removes a user from a team
+func RemoveTeamMember(id, memberAuthID, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	var member model.TeamMember
+
+	if err := db.Where("team_id = ? AND auth_id = ?", id, memberAuthID).First(&member).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3207.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("team member not found", http.StatusNotFound)
+	}
+
+	// members may always leave, otherwise team:manage is required
+	if memberAuthID != authID {
+		if resp, code, ok := requireTeamPermission(authID, id, model.PermTeamManage, "3207.2"); !ok {
+			return resp, code
+		}
+	}
+
+	// a team must keep at least one lead
+	if member.Role == model.TeamRoleLead {
+		if resp, code, ok := requireOtherLead(id, memberAuthID, "3207.3"); !ok {
+			return resp, code
+		}
+	}
+
+	if err := db.Delete(&member).Error; err != nil {
+		log.WithError(err).Error("error code: 3207.4")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = "team member removed"
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// GetTeamIncidents returns the queue of a team: its incidents ordered by
+// severity and age. Closed incidents are left out unless requested.
+// Members need the incident:view permission.
+func GetTeamIncidents(id uint64, status model.StatusType, authID uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Team{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3208.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("team not found", http.StatusNotFound)
+	}
+
+	if resp, code, ok := requireTeamPermission(authID, id, model.PermIncidentView, "3208.2"); !ok {
+		return resp, code
+	}
+
+	var incidents []model.Incident
+
+	query := db.Preload("Services").Where("team_id = ?", id)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	} else {
+		query = query.Where("status <> ?", model.Closed)
+	}
+
+	err := query.
+		Order("CASE severity WHEN 'critical' THEN 0 WHEN 'high' THEN 1 WHEN 'medium' THEN 2 ELSE 3 END").
+		Order("created_at").
+		Find(&incidents).Error
+	if err != nil {
+		log.WithError(err).Error("error code: 3208.3")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = incidents
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// hasTeamPermission checks whether the user's role in the team
+// grants the permission
+func hasTeamPermission(authID, teamID uint64, permission model.TeamPermission) (bool, error) {
+	var member model.TeamMember
+
+	err := database.GetDB().Where("team_id = ? AND auth_id = ?", teamID, authID).First(&member).Error
+	if err != nil {
+		if err.Error() == database.RecordNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return member.Role.Can(permission), nil
+}
+
+// requireTeamPermission prepares a 403 response when the user lacks the
+// permission in the team; ok is true when the user may proceed
+func requireTeamPermission(authID, teamID uint64, permission model.TeamPermission, errCode string) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	allowed, err := hasTeamPermission(authID, teamID, permission)
+	if err != nil {
+		log.WithError(err).Error("error code: " + errCode)
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
+	}
+
+	if !allowed {
+		httpResponse, httpStatusCode = setErrorMessage(
+			"permission '"+string(permission)+"' required in this team",
+			http.StatusForbidden,
+		)
+		return
+	}
+
+	ok = true
+	return
+}
+
+// requireOtherLead prepares a 409 response when the given member is
+// the last lead of the team
+func requireOtherLead(teamID, authID uint64, errCode string) (httpResponse model.HTTPResponse, httpStatusCode int, ok bool) {
+	var leads int64
+
+	err := database.GetDB().Model(&model.TeamMember{}).
+		Where("team_id = ? AND role = ? AND auth_id <> ?", teamID, model.TeamRoleLead, authID).
+		Count(&leads).Error
+	if err != nil {
+		log.WithError(err).Error("error code: " + errCode)
+		httpResponse, httpStatusCode = setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		return
+	}
+
+	if leads == 0 {
+		httpResponse, httpStatusCode = setErrorMessage("a team needs at least one lead", http.StatusConflict)
+		return
+	}
+
+	ok = true
+	return
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetIncidentTimeline returns the timeline of an incident, oldest first
+func GetIncidentTimeline(id uint64) (httpResponse model.HTTPResponse, httpStatusCode int) {
+	db := database.GetDB()
+
+	if err := db.First(&model.Incident{}, id).Error; err != nil {
+		if err.Error() != database.RecordNotFound {
+			log.WithError(err).Error("error code: 3401.1")
+			return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+		}
+		return setErrorMessage("incident not found", http.StatusNotFound)
+	}
+
+	events := []model.IncidentEvent{}
+
+	if err := db.Where("incident_id = ?", id).Order("created_at, id").Find(&events).Error; err != nil {
+		log.WithError(err).Error("error code: 3401.2")
+		return setErrorMessage(errInternalServer, http.StatusInternalServerError)
+	}
+
+	httpResponse.Message = events
+	httpStatusCode = http.StatusOK
+	return
+}
+
+// recordEvent appends an entry to the timeline of an incident.
+// Pass the open transaction so the entry is kept in step with the change.
+func recordEvent(tx *gorm.DB, incidentID, authID uint64, eventType model.EventType, message string) error {
+	return tx.Create(&model.IncidentEvent{
+		IncidentID: incidentID,
+		AuthID:     authID,
+		Type:       eventType,
+		Message:    message,
+	}).Error
+}
@@ -11,6 +11,38 @@ import (
 type auth model.Auth
 type user model.User
 type incident model.Incident
+type schedule model.Schedule
+type scheduleMember model.ScheduleMember
+type calendarFeed model.CalendarFeed
+type service model.Service
+type team model.Team
+type teamMember model.TeamMember
+type incidentParticipant model.IncidentParticipant
+type incidentEvent model.IncidentEvent
+type task model.Task
+type postmortem model.Postmortem
+type postmortemSection model.PostmortemSection
+type postmortemReview model.PostmortemReview
+type postmortemTemplate model.PostmortemTemplate
+type incidentLink model.IncidentLink
+type tag model.Tag
+type customField model.CustomField
+type slaPolicy model.SLAPolicy
+type businessCalendar model.BusinessCalendar
+type holiday model.Holiday
+type maintenanceWindow model.MaintenanceWindow
+type alert model.Alert
+type heartbeat model.Heartbeat
+type check model.Check
+type checkResult model.CheckResult
+type automationRule model.AutomationRule
+type automationRun model.AutomationRun
+type playbook model.Playbook
+type incidentPlaybook model.IncidentPlaybook
+type incidentPlaybookStep model.IncidentPlaybookStep
+type indicator model.Indicator
+type incidentIndicator model.IncidentIndicator
+type incidentTechnique model.IncidentTechnique
 
 func StartMigration(configure config.Configuration) error {
 	db := database.GetDB()
@@ -21,6 +53,38 @@ func StartMigration(configure config.Configuration) error {
 			&auth{},
 			&user{},
 			&incident{},
+			&schedule{},
+			&scheduleMember{},
+			&calendarFeed{},
+			&service{},
+			&team{},
+			&teamMember{},
+			&incidentParticipant{},
+			&incidentEvent{},
+			&task{},
+			&postmortem{},
+			&postmortemSection{},
+			&postmortemReview{},
+			&postmortemTemplate{},
+			&incidentLink{},
+			&tag{},
+			&customField{},
+			&slaPolicy{},
+			&businessCalendar{},
+			&holiday{},
+			&maintenanceWindow{},
+			&alert{},
+			&heartbeat{},
+			&check{},
+			&checkResult{},
+			&automationRule{},
+			&automationRun{},
+			&playbook{},
+			&incidentPlaybook{},
+			&incidentPlaybookStep{},
+			&indicator{},
+			&incidentIndicator{},
+			&incidentTechnique{},
 		); err != nil {
 			return err
 		}
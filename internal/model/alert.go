@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+// Alert model - 'alerts' table
+//
+// Monitoring alert received on the ingestion path, kept with what
+// became of it so suppressed alerts can be reviewed later
+type Alert struct {
+	AlertID   uint64    `gorm:"primaryKey" json:"alertID"`
+	CreatedAt time.Time `gorm:"index" json:"createdAt"`
+	AuthID    uint64    `gorm:"not null" json:"authID"` // integration which sent it
+
+	Source      string       `gorm:"type:varchar(64)" json:"source,omitempty"`
+	Title       string       `gorm:"not null" json:"title"`
+	Description string       `gorm:"type:text" json:"description,omitempty"`
+	Severity    SeverityType `gorm:"type:varchar(16)" json:"severity"` // as received
+	ServiceIDs  []uint64     `gorm:"type:text;serializer:json" json:"serviceIDs"`
+
+	Outcome    AlertOutcome `gorm:"type:varchar(16);index;not null" json:"outcome"`
+	IncidentID *uint64      `gorm:"index" json:"incidentID,omitempty"`
+	WindowID   *uint64      `gorm:"index" json:"windowID,omitempty"` // maintenance window which applied
+
+	SuppressedUntil *time.Time `json:"suppressedUntil,omitempty"` // when the maintenance of a suppressed alert ends
+}
+
+// AlertReq - payload of an incoming alert
+type AlertReq struct {
+	Source      string       `json:"source"`
+	Title       string       `json:"title" validate:"required"`
+	Description string       `json:"description"`
+	Severity    SeverityType `json:"severity"`
+	ServiceIDs  []uint64     `json:"serviceIDs"`
+	AssignedTo  uint64       `json:"assignedTo"` // the sender when 0
+}
+
+// AlertFilter - optional filters for listing alerts
+type AlertFilter struct {
+	Outcome  AlertOutcome
+	WindowID uint64
+	Before   uint64 // only alerts with a lower ID, the last ID of the previous page
+	Limit    int
+}
+
+// AlertOutcome - what an alert led to
+type AlertOutcome string
+
+// Alert outcomes
+const (
+	AlertIncidentCreated AlertOutcome = "created"
+	AlertDowngraded      AlertOutcome = "downgraded"
+	AlertSuppressed      AlertOutcome = "suppressed"
+)
+
+// Valid reports whether o is a known alert outcome
+func (o AlertOutcome) Valid() bool {
+	return o == AlertIncidentCreated || o == AlertDowngraded || o == AlertSuppressed
+}
+
+// Alert list page sizes
+const (
+	AlertsPageSize = 100
+	AlertsPageMax  = 1000
+)
@@ -0,0 +1,77 @@
+package model
+
+import "time"
+
+// AttackMatrix - MITRE ATT&CK matrix bundled with the server
+type AttackMatrix struct {
+	Domain     string            `json:"domain"`
+	Version    string            `json:"version"`
+	Tactics    []AttackTactic    `json:"tactics"` // in matrix order
+	Techniques []AttackTechnique `json:"techniques"`
+}
+
+// AttackTactic - goal of an adversary in the ATT&CK matrix
+type AttackTactic struct {
+	ID        string `json:"id"` // TA0001
+	Name      string `json:"name"`
+	ShortName string `json:"shortName"` // initial-access
+}
+
+// AttackTechnique - technique or sub-technique of the ATT&CK matrix
+type AttackTechnique struct {
+	ID      string   `json:"id"` // T1566, T1566.001 for a sub-technique
+	Name    string   `json:"name"`
+	Tactics []string `json:"tactics"` // tactic IDs
+}
+
+// IncidentTechnique model - 'incident_techniques' table
+//
+// ATT&CK technique observed in an incident
+type IncidentTechnique struct {
+	ID          uint64    `gorm:"primaryKey" json:"-"`
+	CreatedAt   time.Time `json:"createdAt,omitempty"`
+	IncidentID  uint64    `gorm:"uniqueIndex:idx_incident_technique;not null" json:"incidentID"`
+	TechniqueID string    `gorm:"type:varchar(16);uniqueIndex:idx_incident_technique;index;not null" json:"techniqueID"`
+	TacticID    string    `gorm:"type:varchar(8)" json:"tacticID,omitempty"` // tactic it served, empty when not known
+	AuthID      uint64    `json:"authID"`                                    // who tagged it
+	Note        string    `gorm:"type:text" json:"note,omitempty"`
+
+	// from the matrix
+	Name    string   `gorm:"-" json:"name"`
+	Tactics []string `gorm:"-" json:"tactics"`
+}
+
+// IncidentTechniqueReq - payload to tag an incident with a technique
+type IncidentTechniqueReq struct {
+	TechniqueID string `json:"techniqueID" validate:"required"`
+	TacticID    string `json:"tacticID"` // ID or short name, implied when the technique has one tactic
+	Note        string `json:"note"`
+}
+
+// TechniqueReport - how often techniques were seen in the incidents
+// created in a date range
+type TechniqueReport struct {
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Total int64     `json:"total"` // incidents with at least one technique
+
+	Techniques []TechniqueCount `json:"techniques"` // most frequent first
+	Tactics    []TacticCount    `json:"tactics"`    // in matrix order
+}
+
+// TechniqueCount - number of incidents a technique was seen in
+type TechniqueCount struct {
+	TechniqueID string    `json:"techniqueID"`
+	Name        string    `json:"name"`
+	Tactics     []string  `json:"tactics"`
+	Count       int64     `json:"count"`
+	LastSeenAt  time.Time `json:"lastSeenAt"` // latest incident
+}
+
+// TacticCount - number of incidents a tactic was seen in. Techniques
+// tagged without a tactic count for each of their tactics.
+type TacticCount struct {
+	TacticID string `json:"tacticID"`
+	Name     string `json:"name"`
+	Count    int64  `json:"count"`
+}
@@ -0,0 +1,171 @@
+package model
+
+import "time"
+
+// AutomationRule model - 'automation_rules' table
+//
+// Applies its actions to an incident which is created or updated and
+// matches its conditions. Rules run by position, each one sees the
+// changes of the rules before it. Changes made by rules do not run
+// the rules again.
+type AutomationRule struct {
+	RuleID    uint64    `gorm:"primaryKey" json:"ruleID"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+
+	Name        string `gorm:"type:varchar(255);not null" json:"name"`
+	Description string `gorm:"type:text" json:"description"`
+	AuthID      uint64 `gorm:"not null" json:"authID"` // creator
+	Disabled    bool   `gorm:"not null;default:false" json:"disabled"`
+	Position    int    `gorm:"not null;index" json:"position"` // lower runs first
+
+	Events     []AutomationEvent     `gorm:"type:text;serializer:json" json:"events"`
+	MatchAny   bool                  `gorm:"not null;default:false" json:"matchAny"` // any condition instead of all
+	Conditions []AutomationCondition `gorm:"type:text;serializer:json" json:"conditions"`
+	Actions    []AutomationAction    `gorm:"type:text;serializer:json" json:"actions"`
+}
+
+// AutomationRuleReq - payload to create or update an automation rule
+type AutomationRuleReq struct {
+	Name        string                `json:"name" validate:"required"`
+	Description string                `json:"description"`
+	Disabled    bool                  `json:"disabled"`
+	Position    int                   `json:"position"`
+	Events      []AutomationEvent     `json:"events"`
+	MatchAny    bool                  `json:"matchAny"`
+	Conditions  []AutomationCondition `json:"conditions"`
+	Actions     []AutomationAction    `json:"actions"`
+}
+
+// AutomationCondition - test of one incident field
+//
+// Field is title, description, status, severity, priority, impact,
+// urgency, assignedTo (auth ID), team (team ID), service (name), tag
+// (full name) or custom.<key>. Text is compared ignoring case. A
+// service or tag condition matches when any of them does, except ne
+// which matches when none is equal.
+type AutomationCondition struct {
+	Field    string             `json:"field"`
+	Operator AutomationOperator `json:"operator"`
+	Value    string             `json:"value"` // comma separated for in
+}
+
+// AutomationOperator - comparison of a condition
+type AutomationOperator string
+
+// Automation operators
+const (
+	AutomationEq       AutomationOperator = "eq"
+	AutomationNe       AutomationOperator = "ne"
+	AutomationContains AutomationOperator = "contains"
+	AutomationIn       AutomationOperator = "in"
+)
+
+// Valid reports whether o is a known operator
+func (o AutomationOperator) Valid() bool {
+	return o == AutomationEq || o == AutomationNe || o == AutomationContains || o == AutomationIn
+}
+
+// AutomationAction - change or notification of a rule
+type AutomationAction struct {
+	Type AutomationActionType `json:"type"`
+
+	// action arguments
+	AuthID     uint64       `json:"authID,omitempty"`     // assign, notify
+	ScheduleID uint64       `json:"scheduleID,omitempty"` // assign, notify: whoever is on call
+	Severity   SeverityType `json:"severity,omitempty"`   // set_severity
+	Tag        string       `json:"tag,omitempty"`        // add_tag
+	URL        string       `json:"url,omitempty"`        // webhook
+}
+
+// AutomationActionType - kind of an automation action
+type AutomationActionType string
+
+// Automation actions
+const (
+	AutomationAssign      AutomationActionType = "assign"
+	AutomationSetSeverity AutomationActionType = "set_severity"
+	AutomationAddTag      AutomationActionType = "add_tag"
+	AutomationNotify      AutomationActionType = "notify"  // email, the assignee unless a user or schedule is set
+	AutomationWebhook     AutomationActionType = "webhook" // POST of the incident as JSON
+)
+
+// AutomationEvent - incident change which runs the rules
+type AutomationEvent string
+
+// Automation events
+const (
+	AutomationCreated AutomationEvent = "created"
+	AutomationUpdated AutomationEvent = "updated"
+)
+
+// Valid reports whether e is a known event
+func (e AutomationEvent) Valid() bool {
+	return e == AutomationCreated || e == AutomationUpdated
+}
+
+// AutomationRun model - 'automation_runs' table
+//
+// Audit trail entry of a rule which fired on an incident
+type AutomationRun struct {
+	RunID      uint64          `gorm:"primaryKey" json:"runID"`
+	CreatedAt  time.Time       `gorm:"index" json:"createdAt"`
+	RuleID     uint64          `gorm:"index;not null" json:"ruleID"`
+	RuleName   string          `gorm:"type:varchar(255);not null" json:"ruleName"` // kept once the rule is deleted
+	IncidentID uint64          `gorm:"index;not null" json:"incidentID"`
+	Event      AutomationEvent `gorm:"type:varchar(16);not null" json:"event"`
+	AuthID     uint64          `json:"authID,omitempty"` // whose change ran the rule
+
+	Actions []AutomationActionResult `gorm:"type:text;serializer:json" json:"actions"`
+}
+
+// AutomationActionResult - outcome of one action
+type AutomationActionResult struct {
+	Type    AutomationActionType `json:"type"`
+	Message string               `json:"message"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// AutomationRunFilter - optional filters for the audit trail
+type AutomationRunFilter struct {
+	RuleID     uint64
+	IncidentID uint64
+}
+
+// AutomationDryRunReq - rules to try on an incident without applying
+// them, the enabled rules unless a draft rule is given
+type AutomationDryRunReq struct {
+	IncidentID uint64             `json:"incidentID" validate:"required"`
+	Event      AutomationEvent    `json:"event"` // updated when empty
+	Rule       *AutomationRuleReq `json:"rule"`
+}
+
+// AutomationDryRun - what the rules would do to an incident
+type AutomationDryRun struct {
+	IncidentID uint64                 `json:"incidentID"`
+	Event      AutomationEvent        `json:"event"`
+	Rules      []AutomationRuleResult `json:"rules"`
+}
+
+// AutomationRuleResult - whether a rule matched and its actions
+type AutomationRuleResult struct {
+	RuleID  uint64                   `json:"ruleID,omitempty"`
+	Name    string                   `json:"name"`
+	Matched bool                     `json:"matched"`
+	Actions []AutomationActionResult `json:"actions,omitempty"`
+}
+
+// Automation limits
+const (
+	AutomationConditionsMax = 20
+	AutomationActionsMax    = 10
+	AutomationRunsLimit     = 100
+)
+
+// AutomationWebhookPayload - body POSTed by a webhook action
+type AutomationWebhookPayload struct {
+	Event    AutomationEvent `json:"event"`
+	RuleID   uint64          `json:"ruleID"`
+	RuleName string          `json:"ruleName"`
+	Incident Incident        `json:"incident"`
+}
@@ -0,0 +1,66 @@
+package model
+
+// BulkAction - change applied by a bulk operation
+type BulkAction string
+
+// Bulk actions
+const (
+	BulkSetStatus   BulkAction = "set_status"
+	BulkSetSeverity BulkAction = "set_severity"
+	BulkAssign      BulkAction = "assign"
+	BulkAddTag      BulkAction = "add_tag"
+	BulkMerge       BulkAction = "merge"
+)
+
+// BulkReq - bulk operation on the listed incidents
+// or on every incident matching the filter
+type BulkReq struct {
+	IncidentIDs []uint64    `json:"incidentIDs"`
+	Filter      *BulkFilter `json:"filter"`
+	Action      BulkAction  `json:"action"`
+
+	// action arguments
+	Status     StatusType   `json:"status"`
+	Severity   SeverityType `json:"severity"`
+	AssignedTo uint64       `json:"assignedTo"`
+	Tag        string       `json:"tag"`
+	TargetID   uint64       `json:"targetID"` // incident to merge into
+}
+
+// BulkFilter - selects incidents like the list filters
+type BulkFilter struct {
+	ServiceID uint64     `json:"service"`
+	TeamID    uint64     `json:"team"`
+	Status    StatusType `json:"status"`
+}
+
+// BulkResult - outcome of a bulk operation
+type BulkResult struct {
+	Action    BulkAction       `json:"action"`
+	Applied   bool             `json:"applied"`
+	Total     int              `json:"total"`
+	Changed   int              `json:"changed"`
+	Unchanged int              `json:"unchanged"`
+	Failed    int              `json:"failed"`
+	Items     []BulkItemResult `json:"items"`
+}
+
+// BulkItemResult - outcome for one incident
+type BulkItemResult struct {
+	IncidentID uint64         `json:"incidentID"`
+	Result     BulkItemStatus `json:"result"`
+	Message    string         `json:"message,omitempty"`
+}
+
+// BulkItemStatus - outcome kind for one incident
+type BulkItemStatus string
+
+// Bulk item outcomes
+const (
+	BulkItemChanged   BulkItemStatus = "changed"
+	BulkItemUnchanged BulkItemStatus = "unchanged"
+	BulkItemFailed    BulkItemStatus = "failed"
+)
+
+// BulkMaxIncidents - upper limit of incidents changed at once
+const BulkMaxIncidents = 1000
@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// BusinessCalendar model - 'business_calendars' table
+//
+// Working hours and holidays clocks like SLA targets run on
+type BusinessCalendar struct {
+	CalendarID uint64    `gorm:"primaryKey" json:"calendarID"`
+	CreatedAt  time.Time `json:"createdAt,omitempty"`
+	UpdatedAt  time.Time `json:"updatedAt,omitempty"`
+
+	Name     string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"name"`
+	TimeZone string         `gorm:"type:varchar(64);not null" json:"timeZone"` // IANA name
+	Hours    []WorkingHours `gorm:"type:text;serializer:json" json:"hours"`
+
+	Holidays []Holiday `gorm:"foreignKey:CalendarID" json:"holidays,omitempty"`
+}
+
+// BusinessCalendarReq - payload to create or update a business calendar
+type BusinessCalendarReq struct {
+	Name     string         `json:"name" validate:"required"`
+	TimeZone string         `json:"timeZone" validate:"required"`
+	Hours    []WorkingHours `json:"hours"`
+}
+
+// WorkingHours - one working period on a weekday in wall clock
+// time, a day may have several (e.g. around a lunch break)
+type WorkingHours struct {
+	Day   time.Weekday `json:"day"`   // 0 is Sunday
+	Start string       `json:"start"` // HH:MM
+	End   string       `json:"end"`   // HH:MM, after start, 24:00 for midnight
+}
+
+// WorkingHoursLayout - layout of the start and end of working hours
+const WorkingHoursLayout = "15:04"
+
+// Holiday model - 'holidays' table
+//
+// Day off of a business calendar
+type Holiday struct {
+	HolidayID  uint64    `gorm:"primaryKey" json:"holidayID"`
+	CreatedAt  time.Time `json:"createdAt,omitempty"`
+	CalendarID uint64    `gorm:"uniqueIndex:idx_calendar_holiday;not null" json:"calendarID"`
+	Date       string    `gorm:"type:varchar(10);uniqueIndex:idx_calendar_holiday;not null" json:"date"` // YYYY-MM-DD
+	Name       string    `gorm:"type:varchar(255)" json:"name"`
+}
+
+// HolidayReq - payload to add a holiday
+type HolidayReq struct {
+	Date string `json:"date" validate:"required"`
+	Name string `json:"name"`
+}
+
+// HolidayImport - outcome of a holiday import
+type HolidayImport struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"` // already known dates
+}
+
+// BusinessDue - the moment a duration of business time is over
+type BusinessDue struct {
+	From    time.Time `json:"from"`
+	Minutes int64     `json:"minutes"`
+	Due     time.Time `json:"due"`
+}
+
+// Holiday limits
+const (
+	HolidayDateLayout     = "2006-01-02"
+	HolidayImportMaxBytes = 1 << 20
+)
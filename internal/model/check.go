@@ -0,0 +1,136 @@
+package model
+
+import "time"
+
+// Check model - 'checks' table
+//
+// Synthetic uptime check run by the server at its interval. An
+// incident is opened once it failed FailureThreshold times in a row.
+type Check struct {
+	CheckID   uint64    `gorm:"primaryKey" json:"checkID"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+
+	Name   string    `gorm:"type:varchar(255);not null" json:"name"`
+	Type   CheckType `gorm:"type:varchar(16);not null" json:"type"`
+	Target string    `gorm:"type:varchar(2048);not null" json:"target"` // URL for http, host:port else
+	AuthID uint64    `gorm:"not null" json:"authID"`                    // creator, files the incidents
+	Paused bool      `gorm:"not null;default:false" json:"paused"`
+
+	// in seconds
+	Interval int64 `gorm:"not null" json:"interval"`
+	Timeout  int64 `gorm:"not null" json:"timeout"`
+
+	// assertions
+	Method         string `gorm:"type:varchar(8)" json:"method,omitempty"`            // http
+	ExpectedStatus int    `json:"expectedStatus,omitempty"`                           // http, any 2xx when 0
+	BodyContains   string `gorm:"type:text" json:"bodyContains,omitempty"`            // http
+	MaxLatency     int64  `json:"maxLatency,omitempty"`                               // in milliseconds, none when 0
+	ExpiryDays     int    `json:"expiryDays,omitempty"`                               // tls, fails when the certificate expires sooner
+	SkipVerify     bool   `gorm:"not null;default:false" json:"skipVerify,omitempty"` // http and tls, accept untrusted certificates
+
+	// incident opened after FailureThreshold failures in a row
+	FailureThreshold int          `gorm:"not null" json:"failureThreshold"`
+	Severity         SeverityType `gorm:"type:varchar(16)" json:"severity"`
+	AssignedTo       uint64       `gorm:"not null" json:"assignedTo"`
+	ServiceIDs       []uint64     `gorm:"type:text;serializer:json" json:"serviceIDs"`
+
+	ConsecutiveFailures int        `gorm:"not null;default:0" json:"consecutiveFailures"`
+	LastCheckedAt       *time.Time `json:"lastCheckedAt,omitempty"`
+	NextCheckAt         time.Time  `gorm:"index;not null" json:"nextCheckAt"`
+	AlertedAt           *time.Time `json:"alertedAt,omitempty"`       // set once the failures opened or joined an incident
+	IncidentID          *uint64    `json:"incidentID,omitempty"`      // incident of the current failures
+	SuppressedUntil     *time.Time `json:"suppressedUntil,omitempty"` // end of the maintenance which suppressed the failures
+
+	Status CheckStatus `gorm:"-" json:"status"`
+}
+
+// CheckReq - payload to create or update a check
+type CheckReq struct {
+	Name             string       `json:"name" validate:"required"`
+	Type             CheckType    `json:"type" validate:"required"`
+	Target           string       `json:"target" validate:"required"`
+	Paused           bool         `json:"paused"`
+	Interval         int64        `json:"interval"`
+	Timeout          int64        `json:"timeout"`
+	Method           string       `json:"method"`
+	ExpectedStatus   int          `json:"expectedStatus"`
+	BodyContains     string       `json:"bodyContains"`
+	MaxLatency       int64        `json:"maxLatency"`
+	ExpiryDays       int          `json:"expiryDays"`
+	SkipVerify       bool         `json:"skipVerify"`
+	FailureThreshold int          `json:"failureThreshold"`
+	Severity         SeverityType `json:"severity"`
+	AssignedTo       uint64       `json:"assignedTo"`
+	ServiceIDs       []uint64     `json:"serviceIDs"`
+}
+
+// CheckResult model - 'check_results' table
+//
+// One run of a check
+type CheckResult struct {
+	ResultID  uint64    `gorm:"primaryKey" json:"resultID"`
+	CheckID   uint64    `gorm:"index;not null" json:"checkID"`
+	CreatedAt time.Time `gorm:"index" json:"createdAt"`
+
+	Success       bool       `gorm:"not null" json:"success"`
+	Latency       int64      `gorm:"not null" json:"latency"` // in milliseconds
+	StatusCode    int        `json:"statusCode,omitempty"`    // http
+	CertExpiresAt *time.Time `json:"certExpiresAt,omitempty"` // http and tls
+	Message       string     `gorm:"type:text" json:"message,omitempty"`
+}
+
+// CheckType - what a check probes
+type CheckType string
+
+// Check types
+const (
+	CheckHTTP CheckType = "http" // request with status, body and latency assertions
+	CheckTCP  CheckType = "tcp"  // connect
+	CheckTLS  CheckType = "tls"  // handshake and certificate expiry
+)
+
+// Valid reports whether t is a known check type
+func (t CheckType) Valid() bool {
+	return t == CheckHTTP || t == CheckTCP || t == CheckTLS
+}
+
+// CheckStatus - state of a check
+type CheckStatus string
+
+// Check states
+const (
+	CheckNew     CheckStatus = "new"     // not run yet
+	CheckUp      CheckStatus = "up"      // last run succeeded
+	CheckFailing CheckStatus = "failing" // failed, below the threshold
+	CheckDown    CheckStatus = "down"    // failed at least threshold times in a row
+	CheckPaused  CheckStatus = "paused"
+)
+
+// Check limits
+const (
+	CheckIntervalMin     int64 = 10        // in seconds
+	CheckIntervalMax     int64 = 24 * 3600 // in seconds
+	CheckTimeoutMax      int64 = 60        // in seconds
+	CheckFailuresMax           = 100
+	CheckExpiryDaysMax         = 365
+	CheckBodyMaxBytes          = 1 << 20
+	CheckResultsMax            = 1000 // kept per check
+	CheckResultsPageSize       = 100
+)
+
+// FillStatus sets the status of the check
+func (c *Check) FillStatus() {
+	switch {
+	case c.Paused:
+		c.Status = CheckPaused
+	case c.LastCheckedAt == nil:
+		c.Status = CheckNew
+	case c.ConsecutiveFailures == 0:
+		c.Status = CheckUp
+	case c.ConsecutiveFailures < c.FailureThreshold:
+		c.Status = CheckFailing
+	default:
+		c.Status = CheckDown
+	}
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// CustomField model - 'custom_fields' table
+//
+// Administrator defined field stored on every incident
+// under its key
+type CustomField struct {
+	FieldID   uint64    `gorm:"primaryKey" json:"fieldID"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+
+	Key         string          `gorm:"type:varchar(64);uniqueIndex;not null" json:"key"`
+	Label       string          `gorm:"not null" json:"label"`
+	Description string          `gorm:"type:text" json:"description,omitempty"`
+	Type        CustomFieldType `gorm:"type:varchar(16);not null" json:"type"`
+	Required    bool            `json:"required"`
+	Options     []string        `gorm:"type:text;serializer:json" json:"options,omitempty"` // enum choices
+}
+
+// CustomFieldReq - payload to define a custom field,
+// the key and the type cannot change afterwards
+type CustomFieldReq struct {
+	Key         string          `json:"key" validate:"required"`
+	Label       string          `json:"label" validate:"required"`
+	Description string          `json:"description"`
+	Type        CustomFieldType `json:"type" validate:"required"`
+	Required    bool            `json:"required"`
+	Options     []string        `json:"options"`
+}
+
+// CustomFieldType - kind of value a custom field holds
+type CustomFieldType string
+
+// Custom field types
+const (
+	FieldText   CustomFieldType = "text"
+	FieldNumber CustomFieldType = "number"
+	FieldEnum   CustomFieldType = "enum"
+	FieldDate   CustomFieldType = "date" // YYYY-MM-DD
+	FieldUser   CustomFieldType = "user" // auth ID
+)
+
+// Valid reports whether t is a known field type
+func (t CustomFieldType) Valid() bool {
+	return t == FieldText || t == FieldNumber || t == FieldEnum || t == FieldDate || t == FieldUser
+}
+
+// Custom field limits
+const (
+	CustomFieldTextMaxLength = 1024
+	CustomFieldDateLayout    = "2006-01-02"
+)
+
+// CustomFieldValues - custom field values of an incident by key,
+// stored as JSONB on Postgres and as JSON text elsewhere
+type CustomFieldValues map[string]any
+
+// GormDataType tells gorm to store the map in one column
+func (CustomFieldValues) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType picks the column type per dialect
+func (CustomFieldValues) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "JSONB"
+	}
+	return "TEXT"
+}
+
+// Value implements driver.Valuer
+func (v CustomFieldValues) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// Scan implements sql.Scanner
+func (v *CustomFieldValues) Scan(src any) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return errors.New("unsupported custom field values")
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,27 @@
+package model
+
+// Export formats
+const (
+	ExportCSV   string = "csv"
+	ExportJSONL string = "jsonl"
+)
+
+// IncidentExportColumns - columns available in incident exports,
+// in their default order
+var IncidentExportColumns = []string{
+	"incident_id",
+	"title",
+	"description",
+	"status",
+	"severity",
+	"impact",
+	"urgency",
+	"priority",
+	"auth_id",
+	"assigned_to",
+	"team_id",
+	"created_at",
+	"updated_at",
+	"acknowledged_at",
+	"resolved_at",
+}
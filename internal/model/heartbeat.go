@@ -0,0 +1,82 @@
+package model
+
+import "time"
+
+// Heartbeat model - 'heartbeats' table
+//
+// Dead man's switch for cron jobs and batch pipelines: the job pings
+// it at least every interval and an incident is opened once a ping
+// is more than the grace period late. Jobs can't send bearer tokens,
+// they ping with a secret of which only the hash is stored.
+type Heartbeat struct {
+	HeartbeatID uint64    `gorm:"primaryKey" json:"heartbeatID"`
+	CreatedAt   time.Time `json:"createdAt,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt,omitempty"`
+
+	Name      string `gorm:"type:varchar(255);not null" json:"name"`
+	TokenHash string `gorm:"type:varchar(255);uniqueIndex;not null" json:"-"`
+	AuthID    uint64 `gorm:"not null" json:"authID"` // creator, files the incidents
+
+	// in seconds
+	Interval int64 `gorm:"not null" json:"interval"`
+	Grace    int64 `gorm:"not null" json:"grace"`
+
+	// incident opened when the heartbeat is missed
+	Severity   SeverityType `gorm:"type:varchar(16)" json:"severity"`
+	AssignedTo uint64       `gorm:"not null" json:"assignedTo"`
+	ServiceIDs []uint64     `gorm:"type:text;serializer:json" json:"serviceIDs"`
+
+	LastPingAt *time.Time `json:"lastPingAt,omitempty"`
+	DueAt      time.Time  `gorm:"index;not null" json:"dueAt"` // missed when no ping came by then
+	MissedAt   *time.Time `json:"missedAt,omitempty"`          // set once the miss was alerted
+	IncidentID *uint64    `json:"incidentID,omitempty"`        // incident of the current miss
+
+	SuppressedUntil *time.Time `gorm:"index" json:"suppressedUntil,omitempty"` // end of the maintenance which suppressed the miss
+
+	Status HeartbeatStatus `gorm:"-" json:"status"`
+
+	// only returned when the secret is issued
+	Token   string `gorm:"-" json:"token,omitempty"`
+	PingURL string `gorm:"-" json:"pingURL,omitempty"`
+}
+
+// HeartbeatReq - payload to create or update a heartbeat
+type HeartbeatReq struct {
+	Name       string       `json:"name" validate:"required"`
+	Interval   int64        `json:"interval" validate:"required"`
+	Grace      int64        `json:"grace"`
+	Severity   SeverityType `json:"severity"`
+	AssignedTo uint64       `json:"assignedTo"`
+	ServiceIDs []uint64     `json:"serviceIDs"`
+}
+
+// HeartbeatStatus - state of a heartbeat
+type HeartbeatStatus string
+
+// Heartbeat states
+const (
+	HeartbeatNew  HeartbeatStatus = "new"  // waiting for the first ping
+	HeartbeatUp   HeartbeatStatus = "up"   // pinged within the interval
+	HeartbeatLate HeartbeatStatus = "late" // within the grace period
+	HeartbeatDown HeartbeatStatus = "down" // missed
+)
+
+// Heartbeat limits in seconds
+const (
+	HeartbeatIntervalMin int64 = 10
+	HeartbeatIntervalMax int64 = 31 * 24 * 60 * 60
+)
+
+// FillStatus sets the status of the heartbeat at now
+func (h *Heartbeat) FillStatus(now time.Time) {
+	switch {
+	case h.MissedAt != nil || now.After(h.DueAt):
+		h.Status = HeartbeatDown
+	case h.LastPingAt == nil:
+		h.Status = HeartbeatNew
+	case now.Sub(*h.LastPingAt) > time.Duration(h.Interval)*time.Second:
+		h.Status = HeartbeatLate
+	default:
+		h.Status = HeartbeatUp
+	}
+}
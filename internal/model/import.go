@@ -0,0 +1,41 @@
+package model
+
+// ImportResult - outcome of an incident import
+type ImportResult struct {
+	DryRun   bool             `json:"dryRun"`
+	Total    int              `json:"total"`
+	Valid    int              `json:"valid"`
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// ImportRowError - validation error of one imported row,
+// rows are numbered from 1 without the CSV header
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportFields - fields an imported column can be mapped to
+var ImportFields = []string{
+	"title",
+	"description",
+	"status",
+	"severity",
+	"assigned_to",
+	"assignee_email",
+	"creator_email",
+	"team_id",
+	"created_at",
+	"updated_at",
+	"acknowledged_at",
+	"resolved_at",
+}
+
+// Import limits
+const (
+	ImportMaxRows   = 50000
+	ImportMaxBytes  = 32 << 20 // 32 MiB
+	ImportBatchSize = 500
+)
@@ -17,11 +17,49 @@ type Incident struct {
 	Status      StatusType   `gorm:"default:'open'"`
 	Severity    SeverityType `gorm:"default:'medium'"`
 
-	AuthID     uint64 `gorm:"not null"`
-	AssignedTo uint64 `gorm:"not null"`
+	// priority follows the impact and urgency matrix unless overridden
+	Impact           LevelType    `gorm:"type:varchar(16)" json:"impact,omitempty"`
+	Urgency          LevelType    `gorm:"type:varchar(16)" json:"urgency,omitempty"`
+	Priority         PriorityType `gorm:"type:varchar(2);index" json:"priority,omitempty"`
+	PriorityOverride bool         `json:"priorityOverride,omitempty"`
+
+	// response milestones, used for MTTA and MTTR
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+	ResolvedAt     *time.Time `gorm:"index" json:"resolvedAt,omitempty"`
+
+	AuthID     uint64  `gorm:"not null"`
+	AssignedTo uint64  `gorm:"not null"`
+	TeamID     *uint64 `gorm:"index"` // owning team, optional
+
+	// due times of the SLA targets, see SLAPolicy
+	SLAPolicyID      *uint64    `json:"slaPolicyID,omitempty"`
+	AcknowledgeDueAt *time.Time `gorm:"index" json:"acknowledgeDueAt,omitempty"`
+	ResolveDueAt     *time.Time `gorm:"index" json:"resolveDueAt,omitempty"`
+	SLAAlerts        SLAAlert   `gorm:"not null;default:0" json:"-"` // alerts already sent
+
+	SLA *SLAStatus `gorm:"-" json:"sla,omitempty"`
+
+	// set once the incident was merged into another one
+	DuplicateOf *uint64 `gorm:"index" json:"duplicateOf,omitempty"`
+
+	// values of the administrator defined fields by key
+	CustomFields CustomFieldValues `json:"customFields,omitempty"`
 
 	Creator  Auth `gorm:"foreignKey:AuthID"`
 	Assignee Auth `gorm:"foreignKey:AssignedTo"`
+
+	// impacted services
+	Services []Service `gorm:"many2many:incident_services;joinForeignKey:IncidentID;joinReferences:ServiceID"`
+
+	// responders holding incident-scoped roles
+	Participants []IncidentParticipant `gorm:"foreignKey:IncidentID"`
+
+	// typed links to other incidents, and from them
+	Links      []IncidentLink `gorm:"foreignKey:IncidentID"`
+	LinkedFrom []IncidentLink `gorm:"foreignKey:LinkedID"`
+
+	// free-form tags and key:value labels
+	Tags []Tag `gorm:"many2many:incident_tags;joinForeignKey:IncidentID;joinReferences:TagID"`
 }
 
 type IncidentReq struct {
@@ -30,6 +68,17 @@ type IncidentReq struct {
 	Status      StatusType   `json:"status"`
 	Severity    SeverityType `json:"severity"`
 	AssignedTo  uint64       `json:"assigned_to"`
+	TeamID      uint64       `json:"team_id"`
+	ServiceIDs  []uint64     `json:"service_ids"`
+	Tags        []string     `json:"tags"`
+
+	// priority is derived from impact and urgency,
+	// setting it overrides the matrix
+	Impact   LevelType    `json:"impact"`
+	Urgency  LevelType    `json:"urgency"`
+	Priority PriorityType `json:"priority"`
+
+	CustomFields map[string]any `json:"custom_fields"`
 }
 
 type IncidentUpdate struct {
@@ -39,6 +88,30 @@ type IncidentUpdate struct {
 	Status      StatusType   `json:"status"`
 	Severity    SeverityType `json:"severity"`
 	AssignedTo  uint64       `json:"assigned_to"`
+	TeamID      *uint64      `json:"team_id"` // kept when omitted, 0 removes the owning team
+	ServiceIDs  []uint64     `json:"service_ids"`
+	Tags        []string     `json:"tags"`
+
+	// priority is derived from impact and urgency,
+	// setting it overrides the matrix
+	Impact   LevelType    `json:"impact"`
+	Urgency  LevelType    `json:"urgency"`
+	Priority PriorityType `json:"priority"`
+
+	CustomFields map[string]any `json:"custom_fields"`
+}
+
+// IncidentFilter - optional filters for listing incidents
+type IncidentFilter struct {
+	ServiceID uint64
+	TeamID    uint64
+	Status    StatusType
+	Tags      []string // every tag, by full name
+	Labels    []string // every label, key:value or any value of key
+
+	CustomFields map[string]string // custom field key to value
+
+	Sort string // list order, see Sort* constants
 }
 
 type SeverityType string
@@ -57,3 +130,38 @@ const (
 	Acknowledged StatusType = "acknowledged"
 	Closed       StatusType = "closed"
 )
+
+// Incident list orders, priority sorts incidents
+// without a priority last
+const (
+	SortPriority     string = "priority"
+	SortPriorityDesc string = "-priority"
+	SortCreated      string = "created"
+	SortCreatedDesc  string = "-created"
+)
+
+// TrackStatus records the response milestones for the current status.
+// Acknowledgement is kept once reached, reopening clears the resolution.
+func (i *Incident) TrackStatus(now time.Time) {
+	if (i.Status == Acknowledged || i.Status == Closed) && i.AcknowledgedAt == nil {
+		i.AcknowledgedAt = &now
+	}
+
+	if i.Status == Closed && i.ResolvedAt == nil {
+		i.ResolvedAt = &now
+	}
+
+	if i.Status != Closed {
+		i.ResolvedAt = nil
+	}
+}
+
+// Valid reports whether s is a known status
+func (s StatusType) Valid() bool {
+	return s == Open || s == Acknowledged || s == Closed
+}
+
+// Valid reports whether s is a known severity
+func (s SeverityType) Valid() bool {
+	return s == Low || s == Medium || s == High || s == Critical
+}
@@ -0,0 +1,88 @@
+package model
+
+import "time"
+
+// Indicator model - 'indicators' table
+//
+// Indicator of compromise seen in security incidents. Values are
+// normalized so that the same attacker infrastructure is found across
+// incidents however it was written.
+type Indicator struct {
+	IndicatorID uint64        `gorm:"primaryKey" json:"indicatorID"`
+	CreatedAt   time.Time     `json:"createdAt,omitempty"` // first seen
+	Type        IndicatorType `gorm:"type:varchar(16);uniqueIndex:idx_indicator;not null" json:"type"`
+	Value       string        `gorm:"type:varchar(2048);uniqueIndex:idx_indicator;not null" json:"value"`
+}
+
+// IncidentIndicator model - 'incident_indicators' table
+//
+// Indicator observed in an incident
+type IncidentIndicator struct {
+	ID          uint64    `gorm:"primaryKey" json:"-"`
+	CreatedAt   time.Time `json:"createdAt,omitempty"`
+	IncidentID  uint64    `gorm:"uniqueIndex:idx_incident_indicator;not null" json:"incidentID"`
+	IndicatorID uint64    `gorm:"uniqueIndex:idx_incident_indicator;index;not null" json:"indicatorID"`
+	AuthID      uint64    `json:"authID"` // who added it
+	Note        string    `gorm:"type:text" json:"note,omitempty"`
+
+	Indicator Indicator `gorm:"foreignKey:IndicatorID;references:IndicatorID" json:"indicator"`
+}
+
+// IndicatorReq - payload to add an indicator to an incident
+type IndicatorReq struct {
+	Type  IndicatorType `json:"type"` // detected from the value when empty
+	Value string        `json:"value" validate:"required"`
+	Note  string        `json:"note"`
+}
+
+// IndicatorBulkReq - pasted text to extract indicators from
+type IndicatorBulkReq struct {
+	Text string `json:"text" validate:"required"`
+	Note string `json:"note"`
+}
+
+// IndicatorBulkResult - outcome of a bulk add
+type IndicatorBulkResult struct {
+	Added    []IncidentIndicator `json:"added"`
+	Existing []Indicator         `json:"existing"` // already on the incident
+}
+
+// IndicatorLookup - incidents an indicator was seen in
+type IndicatorLookup struct {
+	Indicator Indicator           `json:"indicator"`
+	Incidents []IndicatorIncident `json:"incidents"`
+}
+
+// IndicatorIncident - incident of an indicator lookup
+type IndicatorIncident struct {
+	IncidentID uint64       `json:"incidentID"`
+	Title      string       `json:"title"`
+	Status     StatusType   `json:"status"`
+	Severity   SeverityType `json:"severity"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	AddedAt    time.Time    `json:"addedAt"` // when the indicator was added to it
+}
+
+// IndicatorType - kind of an indicator of compromise
+type IndicatorType string
+
+// Indicator types
+const (
+	IndicatorIP     IndicatorType = "ip"
+	IndicatorDomain IndicatorType = "domain"
+	IndicatorURL    IndicatorType = "url"
+	IndicatorHash   IndicatorType = "hash" // MD5, SHA-1, SHA-256 or SHA-512
+	IndicatorEmail  IndicatorType = "email"
+)
+
+// Valid reports whether t is a known indicator type
+func (t IndicatorType) Valid() bool {
+	return t == IndicatorIP || t == IndicatorDomain || t == IndicatorURL || t == IndicatorHash || t == IndicatorEmail
+}
+
+// Indicator limits
+const (
+	IndicatorValueMax = 2048
+	IndicatorBulkMax  = 500     // extracted from one text
+	IndicatorTextMax  = 1 << 20 // bytes of pasted text
+)
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// IncidentLink model - 'incident_links' table
+//
+// Typed relation from one incident to another. Links are stored
+// once, on the incident they were created from, and show up on the
+// other incident as linked from.
+type IncidentLink struct {
+	ID         uint64    `gorm:"primaryKey" json:"linkID"`
+	CreatedAt  time.Time `json:"createdAt,omitempty"`
+	IncidentID uint64    `gorm:"uniqueIndex:idx_incident_link;not null" json:"incidentID"`
+	LinkedID   uint64    `gorm:"uniqueIndex:idx_incident_link;index;not null" json:"linkedID"`
+	Type       LinkType  `gorm:"type:varchar(32);uniqueIndex:idx_incident_link;not null" json:"type"`
+	CreatedBy  uint64    `json:"createdBy,omitempty"`
+}
+
+// LinkReq - payload to link an incident to another one
+type LinkReq struct {
+	LinkedID uint64   `json:"linkedID" validate:"required"`
+	Type     LinkType `json:"type" validate:"required"`
+}
+
+// MergeReq - payload to merge an incident into another one
+type MergeReq struct {
+	TargetID uint64 `json:"targetID" validate:"required"`
+}
+
+// LinkType - kind of relation between two incidents
+type LinkType string
+
+// Link types
+const (
+	LinkDuplicates LinkType = "duplicates"
+	LinkCausedBy   LinkType = "caused-by"
+	LinkRelatedTo  LinkType = "related-to"
+)
+
+// Valid reports whether t is a known link type
+func (t LinkType) Valid() bool {
+	return t == LinkDuplicates || t == LinkCausedBy || t == LinkRelatedTo
+}
+
+// Symmetric reports whether the link reads the same in both directions
+func (t LinkType) Symmetric() bool {
+	return t == LinkRelatedTo
+}
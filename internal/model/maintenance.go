@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// MaintenanceWindow model - 'maintenance_windows' table
+//
+// Planned work on services during which their alerts are suppressed
+// or open incidents of low severity only
+type MaintenanceWindow struct {
+	WindowID  uint64    `gorm:"primaryKey" json:"windowID"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+
+	Name        string            `gorm:"type:varchar(255);not null" json:"name"`
+	Description string            `gorm:"type:text" json:"description"`
+	Action      MaintenanceAction `gorm:"type:varchar(16);not null" json:"action"`
+	AuthID      uint64            `gorm:"not null" json:"authID"` // creator
+
+	// first occurrence, later ones repeat it by RRule
+	StartsAt time.Time `gorm:"index;not null" json:"startsAt"`
+	EndsAt   time.Time `gorm:"not null" json:"endsAt"`
+	RRule    string    `gorm:"column:rrule;type:varchar(255)" json:"rrule,omitempty"` // RFC 5545 recurrence rule
+	TimeZone string    `gorm:"type:varchar(64);not null" json:"timeZone"`             // IANA name occurrences keep their wall clock time in
+
+	// covered services
+	Services []Service `gorm:"many2many:maintenance_window_services;joinForeignKey:WindowID;joinReferences:ServiceID" json:"services"`
+
+	// current or upcoming occurrence, none once the window is over
+	Next *MaintenanceOccurrence `gorm:"-" json:"next,omitempty"`
+}
+
+// MaintenanceWindowReq - payload to create or update a maintenance window
+type MaintenanceWindowReq struct {
+	Name        string            `json:"name" validate:"required"`
+	Description string            `json:"description"`
+	Action      MaintenanceAction `json:"action"`
+	StartsAt    time.Time         `json:"startsAt" validate:"required"`
+	EndsAt      time.Time         `json:"endsAt" validate:"required"`
+	RRule       string            `json:"rrule"`
+	TimeZone    string            `json:"timeZone"`
+	ServiceIDs  []uint64          `json:"serviceIDs"`
+}
+
+// MaintenanceOccurrence - one occurrence of a maintenance window
+type MaintenanceOccurrence struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// MaintenanceAction - what happens to the alerts of covered services
+type MaintenanceAction string
+
+// Maintenance actions
+const (
+	MaintenanceSuppress  MaintenanceAction = "suppress"  // no incident
+	MaintenanceDowngrade MaintenanceAction = "downgrade" // incident of low severity
+)
+
+// Valid reports whether a is a known maintenance action
+func (a MaintenanceAction) Valid() bool {
+	return a == MaintenanceSuppress || a == MaintenanceDowngrade
+}
+
+// Maintenance window limits
+const (
+	MaintenanceOccurrencesMax = 500
+	MaintenanceDurationMax    = 31 * 24 * time.Hour
+)
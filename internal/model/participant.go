@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+// IncidentParticipant model - 'incident_participants' table
+//
+// Responders holding an incident-scoped role. AssignedTo on the
+// incident stays the primary owner.
+type IncidentParticipant struct {
+	ID         uint64          `gorm:"primaryKey" json:"participantID"`
+	CreatedAt  time.Time       `json:"createdAt,omitempty"`
+	IncidentID uint64          `gorm:"uniqueIndex:idx_incident_participant;not null" json:"incidentID"`
+	AuthID     uint64          `gorm:"uniqueIndex:idx_incident_participant;index;not null" json:"authID"`
+	Role       ParticipantRole `gorm:"type:varchar(32);uniqueIndex:idx_incident_participant;not null" json:"role"`
+}
+
+// ParticipantReq - payload to add a participant
+type ParticipantReq struct {
+	AuthID uint64          `json:"authID" validate:"required"`
+	Role   ParticipantRole `json:"role" validate:"required"`
+}
+
+// HandoffReq - payload to hand a role over to another responder
+type HandoffReq struct {
+	Role   ParticipantRole `json:"role" validate:"required"`
+	AuthID uint64          `json:"authID" validate:"required"` // new holder
+	Note   string          `json:"note"`
+}
+
+// ParticipantRole - incident-scoped responder role
+type ParticipantRole string
+
+// Participant roles
+const (
+	RoleCommander     ParticipantRole = "incident_commander"
+	RoleCommsLead     ParticipantRole = "communications_lead"
+	RoleScribe        ParticipantRole = "scribe"
+	RoleSubjectExpert ParticipantRole = "subject_matter_expert"
+)
+
+// participantRoleNames - human readable role names
+var participantRoleNames = map[ParticipantRole]string{
+	RoleCommander:     "incident commander",
+	RoleCommsLead:     "communications lead",
+	RoleScribe:        "scribe",
+	RoleSubjectExpert: "subject matter expert",
+}
+
+// Valid returns true for a known participant role
+func (r ParticipantRole) Valid() bool {
+	_, ok := participantRoleNames[r]
+	return ok
+}
+
+// Exclusive returns true when only one responder
+// may hold the role at a time
+func (r ParticipantRole) Exclusive() bool {
+	return r != RoleSubjectExpert
+}
+
+// String returns the human readable role name
+func (r ParticipantRole) String() string {
+	if name, ok := participantRoleNames[r]; ok {
+		return name
+	}
+	return string(r)
+}
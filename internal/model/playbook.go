@@ -0,0 +1,104 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Playbook model - 'playbooks' table
+//
+// Ordered checklist responders follow on an incident. It is attached by
+// hand, or automatically to incidents which match its services and
+// severities when it has any.
+type Playbook struct {
+	PlaybookID uint64    `gorm:"primaryKey" json:"playbookID"`
+	CreatedAt  time.Time `json:"createdAt,omitempty"`
+	UpdatedAt  time.Time `json:"updatedAt,omitempty"`
+
+	Name        string `gorm:"type:varchar(255);uniqueIndex;not null" json:"name"`
+	Description string `gorm:"type:text" json:"description"`
+
+	// attached to incidents of any of the services, with any of the
+	// severities, an empty list matches all
+	ServiceIDs []uint64       `gorm:"type:text;serializer:json" json:"serviceIDs"`
+	Severities []SeverityType `gorm:"type:text;serializer:json" json:"severities"`
+
+	Steps []PlaybookStep `gorm:"type:text;serializer:json" json:"steps"`
+}
+
+// PlaybookStep - step definition of a playbook
+type PlaybookStep struct {
+	Title        string          `json:"title"`
+	Instructions string          `json:"instructions,omitempty"` // markdown
+	RequiredRole ParticipantRole `json:"requiredRole,omitempty"` // only its holders complete the step
+}
+
+// PlaybookReq - payload to create or update a playbook
+type PlaybookReq struct {
+	Name        string         `json:"name" validate:"required"`
+	Description string         `json:"description"`
+	ServiceIDs  []uint64       `json:"serviceIDs"`
+	Severities  []SeverityType `json:"severities"`
+	Steps       []PlaybookStep `json:"steps" validate:"required"`
+}
+
+// Automatic reports whether the playbook is attached by matching
+func (p Playbook) Automatic() bool {
+	return len(p.ServiceIDs) > 0 || len(p.Severities) > 0
+}
+
+// IncidentPlaybook model - 'incident_playbooks' table
+//
+// Copy of a playbook attached to an incident, later changes of the
+// playbook do not affect it. A detached playbook is not attached by
+// matching again.
+type IncidentPlaybook struct {
+	ID         uint64         `gorm:"primaryKey" json:"incidentPlaybookID"`
+	CreatedAt  time.Time      `json:"createdAt,omitempty"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	DetachedID uint64         `gorm:"uniqueIndex:idx_incident_playbook_detached,priority:3;not null;default:0" json:"-"` // the ID once detached, 0 before
+	IncidentID uint64         `gorm:"uniqueIndex:idx_incident_playbook_detached,priority:1;not null" json:"incidentID"`  // once at a time
+	PlaybookID uint64         `gorm:"uniqueIndex:idx_incident_playbook_detached,priority:2;index;not null" json:"playbookID"`
+	Name       string         `gorm:"type:varchar(255);not null" json:"name"`
+	AttachedBy uint64         `json:"attachedBy,omitempty"` // 0 when matched
+
+	Steps []IncidentPlaybookStep `gorm:"foreignKey:IncidentPlaybookID" json:"steps"`
+
+	CompletedSteps int `gorm:"-" json:"completedSteps"`
+}
+
+// IncidentPlaybookStep model - 'incident_playbook_steps' table
+type IncidentPlaybookStep struct {
+	StepID             uint64          `gorm:"primaryKey" json:"stepID"`
+	IncidentPlaybookID uint64          `gorm:"index;not null" json:"-"`
+	Position           int             `json:"position"`
+	Title              string          `gorm:"type:varchar(255);not null" json:"title"`
+	Instructions       string          `gorm:"type:text" json:"instructions,omitempty"`
+	RequiredRole       ParticipantRole `gorm:"type:varchar(32)" json:"requiredRole,omitempty"`
+	CompletedAt        *time.Time      `json:"completedAt,omitempty"`
+	CompletedBy        uint64          `json:"completedBy,omitempty"`
+}
+
+// PlaybookAttachReq - payload to attach a playbook to an incident
+type PlaybookAttachReq struct {
+	PlaybookID uint64 `json:"playbookID" validate:"required"`
+}
+
+// PlaybookStepReq - payload to complete or reopen a step
+type PlaybookStepReq struct {
+	Completed bool `json:"completed"`
+}
+
+// PlaybookStepsMax - steps of a playbook at most
+const PlaybookStepsMax = 50
+
+// FillProgress counts the completed steps
+func (p *IncidentPlaybook) FillProgress() {
+	p.CompletedSteps = 0
+	for _, step := range p.Steps {
+		if step.CompletedAt != nil {
+			p.CompletedSteps++
+		}
+	}
+}
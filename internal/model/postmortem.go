@@ -0,0 +1,130 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Postmortem model - 'postmortems' table
+//
+// One postmortem document per incident
+type Postmortem struct {
+	PostmortemID uint64         `gorm:"primaryKey" json:"postmortemID"`
+	CreatedAt    time.Time      `json:"createdAt,omitempty"`
+	UpdatedAt    time.Time      `json:"updatedAt,omitempty"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+
+	IncidentID  uint64           `gorm:"uniqueIndex;not null" json:"incidentID"`
+	TemplateID  *uint64          `json:"templateID,omitempty"`
+	Title       string           `gorm:"type:varchar(255);not null" json:"title"`
+	Status      PostmortemStatus `gorm:"type:varchar(16);index;not null;default:'draft'" json:"status"`
+	AuthorID    uint64           `gorm:"index" json:"authorID"`
+	PublishedAt *time.Time       `json:"publishedAt,omitempty"`
+
+	Sections []PostmortemSection `gorm:"foreignKey:PostmortemID" json:"sections"`
+	Reviews  []PostmortemReview  `gorm:"foreignKey:PostmortemID" json:"reviews"`
+}
+
+// PostmortemSection model - 'postmortem_sections' table
+type PostmortemSection struct {
+	ID           uint64 `gorm:"primaryKey" json:"-"`
+	PostmortemID uint64 `gorm:"uniqueIndex:idx_postmortem_section;not null" json:"-"`
+	Key          string `gorm:"type:varchar(64);uniqueIndex:idx_postmortem_section;not null" json:"key"`
+	Title        string `gorm:"type:varchar(255);not null" json:"title"`
+	Position     int    `json:"position"`
+	Content      string `gorm:"type:text" json:"content"`
+}
+
+// PostmortemReview model - 'postmortem_reviews' table
+//
+// Reviewers requested on submission and their verdicts
+type PostmortemReview struct {
+	ID           uint64     `gorm:"primaryKey" json:"-"`
+	CreatedAt    time.Time  `json:"createdAt,omitempty"`
+	PostmortemID uint64     `gorm:"uniqueIndex:idx_postmortem_review;not null" json:"-"`
+	AuthID       uint64     `gorm:"uniqueIndex:idx_postmortem_review;index;not null" json:"authID"`
+	Approved     bool       `json:"approved"`
+	ApprovedAt   *time.Time `json:"approvedAt,omitempty"`
+	Comment      string     `gorm:"type:text" json:"comment,omitempty"`
+}
+
+// PostmortemTemplate model - 'postmortem_templates' table
+//
+// Configurable list of sections a new postmortem starts with
+type PostmortemTemplate struct {
+	TemplateID uint64         `gorm:"primaryKey" json:"templateID"`
+	CreatedAt  time.Time      `json:"createdAt,omitempty"`
+	UpdatedAt  time.Time      `json:"updatedAt,omitempty"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Name     string            `gorm:"type:varchar(255);uniqueIndex;not null" json:"name"`
+	Sections []TemplateSection `gorm:"type:text;serializer:json" json:"sections"`
+}
+
+// TemplateSection - section definition of a postmortem template
+type TemplateSection struct {
+	Key    string `json:"key"`
+	Title  string `json:"title"`
+	Prompt string `json:"prompt,omitempty"` // initial content of the section
+}
+
+// PostmortemTemplateReq - payload to create or update a template
+type PostmortemTemplateReq struct {
+	Name     string            `json:"name" validate:"required"`
+	Sections []TemplateSection `json:"sections" validate:"required"`
+}
+
+// PostmortemReq - payload to start the postmortem of an incident
+type PostmortemReq struct {
+	Title      string `json:"title"`
+	TemplateID uint64 `json:"templateID"`
+}
+
+// PostmortemUpdate - payload to edit a draft
+type PostmortemUpdate struct {
+	Title    string           `json:"title"`
+	Sections []SectionContent `json:"sections"`
+}
+
+// SectionContent - new content of one section
+type SectionContent struct {
+	Key     string `json:"key"`
+	Content string `json:"content"`
+}
+
+// PostmortemSubmitReq - payload to submit a draft for review
+type PostmortemSubmitReq struct {
+	Reviewers []uint64 `json:"reviewers" validate:"required"`
+}
+
+// PostmortemReviewReq - payload to approve or request changes
+type PostmortemReviewReq struct {
+	Comment string `json:"comment"`
+}
+
+// PostmortemStatus - workflow state of a postmortem
+type PostmortemStatus string
+
+// Postmortem states
+const (
+	PostmortemDraft     PostmortemStatus = "draft"
+	PostmortemInReview  PostmortemStatus = "in_review"
+	PostmortemPublished PostmortemStatus = "published"
+)
+
+// Keys of the sections filled in from the incident
+const (
+	SectionTimeline    string = "timeline"
+	SectionActionItems string = "action_items"
+)
+
+// DefaultPostmortemSections - sections used when no template is chosen
+var DefaultPostmortemSections = []TemplateSection{
+	{Key: "summary", Title: "Summary"},
+	{Key: "impact", Title: "Impact"},
+	{Key: "root_cause", Title: "Root cause"},
+	{Key: SectionTimeline, Title: "Timeline"},
+	{Key: "lessons_learned", Title: "Lessons learned"},
+	{Key: SectionActionItems, Title: "Action items"},
+}
@@ -0,0 +1,60 @@
+package model
+
+// LevelType - impact or urgency of an incident
+type LevelType string
+
+// Impact and urgency levels, in priority matrix order
+const (
+	LevelHigh   LevelType = "high"
+	LevelMedium LevelType = "medium"
+	LevelLow    LevelType = "low"
+)
+
+// Levels - impact and urgency levels in priority matrix order
+var Levels = []LevelType{LevelHigh, LevelMedium, LevelLow}
+
+// Valid reports whether l is a known level
+func (l LevelType) Valid() bool {
+	return l == LevelHigh || l == LevelMedium || l == LevelLow
+}
+
+// PriorityType - P1 (most urgent) to P5
+type PriorityType string
+
+// Priorities
+const (
+	P1 PriorityType = "P1"
+	P2 PriorityType = "P2"
+	P3 PriorityType = "P3"
+	P4 PriorityType = "P4"
+	P5 PriorityType = "P5"
+)
+
+// Priorities - all priorities, most urgent first
+var Priorities = []PriorityType{P1, P2, P3, P4, P5}
+
+// Valid reports whether p is a known priority
+func (p PriorityType) Valid() bool {
+	return p == P1 || p == P2 || p == P3 || p == P4 || p == P5
+}
+
+// PriorityMatrix - how impact and urgency map to a priority,
+// and the SLA targets of each priority
+type PriorityMatrix struct {
+	Rules   []PriorityRule   `json:"matrix"`
+	Targets []PriorityTarget `json:"targets"`
+}
+
+// PriorityRule - one cell of the priority matrix
+type PriorityRule struct {
+	Impact   LevelType    `json:"impact"`
+	Urgency  LevelType    `json:"urgency"`
+	Priority PriorityType `json:"priority"`
+}
+
+// PriorityTarget - SLA targets of a priority in seconds
+type PriorityTarget struct {
+	Priority   PriorityType `json:"priority"`
+	Response   float64      `json:"responseSeconds"`
+	Resolution float64      `json:"resolutionSeconds"`
+}
@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+// MetricsFilter - range and scope of a metrics report
+type MetricsFilter struct {
+	From   time.Time
+	To     time.Time
+	TeamID uint64
+}
+
+// MetricsReport - response KPIs of incidents created in a date range
+type MetricsReport struct {
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Total int64     `json:"total"`
+
+	TimeToAcknowledge DurationStats `json:"timeToAcknowledge"`
+	TimeToResolve     DurationStats `json:"timeToResolve"`
+
+	SLA SLAReport `json:"sla"`
+
+	BySeverity []CountBucket `json:"bySeverity"`
+	ByStatus   []CountBucket `json:"byStatus"`
+	ByService  []CountBucket `json:"byService"`
+	ByAssignee []CountBucket `json:"byAssignee"`
+
+	Weekly []WeeklyMetrics `json:"weekly"`
+}
+
+// DurationStats - distribution of a response time in seconds
+type DurationStats struct {
+	Count  int64   `json:"count"`
+	Mean   float64 `json:"meanSeconds"`
+	Median float64 `json:"medianSeconds"`
+	P90    float64 `json:"p90Seconds"`
+}
+
+// SLAReport - SLA compliance of the incidents with targets
+type SLAReport struct {
+	Acknowledge SLACompliance `json:"acknowledge"`
+	Resolve     SLACompliance `json:"resolve"`
+}
+
+// SLACompliance - targets met, breached and still running,
+// the percentage is of the met and breached ones
+type SLACompliance struct {
+	Met      int64    `json:"met"`
+	Breached int64    `json:"breached"`
+	Pending  int64    `json:"pending"`
+	Percent  *float64 `json:"compliancePercent"`
+}
+
+// CountBucket - number of incidents sharing one value
+type CountBucket struct {
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// WeeklyMetrics - volume and response times of one week,
+// weeks start on Monday
+type WeeklyMetrics struct {
+	WeekStart string   `json:"weekStart"`
+	Incidents int64    `json:"incidents"`
+	MTTA      float64  `json:"mttaSeconds"`
+	MTTR      float64  `json:"mttrSeconds"`
+	Change    *float64 `json:"changePercent"` // incidents compared to the previous week
+}
+
+// Metrics report ranges
+const (
+	MetricsDefaultDays = 28
+	MetricsMaxDays     = 366
+)
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Schedule model - 'schedules' table
+//
+// A schedule is a simple rotation: starting at RotationStart,
+// each member (ordered by position) is on call for ShiftHours
+// before handing over to the next one.
+type Schedule struct {
+	ScheduleID uint64         `gorm:"primaryKey" json:"scheduleID"`
+	CreatedAt  time.Time      `json:"createdAt,omitempty"`
+	UpdatedAt  time.Time      `json:"updatedAt,omitempty"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Name          string    `gorm:"not null" json:"name"`
+	Description   string    `gorm:"type:text" json:"description"`
+	TimeZone      string    `gorm:"not null;default:'UTC'" json:"timeZone"`
+	RotationStart time.Time `gorm:"not null" json:"rotationStart"`
+	ShiftHours    uint32    `gorm:"not null;default:168" json:"shiftHours"`
+
+	Members []ScheduleMember `gorm:"foreignKey:ScheduleID" json:"members"`
+}
+
+// ScheduleMember model - 'schedule_members' table
+type ScheduleMember struct {
+	ID         uint64 `gorm:"primaryKey" json:"-"`
+	ScheduleID uint64 `gorm:"index;not null" json:"-"`
+	AuthID     uint64 `gorm:"index;not null" json:"authID"`
+	Position   uint32 `gorm:"not null" json:"position"`
+}
+
+// ScheduleReq - payload to create an on-call schedule
+type ScheduleReq struct {
+	Name          string    `json:"name" validate:"required"`
+	Description   string    `json:"description"`
+	TimeZone      string    `json:"timeZone"`
+	RotationStart time.Time `json:"rotationStart"`
+	ShiftHours    uint32    `json:"shiftHours"`
+	Members       []uint64  `json:"members"`
+}
+
+// Shift - one computed on-call period of a schedule
+type Shift struct {
+	ScheduleID   uint64    `json:"scheduleID"`
+	ScheduleName string    `json:"scheduleName"`
+	AuthID       uint64    `json:"authID"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+}
+
+// CalendarFeed model - 'calendar_feeds' table
+//
+// Calendar clients can't send bearer tokens, so each user
+// may hold one revocable secret to subscribe to their
+// on-call feed. Only the hash of the secret is stored.
+type CalendarFeed struct {
+	ID        uint64    `gorm:"primaryKey" json:"-"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	UpdatedAt time.Time `json:"-"`
+	TokenHash string    `gorm:"type:varchar(255);uniqueIndex;not null" json:"-"`
+	IDAuth    uint64    `gorm:"uniqueIndex;not null" json:"-"`
+}
+
+// CalendarFeedRes - returned once when a feed secret is issued
+type CalendarFeedRes struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// Calendar export limits in weeks
+const (
+	CalendarDefaultWeeks int = 4
+	CalendarMaxWeeks     int = 52
+)
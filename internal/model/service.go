@@ -0,0 +1,85 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Service model - 'services' table
+type Service struct {
+	ServiceID uint64         `gorm:"primaryKey" json:"serviceID"`
+	CreatedAt time.Time      `json:"createdAt,omitempty"`
+	UpdatedAt time.Time      `json:"updatedAt,omitempty"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	DeletedID uint64         `gorm:"uniqueIndex:idx_service_name_deleted,priority:2;not null;default:0" json:"-"` // the service ID once deleted, 0 before
+
+	Name             string `gorm:"type:varchar(255);uniqueIndex:idx_service_name_deleted,priority:1;not null" json:"name"` // deleted names can be reused
+	Description      string `gorm:"type:text" json:"description"`
+	OwnerTeam        string `json:"ownerTeam"`
+	Tier             uint8  `gorm:"not null;default:3" json:"tier"`
+	RunbookURL       string `json:"runbookURL"`
+	EscalationPolicy string `json:"escalationPolicy"`
+
+	// services this service depends on
+	Dependencies []Service `gorm:"many2many:service_dependencies;joinForeignKey:ServiceID;joinReferences:DependencyID" json:"dependencies,omitempty"`
+}
+
+// ServiceReq - payload to create or update a service
+type ServiceReq struct {
+	Name             string   `json:"name" validate:"required"`
+	Description      string   `json:"description"`
+	OwnerTeam        string   `json:"ownerTeam"`
+	Tier             uint8    `json:"tier"`
+	RunbookURL       string   `json:"runbookURL"`
+	EscalationPolicy string   `json:"escalationPolicy"`
+	Dependencies     []uint64 `json:"dependencies"`
+}
+
+// Service tiers, tier 1 being the most critical
+const (
+	ServiceTierMin uint8 = 1
+	ServiceTierMax uint8 = 4
+)
+
+// ServiceDependency - one edge of the 'service_dependencies' join table:
+// ServiceID depends on DependencyID
+type ServiceDependency struct {
+	ServiceID    uint64 `gorm:"primaryKey"`
+	DependencyID uint64 `gorm:"primaryKey"`
+}
+
+// Dependency walk directions
+//
+// upstream: services the given service depends on
+//
+// downstream: services which depend on the given service
+const (
+	DependencyUpstream   string = "upstream"
+	DependencyDownstream string = "downstream"
+	DependencyBoth       string = "both"
+)
+
+// DependencyNode - a service reached while walking the dependency graph
+type DependencyNode struct {
+	ServiceID uint64 `json:"serviceID"`
+	Name      string `json:"name"`
+	Tier      uint8  `json:"tier"`
+	Depth     int    `json:"depth"`
+	Via       uint64 `json:"via,omitempty"` // previous hop
+}
+
+// DependencyGraph - upstream and downstream services of one service
+type DependencyGraph struct {
+	ServiceID  uint64           `json:"serviceID"`
+	Name       string           `json:"name"`
+	Upstream   []DependencyNode `json:"upstream"`
+	Downstream []DependencyNode `json:"downstream"`
+}
+
+// BlastRadius - services transitively affected by an incident
+type BlastRadius struct {
+	IncidentID uint64           `json:"incidentID"`
+	Impacted   []DependencyNode `json:"impacted"`
+	Affected   []DependencyNode `json:"affected"`
+}
@@ -0,0 +1,99 @@
+package model
+
+import "time"
+
+// SLAPolicy model - 'sla_policies' table
+//
+// Response time targets for the incidents of one priority or one
+// severity, a priority policy wins over a severity policy
+type SLAPolicy struct {
+	PolicyID  uint64    `gorm:"primaryKey" json:"policyID"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+
+	Name     string       `gorm:"type:varchar(255);not null" json:"name"`
+	Priority PriorityType `gorm:"type:varchar(2);index" json:"priority,omitempty"`
+	Severity SeverityType `gorm:"type:varchar(16);index" json:"severity,omitempty"`
+
+	// targets in seconds after the incident was created, 0 for none
+	AcknowledgeWithin int64 `json:"acknowledgeWithin"`
+	ResolveWithin     int64 `json:"resolveWithin"`
+
+	// the clocks only run during business time when set
+	CalendarID *uint64           `gorm:"index" json:"calendarID,omitempty"`
+	Calendar   *BusinessCalendar `gorm:"foreignKey:CalendarID" json:"-"`
+}
+
+// SLAPolicyReq - payload to create or update an SLA policy,
+// exactly one of priority and severity is required
+type SLAPolicyReq struct {
+	Name              string       `json:"name" validate:"required"`
+	Priority          PriorityType `json:"priority"`
+	Severity          SeverityType `json:"severity"`
+	AcknowledgeWithin int64        `json:"acknowledgeWithin"`
+	ResolveWithin     int64        `json:"resolveWithin"`
+	CalendarID        *uint64      `json:"calendarID"`
+}
+
+// SLAState - progress of one SLA target
+type SLAState string
+
+// SLA states
+const (
+	SLAPending  SLAState = "pending"
+	SLAAtRisk   SLAState = "at_risk"
+	SLAMet      SLAState = "met"
+	SLABreached SLAState = "breached"
+)
+
+// SLAStatus - state of the SLA targets of an incident
+type SLAStatus struct {
+	Acknowledge SLAState `json:"acknowledge,omitempty"`
+	Resolve     SLAState `json:"resolve,omitempty"`
+}
+
+// SLAAtRiskRatio - share of the time to a due time after which
+// an unmet target is at risk
+const SLAAtRiskRatio = 0.8
+
+// SLAAlert - at risk and breach alerts already sent for an incident
+type SLAAlert uint8
+
+// SLA alerts, bit flags
+const (
+	SLAAcknowledgeAtRisk SLAAlert = 1 << iota
+	SLAAcknowledgeBreached
+	SLAResolveAtRisk
+	SLAResolveBreached
+)
+
+// SLATargetState returns the state of a target due at due which
+// counts from start and is met at reached
+func SLATargetState(start time.Time, due, reached *time.Time, now time.Time) SLAState {
+	switch {
+	case due == nil:
+		return ""
+	case reached != nil && reached.After(*due):
+		return SLABreached
+	case reached != nil:
+		return SLAMet
+	case now.After(*due):
+		return SLABreached
+	case now.Sub(start) >= time.Duration(float64(due.Sub(start))*SLAAtRiskRatio):
+		return SLAAtRisk
+	}
+	return SLAPending
+}
+
+// FillSLA sets the SLA status of the incident's targets
+func (i *Incident) FillSLA(now time.Time) {
+	if i.AcknowledgeDueAt == nil && i.ResolveDueAt == nil {
+		i.SLA = nil
+		return
+	}
+
+	i.SLA = &SLAStatus{
+		Acknowledge: SLATargetState(i.CreatedAt, i.AcknowledgeDueAt, i.AcknowledgedAt, now),
+		Resolve:     SLATargetState(i.CreatedAt, i.ResolveDueAt, i.ResolvedAt, now),
+	}
+}
@@ -0,0 +1,123 @@
+package model
+
+// StixBundle - STIX 2.1 bundle of threat intelligence objects
+type StixBundle struct {
+	Type    string `json:"type"` // bundle
+	ID      string `json:"id"`
+	Objects []any  `json:"objects"`
+}
+
+// StixCommon - properties shared by STIX objects, timestamps are UTC
+// with millisecond precision
+type StixCommon struct {
+	Type              string   `json:"type"`
+	SpecVersion       string   `json:"spec_version,omitempty"` // optional for cyber-observables
+	ID                string   `json:"id"`                     // type--UUID
+	Created           string   `json:"created,omitempty"`
+	Modified          string   `json:"modified,omitempty"`
+	Revoked           bool     `json:"revoked,omitempty"`
+	ObjectMarkingRefs []string `json:"object_marking_refs,omitempty"`
+}
+
+// StixMarkingDefinition - data marking such as a TLP level
+type StixMarkingDefinition struct {
+	StixCommon
+	Name           string            `json:"name"`
+	DefinitionType string            `json:"definition_type"`
+	Definition     map[string]string `json:"definition"`
+}
+
+// StixIncident - incident domain object
+type StixIncident struct {
+	StixCommon
+	Name            string               `json:"name"`
+	Description     string               `json:"description,omitempty"`
+	KillChainPhases []StixKillChainPhase `json:"kill_chain_phases,omitempty"`
+}
+
+// StixAttackPattern - ATT&CK technique
+type StixAttackPattern struct {
+	StixCommon
+	Name               string                  `json:"name"`
+	ExternalReferences []StixExternalReference `json:"external_references"`
+	KillChainPhases    []StixKillChainPhase    `json:"kill_chain_phases,omitempty"`
+}
+
+// StixIndicator - indicator domain object, detects the observables its
+// pattern matches
+type StixIndicator struct {
+	StixCommon
+	Name           string   `json:"name,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	IndicatorTypes []string `json:"indicator_types,omitempty"`
+	Pattern        string   `json:"pattern"`
+	PatternType    string   `json:"pattern_type"` // stix, snort, yara...
+	ValidFrom      string   `json:"valid_from"`
+	ValidUntil     string   `json:"valid_until,omitempty"`
+}
+
+// StixObservedData - cyber-observables seen over a period
+type StixObservedData struct {
+	StixCommon
+	FirstObserved  string   `json:"first_observed"`
+	LastObserved   string   `json:"last_observed"`
+	NumberObserved int      `json:"number_observed"`
+	ObjectRefs     []string `json:"object_refs"`
+}
+
+// StixObservable - cyber-observable of an indicator of compromise, an
+// address, domain, URL or file
+type StixObservable struct {
+	StixCommon
+	Value  string            `json:"value,omitempty"`
+	Hashes map[string]string `json:"hashes,omitempty"` // file
+}
+
+// StixRelationship - relationship between two objects of a bundle
+type StixRelationship struct {
+	StixCommon
+	RelationshipType string `json:"relationship_type"`
+	SourceRef        string `json:"source_ref"`
+	TargetRef        string `json:"target_ref"`
+}
+
+// StixKillChainPhase - phase of a kill chain, ATT&CK tactics use the
+// mitre-attack chain
+type StixKillChainPhase struct {
+	KillChainName string `json:"kill_chain_name"`
+	PhaseName     string `json:"phase_name"`
+}
+
+// StixExternalReference - reference to a non-STIX source
+type StixExternalReference struct {
+	SourceName string `json:"source_name"`
+	ExternalID string `json:"external_id,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// StixImportResult - outcome of a STIX bundle import
+type StixImportResult struct {
+	Total    int                 `json:"total"` // indicator objects in the bundle
+	Added    []IncidentIndicator `json:"added"`
+	Existing []Indicator         `json:"existing"` // already on the incident
+	Skipped  []StixImportIssue   `json:"skipped"`  // indicators which could not be used
+	Errors   []StixImportIssue   `json:"errors"`   // objects breaking the STIX rules
+}
+
+// StixImportIssue - why an object of an imported bundle was skipped or
+// rejected
+type StixImportIssue struct {
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message"`
+}
+
+// TLP levels of STIX exports
+const (
+	TLPWhite = "white"
+	TLPGreen = "green"
+	TLPAmber = "amber"
+	TLPRed   = "red"
+)
+
+// StixImportMaxBytes - size limit of an imported bundle
+const StixImportMaxBytes = 10 << 20
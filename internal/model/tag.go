@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// Tag model - 'tags' table
+//
+// Free-form classification of incidents. A tag written as
+// key:value (env:prod) is a label, Key and Value are split
+// out of the name for filtering.
+type Tag struct {
+	TagID     uint64    `gorm:"primaryKey" json:"tagID"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	Name      string    `gorm:"type:varchar(129);uniqueIndex;not null" json:"name"` // key:value of TagMaxLength each
+	Key       string    `gorm:"type:varchar(64);index" json:"key,omitempty"`
+	Value     string    `gorm:"type:varchar(64)" json:"value,omitempty"`
+}
+
+// TagSuggestion - autocompleted tag with the number of tagged incidents
+type TagSuggestion struct {
+	Tag
+	Incidents int64 `json:"incidents"`
+}
+
+// TagReq - tags to add to or set on an incident
+type TagReq struct {
+	Tags []string `json:"tags" validate:"required"`
+}
+
+// Tag limits
+const (
+	TagMaxLength       = 64 // of a plain tag, a label key or a label value
+	TagSuggestLimit    = 10
+	TagSuggestMaxLimit = 50
+)
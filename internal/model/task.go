@@ -0,0 +1,85 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Task model - 'tasks' table
+//
+// Follow-up work (action items) attached to an incident
+type Task struct {
+	TaskID    uint64         `gorm:"primaryKey" json:"taskID"`
+	CreatedAt time.Time      `json:"createdAt,omitempty"`
+	UpdatedAt time.Time      `json:"updatedAt,omitempty"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	IncidentID  uint64       `gorm:"index;not null" json:"incidentID"`
+	Title       string       `gorm:"type:varchar(255);not null" json:"title"`
+	Description string       `gorm:"type:text" json:"description"`
+	AssignedTo  uint64       `gorm:"index" json:"assignedTo,omitempty"`
+	DueAt       *time.Time   `gorm:"index" json:"dueAt,omitempty"`
+	Priority    TaskPriority `gorm:"type:varchar(16);not null;default:'medium'" json:"priority"`
+	Status      TaskStatus   `gorm:"type:varchar(16);index;not null;default:'open'" json:"status"`
+	CreatedBy   uint64       `json:"createdBy"`
+	CompletedAt *time.Time   `json:"completedAt,omitempty"`
+	CompletedBy uint64       `json:"completedBy,omitempty"`
+	RemindedAt  *time.Time   `json:"-"` // last overdue reminder
+
+	Overdue bool `gorm:"-" json:"overdue"`
+}
+
+// TaskReq - payload to create or update a task
+type TaskReq struct {
+	Title       string       `json:"title" validate:"required"`
+	Description string       `json:"description"`
+	AssignedTo  uint64       `json:"assignedTo"`
+	DueAt       *time.Time   `json:"dueAt"`
+	Priority    TaskPriority `json:"priority"`
+	Status      TaskStatus   `json:"status"`
+}
+
+// TaskFilter - query parameters to list tasks
+type TaskFilter struct {
+	Status  TaskStatus
+	Overdue bool
+}
+
+// TaskPriority - urgency of a task
+type TaskPriority string
+
+// Task priorities
+const (
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+)
+
+// TaskStatus - progress of a task
+type TaskStatus string
+
+// Task statuses
+const (
+	TaskStatusOpen       TaskStatus = "open"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusDone       TaskStatus = "done"
+)
+
+// TaskReminderPeriod - minimum time between two reminders of the same overdue task
+const TaskReminderPeriod = 24 * time.Hour
+
+// Valid returns true for a known task priority
+func (p TaskPriority) Valid() bool {
+	return p == TaskPriorityLow || p == TaskPriorityMedium || p == TaskPriorityHigh
+}
+
+// Valid returns true for a known task status
+func (s TaskStatus) Valid() bool {
+	return s == TaskStatusOpen || s == TaskStatusInProgress || s == TaskStatusDone
+}
+
+// IsOverdue returns true when an unfinished task is past its due date
+func (t Task) IsOverdue(now time.Time) bool {
+	return t.Status != TaskStatusDone && t.DueAt != nil && t.DueAt.Before(now)
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Team model - 'teams' table
+type Team struct {
+	TeamID    uint64         `gorm:"primaryKey" json:"teamID"`
+	CreatedAt time.Time      `json:"createdAt,omitempty"`
+	UpdatedAt time.Time      `json:"updatedAt,omitempty"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	DeletedID uint64         `gorm:"uniqueIndex:idx_team_name_deleted,priority:2;not null;default:0" json:"-"` // the team ID once deleted, 0 before
+
+	Name        string `gorm:"type:varchar(255);uniqueIndex:idx_team_name_deleted,priority:1;not null" json:"name"` // deleted names can be reused
+	Description string `gorm:"type:text" json:"description"`
+
+	Members []TeamMember `gorm:"foreignKey:TeamID" json:"members,omitempty"`
+}
+
+// TeamMember model - 'team_members' table
+type TeamMember struct {
+	ID        uint64    `gorm:"primaryKey" json:"-"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	UpdatedAt time.Time `json:"-"`
+	TeamID    uint64    `gorm:"uniqueIndex:idx_team_member;not null" json:"-"`
+	AuthID    uint64    `gorm:"uniqueIndex:idx_team_member;index;not null" json:"authID"`
+	Role      TeamRole  `gorm:"type:varchar(32);not null;default:'responder'" json:"role"`
+}
+
+// TeamReq - payload to create or update a team
+type TeamReq struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+}
+
+// TeamMemberReq - payload to add a member or change the role of a member
+type TeamMemberReq struct {
+	AuthID uint64   `json:"authID" validate:"required"`
+	Role   TeamRole `json:"role"`
+}
+
+// TeamRole - role of a member within one team
+type TeamRole string
+
+// Team roles
+const (
+	TeamRoleLead      TeamRole = "lead"
+	TeamRoleResponder TeamRole = "responder"
+	TeamRoleViewer    TeamRole = "viewer"
+)
+
+// TeamPermission - action which can be granted at team scope
+type TeamPermission string
+
+// Team permissions
+const (
+	PermIncidentView TeamPermission = "incident:view"
+	PermIncidentEdit TeamPermission = "incident:edit"
+	PermTeamManage   TeamPermission = "team:manage"
+)
+
+// TeamRolePermissions - permissions granted by each team role
+var TeamRolePermissions = map[TeamRole][]TeamPermission{
+	TeamRoleLead:      {PermIncidentView, PermIncidentEdit, PermTeamManage},
+	TeamRoleResponder: {PermIncidentView, PermIncidentEdit},
+	TeamRoleViewer:    {PermIncidentView},
+}
+
+// Valid returns true for a known team role
+func (r TeamRole) Valid() bool {
+	_, ok := TeamRolePermissions[r]
+	return ok
+}
+
+// Can returns true when the role grants the permission
+func (r TeamRole) Can(permission TeamPermission) bool {
+	for _, p := range TeamRolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// IncidentEvent model - 'incident_events' table
+//
+// Append-only timeline of everything that happened to an incident
+type IncidentEvent struct {
+	ID         uint64    `gorm:"primaryKey" json:"eventID"`
+	CreatedAt  time.Time `gorm:"index" json:"createdAt"`
+	IncidentID uint64    `gorm:"index;not null" json:"incidentID"`
+	AuthID     uint64    `json:"authID,omitempty"` // actor, 0 for the system
+	Type       EventType `gorm:"type:varchar(64);not null" json:"type"`
+	Message    string    `gorm:"type:text" json:"message"`
+}
+
+// EventType - kind of a timeline entry
+type EventType string
+
+// Timeline event types
+const (
+	EventCreated       EventType = "created"
+	EventImported      EventType = "imported"
+	EventUpdated       EventType = "updated"
+	EventStatusChanged EventType = "status_changed"
+	EventRoleAssigned  EventType = "role_assigned"
+	EventRoleRemoved   EventType = "role_removed"
+	EventRoleHandoff   EventType = "role_handoff"
+	EventTaskCreated   EventType = "task_created"
+	EventTaskCompleted EventType = "task_completed"
+
+	EventSeverityChanged EventType = "severity_changed"
+	EventReassigned      EventType = "reassigned"
+	EventPriorityChanged EventType = "priority_changed"
+
+	EventSLAAtRisk   EventType = "sla_at_risk"
+	EventSLABreached EventType = "sla_breached"
+
+	EventLinked   EventType = "linked"
+	EventUnlinked EventType = "unlinked"
+	EventMerged   EventType = "merged"
+
+	EventTagged   EventType = "tagged"
+	EventUntagged EventType = "untagged"
+
+	EventPostmortemCreated   EventType = "postmortem_created"
+	EventPostmortemPublished EventType = "postmortem_published"
+
+	EventPlaybookAttached      EventType = "playbook_attached"
+	EventPlaybookDetached      EventType = "playbook_detached"
+	EventPlaybookStepCompleted EventType = "playbook_step_completed"
+	EventPlaybookStepReopened  EventType = "playbook_step_reopened"
+
+	EventIndicatorAdded   EventType = "indicator_added"
+	EventIndicatorRemoved EventType = "indicator_removed"
+
+	EventTechniqueTagged   EventType = "technique_tagged"
+	EventTechniqueUntagged EventType = "technique_untagged"
+)
@@ -0,0 +1,61 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	alert_gen "github.com/Dhar01/incident_resp/router/alerts"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type alertAPI struct{}
+
+var _ alert_gen.ServerInterface = (*alertAPI)(nil)
+
+func newAlertAPI() *alertAPI {
+	return &alertAPI{}
+}
+
+func (api *alertAPI) FetchAlerts(c *gin.Context, params alert_gen.FetchAlertsParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	filter := model.AlertFilter{}
+	if params.Outcome != nil {
+		filter.Outcome = model.AlertOutcome(*params.Outcome)
+	}
+	if params.WindowID != nil {
+		filter.WindowID = *params.WindowID
+	}
+	if params.Before != nil {
+		filter.Before = *params.Before
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+
+	resp, statusCode := handler.GetAlerts(filter)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *alertAPI) IngestAlert(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.AlertReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.IngestAlert(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
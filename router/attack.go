@@ -0,0 +1,107 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	attack_gen "github.com/Dhar01/incident_resp/router/attack"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type attackAPI struct{}
+
+var _ attack_gen.ServerInterface = (*attackAPI)(nil)
+
+func newAttackAPI() *attackAPI {
+	return &attackAPI{}
+}
+
+func (api *attackAPI) FetchAttackTactics(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetAttackTactics()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *attackAPI) FetchAttackTechniques(c *gin.Context, params attack_gen.FetchAttackTechniquesParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	var tactic, query string
+	if params.Tactic != nil {
+		tactic = *params.Tactic
+	}
+	if params.Q != nil {
+		query = *params.Q
+	}
+
+	resp, statusCode := handler.GetAttackTechniques(tactic, query)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *attackAPI) FetchIncidentTechniques(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentTechniques(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *attackAPI) AddIncidentTechnique(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.IncidentTechniqueReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.AddIncidentTechnique(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *attackAPI) RemoveIncidentTechnique(c *gin.Context, id uint64, techniqueID string) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.RemoveIncidentTechnique(id, techniqueID, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *attackAPI) FetchTechniqueReport(c *gin.Context, params attack_gen.FetchTechniqueReportParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	filter := model.MetricsFilter{}
+	if params.From != nil {
+		filter.From = *params.From
+	}
+	if params.To != nil {
+		filter.To = *params.To
+	}
+	if params.Team != nil {
+		filter.TeamID = *params.Team
+	}
+
+	resp, statusCode := handler.GetTechniqueReport(filter)
+
+	renderResponse(c, resp, statusCode)
+}
@@ -0,0 +1,122 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	automation_gen "github.com/Dhar01/incident_resp/router/automation"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type automationAPI struct{}
+
+var _ automation_gen.ServerInterface = (*automationAPI)(nil)
+
+func newAutomationAPI() *automationAPI {
+	return &automationAPI{}
+}
+
+func (api *automationAPI) FetchAutomationRules(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetAutomationRules()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *automationAPI) FetchAutomationRule(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetAutomationRule(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *automationAPI) CreateAutomationRule(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.AutomationRuleReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateAutomationRule(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *automationAPI) UpdateAutomationRule(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.AutomationRuleReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdateAutomationRule(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *automationAPI) DeleteAutomationRule(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteAutomationRule(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *automationAPI) FetchAutomationRuns(c *gin.Context, params automation_gen.FetchAutomationRunsParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	filter := model.AutomationRunFilter{}
+	if params.RuleID != nil {
+		filter.RuleID = *params.RuleID
+	}
+	if params.IncidentID != nil {
+		filter.IncidentID = *params.IncidentID
+	}
+
+	resp, statusCode := handler.GetAutomationRuns(filter)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *automationAPI) DryRunAutomation(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.AutomationDryRunReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.DryRunAutomation(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
@@ -0,0 +1,144 @@
+package router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	calendar_gen "github.com/Dhar01/incident_resp/router/calendars"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type calendarAPI struct{}
+
+var _ calendar_gen.ServerInterface = (*calendarAPI)(nil)
+
+func newCalendarAPI() *calendarAPI {
+	return &calendarAPI{}
+}
+
+func (api *calendarAPI) FetchBusinessCalendars(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetBusinessCalendars()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *calendarAPI) FetchBusinessCalendar(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetBusinessCalendar(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *calendarAPI) CreateBusinessCalendar(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.BusinessCalendarReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateBusinessCalendar(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *calendarAPI) UpdateBusinessCalendar(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.BusinessCalendarReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdateBusinessCalendar(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *calendarAPI) DeleteBusinessCalendar(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteBusinessCalendar(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *calendarAPI) AddHoliday(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.HolidayReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.AddHoliday(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *calendarAPI) ImportHolidays(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, model.HolidayImportMaxBytes)
+
+	resp, statusCode := handler.ImportHolidays(id, body, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *calendarAPI) DeleteHoliday(c *gin.Context, id uint64, holidayID uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteHoliday(id, holidayID, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *calendarAPI) FetchBusinessDue(c *gin.Context, id uint64, params calendar_gen.FetchBusinessDueParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	from := time.Time{}
+	if params.From != nil {
+		from = *params.From
+	}
+
+	resp, statusCode := handler.GetBusinessDue(id, from, params.Minutes)
+
+	renderResponse(c, resp, statusCode)
+}
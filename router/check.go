@@ -0,0 +1,112 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	check_gen "github.com/Dhar01/incident_resp/router/checks"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type checkAPI struct{}
+
+var _ check_gen.ServerInterface = (*checkAPI)(nil)
+
+func newCheckAPI() *checkAPI {
+	return &checkAPI{}
+}
+
+func (api *checkAPI) FetchChecks(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetChecks()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *checkAPI) FetchCheck(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetCheck(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *checkAPI) CreateCheck(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.CheckReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateCheck(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *checkAPI) UpdateCheck(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.CheckReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdateCheck(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *checkAPI) DeleteCheck(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteCheck(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *checkAPI) FetchCheckResults(c *gin.Context, id uint64, params check_gen.FetchCheckResultsParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	limit := 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	resp, statusCode := handler.GetCheckResults(id, limit)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *checkAPI) RunCheck(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.RunCheckNow(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
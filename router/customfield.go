@@ -0,0 +1,76 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	customfield_gen "github.com/Dhar01/incident_resp/router/customfields"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type customFieldAPI struct{}
+
+var _ customfield_gen.ServerInterface = (*customFieldAPI)(nil)
+
+func newCustomFieldAPI() *customFieldAPI {
+	return &customFieldAPI{}
+}
+
+func (api *customFieldAPI) FetchCustomFields(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetCustomFields()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *customFieldAPI) CreateCustomField(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.CustomFieldReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateCustomField(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *customFieldAPI) UpdateCustomField(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.CustomFieldReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdateCustomField(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *customFieldAPI) DeleteCustomField(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteCustomField(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
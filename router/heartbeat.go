@@ -0,0 +1,113 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	heartbeat_gen "github.com/Dhar01/incident_resp/router/heartbeats"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type heartbeatAPI struct{}
+
+var _ heartbeat_gen.ServerInterface = (*heartbeatAPI)(nil)
+
+func newHeartbeatAPI() *heartbeatAPI {
+	return &heartbeatAPI{}
+}
+
+func (api *heartbeatAPI) FetchHeartbeats(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetHeartbeats()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *heartbeatAPI) FetchHeartbeat(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetHeartbeat(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *heartbeatAPI) CreateHeartbeat(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.HeartbeatReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateHeartbeat(req, authID)
+
+	renderResponse(c, withPingURL(resp), statusCode)
+}
+
+func (api *heartbeatAPI) UpdateHeartbeat(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.HeartbeatReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdateHeartbeat(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *heartbeatAPI) DeleteHeartbeat(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteHeartbeat(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *heartbeatAPI) RotateHeartbeatToken(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.RotateHeartbeatToken(id, authID)
+
+	renderResponse(c, withPingURL(resp), statusCode)
+}
+
+// PingHeartbeat is public, jobs authenticate with the heartbeat secret
+func (api *heartbeatAPI) PingHeartbeat(c *gin.Context, token string) {
+	resp, statusCode := handler.PingHeartbeat(token)
+
+	renderResponse(c, resp, statusCode)
+}
+
+// withPingURL sets the ping URL of a heartbeat with a newly issued secret
+func withPingURL(resp model.HTTPResponse) model.HTTPResponse {
+	if heartbeat, ok := resp.Message.(model.Heartbeat); ok && heartbeat.Token != "" {
+		heartbeat.PingURL = base + "/heartbeats/" + heartbeat.Token + "/ping"
+		resp.Message = heartbeat
+	}
+	return resp
+}
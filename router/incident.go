@@ -2,12 +2,16 @@ package router
 
 import (
 	"net/http"
+	"path"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/Dhar01/incident_resp/handler"
 	"github.com/Dhar01/incident_resp/internal/model"
 	"github.com/pilinux/gorest/lib/renderer"
 	incident_gen "github.com/Dhar01/incident_resp/router/incidents"
+	"github.com/Dhar01/incident_resp/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,7 +23,7 @@ func newIncidentAPI() *incidentAPI {
 	return &incidentAPI{}
 }
 
-func (api *incidentAPI) FetchIncidents(c *gin.Context) {
+func (api *incidentAPI) FetchIncidents(c *gin.Context, params incident_gen.FetchIncidentsParams) {
 	authIDRaw, ok := c.Get("authID")
 	if !ok {
 		renderer.Render(c, gin.H{"message": "authID not found in context"}, http.StatusUnauthorized)
@@ -31,9 +35,18 @@ func (api *incidentAPI) FetchIncidents(c *gin.Context) {
 		renderer.Render(c, gin.H{"message": "invalid authID type in context"}, http.StatusUnauthorized)
 	}
 
-	// ! need to handle cases for pagination and query params for filtering
+	// ! need to handle cases for pagination
 
-	resp, statusCode := handler.GetAllIncidents()
+	filter, msg := incidentFilter(params.Service, params.Team, params.Status, params.Tag, params.Label, params.Field)
+	if msg != "" {
+		renderer.Render(c, gin.H{"message": msg}, http.StatusBadRequest)
+		return
+	}
+	if params.Sort != nil {
+		filter.Sort = string(*params.Sort)
+	}
+
+	resp, statusCode := handler.GetAllIncidents(filter)
 
 	if reflect.TypeOf(resp.Message).Kind() == reflect.String {
 		renderer.Render(c, resp, statusCode)
@@ -43,6 +56,63 @@ func (api *incidentAPI) FetchIncidents(c *gin.Context) {
 	renderer.Render(c, resp.Message, statusCode)
 }
 
+// incidentFilter builds the list filters shared by the list and the export
+func incidentFilter(service, team *uint64, status *incident_gen.StatusType, tags, labels, fields *[]string) (filter model.IncidentFilter, msg string) {
+	if service != nil {
+		filter.ServiceID = *service
+	}
+	if team != nil {
+		filter.TeamID = *team
+	}
+	if status != nil {
+		filter.Status = model.StatusType(*status)
+	}
+	if tags != nil {
+		filter.Tags = *tags
+	}
+	if labels != nil {
+		filter.Labels = *labels
+	}
+	if fields != nil {
+		filter.CustomFields = map[string]string{}
+		for _, field := range *fields {
+			key, value, ok := strings.Cut(field, ":")
+			if !ok {
+				return filter, "custom field filters must be key:value"
+			}
+			filter.CustomFields[key] = value
+		}
+	}
+	return filter, ""
+}
+
+func (api *incidentAPI) FetchPriorityMatrix(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetPriorityMatrix()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *incidentAPI) FetchOpenAPISpec(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	spec, err := incident_gen.GetSwagger()
+	if err != nil {
+		_ = c.Error(err)
+		renderer.Render(c, gin.H{"message": "internal server error"}, http.StatusInternalServerError)
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentOpenAPI(spec)
+
+	renderResponse(c, resp, statusCode)
+}
+
 func (api *incidentAPI) FetchIncidentByID(c *gin.Context, id uint64) {
 	authIDRaw, ok := c.Get("authID")
 	if !ok {
@@ -57,6 +127,11 @@ func (api *incidentAPI) FetchIncidentByID(c *gin.Context, id uint64) {
 
 	resp, statusCode := handler.GetIncidentByID(id)
 
+	// merged duplicates point at the incident they were merged into
+	if incident, ok := resp.Message.(model.Incident); ok && incident.DuplicateOf != nil {
+		c.Header("Location", path.Join(path.Dir(c.Request.URL.Path), strconv.FormatUint(*incident.DuplicateOf, 10)))
+	}
+
 	if reflect.TypeOf(resp.Message).Kind() == reflect.String {
 		renderer.Render(c, resp, statusCode)
 		return
@@ -101,7 +176,7 @@ func (api *incidentAPI) UpdateIncident(c *gin.Context, id uint64) {
 		return
 	}
 
-	_, ok = authIDRaw.(uint64)
+	authID, ok := authIDRaw.(uint64)
 	if !ok {
 		renderer.Render(c, gin.H{"message": "invalid authID type in context"}, http.StatusUnauthorized)
 	}
@@ -111,8 +186,9 @@ func (api *incidentAPI) UpdateIncident(c *gin.Context, id uint64) {
 	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
 		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
 	}
+	req.IncidentID = id
 
-	resp, statusCode := handler.UpdateIncident(req, id)
+	resp, statusCode := handler.UpdateIncident(req, id, authID)
 
 	if reflect.TypeOf(resp.Message).Kind() == reflect.String {
 		renderer.Render(c, resp, statusCode)
@@ -121,3 +197,158 @@ func (api *incidentAPI) UpdateIncident(c *gin.Context, id uint64) {
 
 	renderer.Render(c, resp.Message, statusCode)
 }
+
+func (api *incidentAPI) BulkUpdateIncidents(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.BulkReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.BulkUpdateIncidents(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *incidentAPI) MergeIncident(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.MergeReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.MergeIncident(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *incidentAPI) FetchIncidentLinks(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentLinks(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *incidentAPI) CreateIncidentLink(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.LinkReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateIncidentLink(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *incidentAPI) DeleteIncidentLink(c *gin.Context, id uint64, linkID uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteIncidentLink(id, linkID, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *incidentAPI) FetchIncidentBlastRadius(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentBlastRadius(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *incidentAPI) FetchIncidentTimeline(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentTimeline(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *incidentAPI) ExportIncidents(c *gin.Context, params incident_gen.ExportIncidentsParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	filter, msg := incidentFilter(params.Service, params.Team, params.Status, params.Tag, params.Label, params.Field)
+	if msg != "" {
+		renderer.Render(c, gin.H{"message": msg}, http.StatusBadRequest)
+		return
+	}
+
+	format, columns := "", ""
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+	if params.Columns != nil {
+		columns = *params.Columns
+	}
+
+	export, resp, statusCode := handler.PrepareIncidentExport(filter, format, columns)
+	if export == nil {
+		renderResponse(c, resp, statusCode)
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType())
+	c.Header("Content-Disposition", `attachment; filename="incidents.`+export.Format+`"`)
+	c.Status(http.StatusOK)
+
+	// headers are sent, a failure can only truncate the stream
+	if err := export.Write(c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+func (api *incidentAPI) ImportIncidents(c *gin.Context, params incident_gen.ImportIncidentsParams) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	format := service.ImportJSON
+	if c.ContentType() == "text/csv" {
+		format = service.ImportCSV
+	}
+
+	mapping := ""
+	if params.Mapping != nil {
+		mapping = *params.Mapping
+	}
+
+	dryRun := params.DryRun != nil && *params.DryRun
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, model.ImportMaxBytes)
+
+	resp, statusCode := handler.ImportIncidents(body, format, mapping, dryRun, authID)
+
+	renderResponse(c, resp, statusCode)
+}
@@ -23,12 +23,35 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for LevelType.
+const (
+	LevelTypeHigh   LevelType = "high"
+	LevelTypeLow    LevelType = "low"
+	LevelTypeMedium LevelType = "medium"
+)
+
+// Defines values for LinkType.
+const (
+	CausedBy   LinkType = "caused-by"
+	Duplicates LinkType = "duplicates"
+	RelatedTo  LinkType = "related-to"
+)
+
+// Defines values for PriorityType.
+const (
+	P1 PriorityType = "P1"
+	P2 PriorityType = "P2"
+	P3 PriorityType = "P3"
+	P4 PriorityType = "P4"
+	P5 PriorityType = "P5"
+)
+
 // Defines values for SeverityType.
 const (
-	Critical SeverityType = "critical"
-	High     SeverityType = "high"
-	Low      SeverityType = "low"
-	Medium   SeverityType = "medium"
+	SeverityTypeCritical SeverityType = "critical"
+	SeverityTypeHigh     SeverityType = "high"
+	SeverityTypeLow      SeverityType = "low"
+	SeverityTypeMedium   SeverityType = "medium"
 )
 
 // Defines values for StatusType.
@@ -38,35 +61,191 @@ const (
 	Open         StatusType = "open"
 )
 
+// Defines values for FetchIncidentsParamsSort.
+const (
+	Created       FetchIncidentsParamsSort = "created"
+	MinusCreated  FetchIncidentsParamsSort = "-created"
+	MinusPriority FetchIncidentsParamsSort = "-priority"
+	Priority      FetchIncidentsParamsSort = "priority"
+)
+
+// Defines values for ExportIncidentsParamsFormat.
+const (
+	Csv   ExportIncidentsParamsFormat = "csv"
+	Jsonl ExportIncidentsParamsFormat = "jsonl"
+)
+
+// BulkRequest defines model for BulkRequest.
+type BulkRequest = models.BulkReq
+
+// BulkResult defines model for BulkResult.
+type BulkResult = models.BulkResult
+
+// ImportResult defines model for ImportResult.
+type ImportResult = models.ImportResult
+
 // Incident defines model for Incident.
 type Incident = models.IncidentReq
 
+// IncidentEvent defines model for IncidentEvent.
+type IncidentEvent = models.IncidentEvent
+
+// IncidentLink defines model for IncidentLink.
+type IncidentLink = models.IncidentLink
+
+// LevelType defines model for LevelType.
+type LevelType string
+
+// LinkRequest defines model for LinkRequest.
+type LinkRequest = models.LinkReq
+
+// LinkType defines model for LinkType.
+type LinkType string
+
+// MergeRequest defines model for MergeRequest.
+type MergeRequest = models.MergeReq
+
+// PriorityMatrix defines model for PriorityMatrix.
+type PriorityMatrix = models.PriorityMatrix
+
+// PriorityType defines model for PriorityType.
+type PriorityType string
+
 // SeverityType defines model for SeverityType.
 type SeverityType string
 
 // StatusType defines model for StatusType.
 type StatusType string
 
+// FetchIncidentsParams defines parameters for FetchIncidents.
+type FetchIncidentsParams struct {
+	// Service only incidents impacting this service
+	Service *uint64 `form:"service,omitempty" json:"service,omitempty"`
+
+	// Team only incidents owned by this team
+	Team   *uint64     `form:"team,omitempty" json:"team,omitempty"`
+	Status *StatusType `form:"status,omitempty" json:"status,omitempty"`
+
+	// Tag only incidents with every given tag
+	Tag *[]string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Label only incidents with every given label, key:value or key for any value
+	Label *[]string `form:"label,omitempty" json:"label,omitempty"`
+
+	// Field only incidents with every given custom field value, key:value
+	Field *[]string `form:"field,omitempty" json:"field,omitempty"`
+
+	// Sort list order, incidents without a priority come last when sorting by priority
+	Sort *FetchIncidentsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// FetchIncidentsParamsSort defines parameters for FetchIncidents.
+type FetchIncidentsParamsSort string
+
+// ExportIncidentsParams defines parameters for ExportIncidents.
+type ExportIncidentsParams struct {
+	Format *ExportIncidentsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Columns comma separated columns to export, all when absent
+	Columns *string `form:"columns,omitempty" json:"columns,omitempty"`
+
+	// Service only incidents impacting this service
+	Service *uint64 `form:"service,omitempty" json:"service,omitempty"`
+
+	// Team only incidents owned by this team
+	Team   *uint64     `form:"team,omitempty" json:"team,omitempty"`
+	Status *StatusType `form:"status,omitempty" json:"status,omitempty"`
+
+	// Tag only incidents with every given tag
+	Tag *[]string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Label only incidents with every given label, key:value or key for any value
+	Label *[]string `form:"label,omitempty" json:"label,omitempty"`
+
+	// Field only incidents with every given custom field value, key:value
+	Field *[]string `form:"field,omitempty" json:"field,omitempty"`
+}
+
+// ExportIncidentsParamsFormat defines parameters for ExportIncidents.
+type ExportIncidentsParamsFormat string
+
+// ImportIncidentsJSONBody defines parameters for ImportIncidents.
+type ImportIncidentsJSONBody = []map[string]interface{}
+
+// ImportIncidentsParams defines parameters for ImportIncidents.
+type ImportIncidentsParams struct {
+	// Mapping comma separated source:field pairs to rename columns
+	Mapping *string `form:"mapping,omitempty" json:"mapping,omitempty"`
+
+	// DryRun only validate the rows
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
 // CreateNewIncidentJSONRequestBody defines body for CreateNewIncident for application/json ContentType.
 type CreateNewIncidentJSONRequestBody = Incident
 
+// BulkUpdateIncidentsJSONRequestBody defines body for BulkUpdateIncidents for application/json ContentType.
+type BulkUpdateIncidentsJSONRequestBody = BulkRequest
+
+// ImportIncidentsJSONRequestBody defines body for ImportIncidents for application/json ContentType.
+type ImportIncidentsJSONRequestBody = ImportIncidentsJSONBody
+
 // UpdateIncidentJSONRequestBody defines body for UpdateIncident for application/json ContentType.
 type UpdateIncidentJSONRequestBody = Incident
 
+// CreateIncidentLinkJSONRequestBody defines body for CreateIncidentLink for application/json ContentType.
+type CreateIncidentLinkJSONRequestBody = LinkRequest
+
+// MergeIncidentJSONRequestBody defines body for MergeIncident for application/json ContentType.
+type MergeIncidentJSONRequestBody = MergeRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// get all incidents
 	// (GET /incidents)
-	FetchIncidents(c *gin.Context)
+	FetchIncidents(c *gin.Context, params FetchIncidentsParams)
 	// Create a new incident
 	// (POST /incidents)
 	CreateNewIncident(c *gin.Context)
+	// bulk change incidents
+	// (POST /incidents/bulk)
+	BulkUpdateIncidents(c *gin.Context)
+	// export incidents
+	// (GET /incidents/export)
+	ExportIncidents(c *gin.Context, params ExportIncidentsParams)
+	// import incidents
+	// (POST /incidents/import)
+	ImportIncidents(c *gin.Context, params ImportIncidentsParams)
 	// get one incident
 	// (GET /incidents/{id})
 	FetchIncidentByID(c *gin.Context, id uint64)
 	// Update an incident
 	// (PUT /incidents/{id})
 	UpdateIncident(c *gin.Context, id uint64)
+	// services affected by an incident
+	// (GET /incidents/{id}/blast-radius)
+	FetchIncidentBlastRadius(c *gin.Context, id uint64)
+	// get incident links
+	// (GET /incidents/{id}/links)
+	FetchIncidentLinks(c *gin.Context, id uint64)
+	// link incidents
+	// (POST /incidents/{id}/links)
+	CreateIncidentLink(c *gin.Context, id uint64)
+	// remove an incident link
+	// (DELETE /incidents/{id}/links/{linkID})
+	DeleteIncidentLink(c *gin.Context, id uint64, linkID uint64)
+	// merge a duplicate incident
+	// (POST /incidents/{id}/merge)
+	MergeIncident(c *gin.Context, id uint64)
+	// timeline of an incident
+	// (GET /incidents/{id}/timeline)
+	FetchIncidentTimeline(c *gin.Context, id uint64)
+	// API specification
+	// (GET /openapi.json)
+	FetchOpenAPISpec(c *gin.Context)
+	// priority matrix
+	// (GET /priorities)
+	FetchPriorityMatrix(c *gin.Context)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -81,8 +260,69 @@ type MiddlewareFunc func(c *gin.Context)
 // FetchIncidents operation middleware
 func (siw *ServerInterfaceWrapper) FetchIncidents(c *gin.Context) {
 
+	var err error
+
 	c.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params FetchIncidentsParams
+
+	// ------------- Optional query parameter "service" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service", c.Request.URL.Query(), &params.Service)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter service: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "team" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team", c.Request.URL.Query(), &params.Team)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter team: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", c.Request.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tag: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "label" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "label", c.Request.URL.Query(), &params.Label)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter label: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "field" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "field", c.Request.URL.Query(), &params.Field)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter field: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -90,7 +330,7 @@ func (siw *ServerInterfaceWrapper) FetchIncidents(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.FetchIncidents(c)
+	siw.Handler.FetchIncidents(c, params)
 }
 
 // CreateNewIncident operation middleware
@@ -108,6 +348,141 @@ func (siw *ServerInterfaceWrapper) CreateNewIncident(c *gin.Context) {
 	siw.Handler.CreateNewIncident(c)
 }
 
+// BulkUpdateIncidents operation middleware
+func (siw *ServerInterfaceWrapper) BulkUpdateIncidents(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.BulkUpdateIncidents(c)
+}
+
+// ExportIncidents operation middleware
+func (siw *ServerInterfaceWrapper) ExportIncidents(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportIncidentsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "columns" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "columns", c.Request.URL.Query(), &params.Columns)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter columns: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "service" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service", c.Request.URL.Query(), &params.Service)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter service: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "team" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team", c.Request.URL.Query(), &params.Team)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter team: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", c.Request.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tag: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "label" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "label", c.Request.URL.Query(), &params.Label)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter label: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "field" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "field", c.Request.URL.Query(), &params.Field)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter field: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ExportIncidents(c, params)
+}
+
+// ImportIncidents operation middleware
+func (siw *ServerInterfaceWrapper) ImportIncidents(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportIncidentsParams
+
+	// ------------- Optional query parameter "mapping" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mapping", c.Request.URL.Query(), &params.Mapping)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter mapping: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", c.Request.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dryRun: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportIncidents(c, params)
+}
+
 // FetchIncidentByID operation middleware
 func (siw *ServerInterfaceWrapper) FetchIncidentByID(c *gin.Context) {
 
@@ -160,6 +535,201 @@ func (siw *ServerInterfaceWrapper) UpdateIncident(c *gin.Context) {
 	siw.Handler.UpdateIncident(c, id)
 }
 
+// FetchIncidentBlastRadius operation middleware
+func (siw *ServerInterfaceWrapper) FetchIncidentBlastRadius(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id uint64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.FetchIncidentBlastRadius(c, id)
+}
+
+// FetchIncidentLinks operation middleware
+func (siw *ServerInterfaceWrapper) FetchIncidentLinks(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id uint64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.FetchIncidentLinks(c, id)
+}
+
+// CreateIncidentLink operation middleware
+func (siw *ServerInterfaceWrapper) CreateIncidentLink(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id uint64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateIncidentLink(c, id)
+}
+
+// DeleteIncidentLink operation middleware
+func (siw *ServerInterfaceWrapper) DeleteIncidentLink(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id uint64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "linkID" -------------
+	var linkID uint64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "linkID", c.Param("linkID"), &linkID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter linkID: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteIncidentLink(c, id, linkID)
+}
+
+// MergeIncident operation middleware
+func (siw *ServerInterfaceWrapper) MergeIncident(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id uint64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.MergeIncident(c, id)
+}
+
+// FetchIncidentTimeline operation middleware
+func (siw *ServerInterfaceWrapper) FetchIncidentTimeline(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id uint64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.FetchIncidentTimeline(c, id)
+}
+
+// FetchOpenAPISpec operation middleware
+func (siw *ServerInterfaceWrapper) FetchOpenAPISpec(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.FetchOpenAPISpec(c)
+}
+
+// FetchPriorityMatrix operation middleware
+func (siw *ServerInterfaceWrapper) FetchPriorityMatrix(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.FetchPriorityMatrix(c)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -189,31 +759,81 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 
 	router.GET(options.BaseURL+"/incidents", wrapper.FetchIncidents)
 	router.POST(options.BaseURL+"/incidents", wrapper.CreateNewIncident)
+	router.POST(options.BaseURL+"/incidents/bulk", wrapper.BulkUpdateIncidents)
+	router.GET(options.BaseURL+"/incidents/export", wrapper.ExportIncidents)
+	router.POST(options.BaseURL+"/incidents/import", wrapper.ImportIncidents)
 	router.GET(options.BaseURL+"/incidents/:id", wrapper.FetchIncidentByID)
 	router.PUT(options.BaseURL+"/incidents/:id", wrapper.UpdateIncident)
+	router.GET(options.BaseURL+"/incidents/:id/blast-radius", wrapper.FetchIncidentBlastRadius)
+	router.GET(options.BaseURL+"/incidents/:id/links", wrapper.FetchIncidentLinks)
+	router.POST(options.BaseURL+"/incidents/:id/links", wrapper.CreateIncidentLink)
+	router.DELETE(options.BaseURL+"/incidents/:id/links/:linkID", wrapper.DeleteIncidentLink)
+	router.POST(options.BaseURL+"/incidents/:id/merge", wrapper.MergeIncident)
+	router.GET(options.BaseURL+"/incidents/:id/timeline", wrapper.FetchIncidentTimeline)
+	router.GET(options.BaseURL+"/openapi.json", wrapper.FetchOpenAPISpec)
+	router.GET(options.BaseURL+"/priorities", wrapper.FetchPriorityMatrix)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RW227jNhD9FWLaR8ay27To6i2XBnDRXSxyQR+CYEGTY4lbidSSQ3vdQP9ekJJtqbGT",
-	"tAV22yfrMnM8M+fMER9B2rqxBg15yB/BoW+s8ZhuzoW6xk8BPf3snHXxkUIvnW5IWwM5zM1KVFoxbZpA",
-	"nC2EYq5LgJbDhTXLSstjydvXbK2pZFQik8E5NMQ8uhU65kkQRqC5IXRGVDfp+dFauqBtNqawlsM7S1c2",
-	"GHUk7xq9DU4iM5bYMgbGpDsjApXW6T9QnUmJ3h9JHwYykSKhbTl4WWIt0hjnRmqFhuJ142yDjnQ3YOG9",
-	"LgyqD2TjLX4WdVMh5LPpjMPSuloQ5BC0oR9PgQNtGoQctCEsMPU2qmUAALclssZZFWR8t52J9iwYh0KW",
-	"YlHhHtGT06aIgB5X6DRtItq3DpeQwzfZXiJZ31Z208fdRoCYR4KCfzErRW1zSFOsdVh2xzC7tGvztLqW",
-	"Q1SXdqggv+/Td389qJ2PBvuwA7KLjygJOHw+KexJ/7C2Cis/2ZJ0jZ+GASe6bqxL1BlR7+OBQyOohBwK",
-	"TWVYTKSts8tSuOks0z3Uh7hMme51maXE1MVoeLF/E+rYUWXXwKFGpUMNHEpdlMBBOk1aimrQyJ6vwUQH",
-	"QLbBOD8hfzd2XaEqUEWgynpUB2AS7zLEkm4iU/32o3DozkLs8hEW6e5qq8lffruFXuQRqXu7p6wkaqCN",
-	"wNos7dOtOXs/Z0vr2HZUjKJncKi0RONxMO6389uBVna7xN4KIwqs4+XZ+zlwWKHzHfh0Mp3MEolWNPpE",
-	"WoUFmo7OWjSNNkVqMAStxgQW1hYVZvHF5O5ufplGE4cpGg05fD+ZTqY98wlhR3W6K5CedlppT0xUFRMr",
-	"oau4dmyflMCdiKHzWMoVkizng9cjO/5uOo0/0hrq3UQ0TaVlys8++s4Dul2LV5qwfnEld+bU7sgTzolN",
-	"x964l19jL3Y5aKDlcDqdHfuPXfXZMTttOfzQdfV8/qEvwFC2kN+PBXv/0D5w8KGuhdtEkrFjYTh7ElEH",
-	"97B9Bg8th8b6AyxKh4KQCWZwvRdt+nDVSEIJEk/IvEg573C9G3HnX+jp3KrN36LydQyOHZJcwPaJhGaH",
-	"vpx9P12XivmQKFqGqtp0FL+Cor8eFv430rg4xO1hebR8sPLZo1bt0b1fxlVm1uzXnS02TJNnWj2/9+eb",
-	"+WUyGSdqJHQ+daAjaPrk8K01JqAx33ygmZfPD3EO/8BhXilGfkxmu0PWv9XH6fT05fzx8e+LGs6Q/aN+",
-	"E9Kcx3q4a5QgHPjGFxPD17an6TP2FNJU1Nd0pP+y4jrRMGH2mms7gAjYCSe4qj+d5VlWWSmq0nrKf3rz",
-	"5k0mGp2tZtA+tH8GAAD//ycq+pEVDgAA",
+	"H4sIAAAAAAAC/+w8WXPbtrp/BYN7H9oZWpKT9E6jN2ebUcdpPXFyz0OOxwMRnyTUJMACoBQdj/77mQ8A",
+	"N4mUKDeWmzYviUhi+fYV8D2NVZopCdIaOr6nGkympAH38IrxD/BHDsa+1VppfMXBxFpkVihJx3QilywR",
+	"nAiZ5TYiU8aJ9hPoJqKvlZwlIu6aXHwmK2EXxC6AxLnWIC0xoJegibHMAi70Tump4Bxkx0q/KktYkqgV",
+	"cDJTurFWbkDjGhNpQUuWXLu1O/HxgwoIwA3bRLjDO5VL3jHvAxiV6xiIVJbMcCBO+iRZbhdKi/8Av4hj",
+	"MKZjen0gYW4k3WwiauIFpMyzIk/uAi/wMdMqA22F5xOL/UL3FGSe0vFnasDeIvlyQyP/AEvQwq5pRJkx",
+	"Yi7xB+e3ls1pRFPQc6A3EbXrDOiYGquFnNNNMRj4R4XLz5ROmaVjmgtp/+8FLScIaWHuCT0TiYUWJHMD",
+	"KCjGAuNEzYiQseAg7eSNiYgE4IYwSxJgxhIlgcRaWNA4N9pCF5kjYugLUKDD+J7+r4YZHdP/GVYyPww0",
+	"Hl67UR9x9iaiFljab/1N+UpNf4fYCX4NNVxEWEhNX2jDG6Y1WzvoC8Ydgj+MKzB4ENZsjhPgC0uzBKEA",
+	"uRxnWnHaIhmW6TnYyZtdThfoE6uIkywipFU06kdNNCBCA0cxDoJ9s03jiH45m6uz8DJVHBIzCCpS/3gm",
+	"0kxppzGSpdVYFClmF3RM58Iu8ukgVunwzYLp0fmwgP4WTeFQBIswdBMdgH4fkyd7NXFXk7IsEcBr36ZK",
+	"JcAkfowXTM4bH+saxUTS9a2UrfJHE6BKFPsKYArGsDm04qBLtAtDUwAe0VxWvwPEuwalTVu2Jd4qy5J2",
+	"bKs9Wj5vjpASh8ZjC8rErdklKlyvP+SyXRyc39nH1pmAhLeyaC/71KoX4VqY4unTJYV7WOYChIezq0HD",
+	"R2dYGNCi18EN3lrVsJDno/Ool1bFubEqvXV88ytyLtBWsOSqtpPVOURb5pTxVEhhrGZWacJhJiTGOW4l",
+	"Ml2TO1hHxJGZWYwf5kxIY8nQb3kWBjKJsVmWsBg4UZLkGQ4nqwVIkmkwIO2ADFUGkmVi8LtRkiTCWNMI",
+	"pvxag39L2iIyDajrXuTjAgh6kdwZxyK2EobkUgOLF2yaQJuHEWnGYnvIhV3CEpLCg2VaqD7e8iqMK+aF",
+	"kOJWeOZs+TMHB3ASRhkafVM+vQUlfOtE4g7W4yVLciAJm0JiokNCQqOKs58pZ5ZNmUH2lbHCTY06u1HD",
+	"trEHlt4KvguhWkkh5wS/RwS4sCglRMlkjTIvrCEppFPQZkAuSkDvADIvsTjPw61SYS3wiIyIhlQtwRBh",
+	"aT+ttcIm0BRmnz+QN2ol22Q213OQ8foIod0KefyWJa9rwhI1rFC/mKiwaKeIi4q93i7bTWhuF/2jkFgD",
+	"WrML25iATD6zIm01F7A8Ks75upGRf1EXFK0SuF0wydVsRg8HQnvZ50l6KgZeCnm3y78HcCRMebV+PJ4k",
+	"Qt4dNxp4//EFV/eqspB3pSYfwVOc9+gsrexMLWZfiPnCJf1c5CmNaKJWrbk/QthZc3h0UtaNYrlZWKef",
+	"8QvwPz6RC7BrNOZ5loiYWRcsxAyLH2dTtOEaEtSJM6taaf4e8+VOotdz7qOT6XJyP+oVkDw6+YpY7D2z",
+	"WnzZRTot33fluKeLEx/k3g9nvI4z+/K9h8Krwagkt0LJa4iV5PWgTOYYP4VRrujbPeYwFv2M3xavTyVa",
+	"29p5dU4jevUM/3mO/6D2XP3UqpCNGLy2BBrNmg0NNjXWwoqYJe1LVYF5bSFMuWhEWXwn1SoB7isocaJM",
+	"VwXFQJwjSNfI9FCpB6ZBX+RIqHs6dU/vCgPxy78+0lBMdnUG97WyFwtrM7rZOPc7U7uR+MXVxFXVq7Ie",
+	"GGRvImKQBmocez/5WIuay3iCvGeSzSHFnxdXExrRJWjjFx8NRoNzJweKZeIsVhzmIL1EpCzLhPTpS54L",
+	"3pSBuVLzBIb4YfDp0+SNI03IX+mYPh+MBqMgPG6FUlrc0xzsLqaY8GIjgbAlE4lLOKpJbnHNcOgEQXkH",
+	"Nl5Map8zplkKFrSh48/bS7vEpVyMeJPlEpyFMEViSZEFdEz/yEGjrwhkrb56Ne9r/Q/AoFYSOCZTDgRX",
+	"8W7fP3w6dvNWVMqsplysbyp7EB/XRkJtXZO5WIIkvrfRipH7UsHQltEekcYeD5pLtqNa9q00Pjg9Y3JN",
+	"3MsO4N3cLvBrFXsN81A8fzw8fI3J14U80DWkOuB3g7vg9wuCvhV8fPH6/duzF8/+HAZOp5XmoKMtRFRu",
+	"CSOFZyWxSrEIYqwvGxilnX5O1+WQLvVU2jbRCZa9Nu+s9jtkRfi2+Nli5m+iZi/22WiE/8VK2pBeu4ZC",
+	"7AzSEOt1+K4CoqTYPv0qC567hNwu6NFLR8iqb2dw0ovRedceJfTDrj7oJqI/jUaH57e1but+0Jnbugf8",
+	"fIPkM3maMr1GrwHerNeNua+LfS5TTnqDYaEyLW7Bc4kwImFVeUGnCylYhhZjxzu8dnN+hVVJYh+Mg7Gv",
+	"FF8fxcp+HGyG+1bnsNkRofO2lnfAJ8giMblj0SxPkrVncQ8WbZ8U+Aqi8WL0/PD8rZMBJ5Oo120i0S5V",
+	"m6gWegyneeJrK62ShnKwdt1v30jEDipWM9GIue556bs1fvKWuHhLUmbjhY8pgPg2PPmBWZIqY8n5aDT6",
+	"cUAu6mpAmAYSLyC+cw0FjdGP5G6677P5Ec4JrLSwFiQaUQeg1UwaD2XkLabE12qG01PiO4AD8pbFi7BY",
+	"BT/BbIcwYkUKiZBAQFq99m2Fphpht+6Tq+3WI63HUKT6AYteujT6ylu7DleL3X1dcCL0j59UJV8cnt88",
+	"KoOznj07Eamu0YVX0u1lMCJSWacWQQxPZyVQ2cOuh3xP00rAlyIXbs1TjNXY3kA9rbBtaL+LerwJMIQZ",
+	"8vr6/8kPH969Ji/Ofx79iObjl+vffiWXQoKJiEo4uPHa2B0VfOtg2ZPotMZ4PkOoR0UcZsw1wmlsljQq",
+	"oyT/hGKQtKa7O95YpSkjBhAKNIqxSvJUGmcPHaiR8/XOJLFpaFm1wRgmtkeiJW9uBY9cQhv53CUq+jFR",
+	"cJi3Ds+DYH9PAL8ngN8TwMdPAI/Lm76cSb7rgVp6dfDFDtFS7R2345C87axHbk/pvk/j9LwRPs7fVbXf",
+	"9rg4UYyThTBWaayu1n28Vilhzr9xFedpmZkxsgDGQRMXYKJWeZfn5ATDVF+mNj4k1mrlY93qHE0VD3sd",
+	"CAegvGPxKqDVigjj5wzIOxRrMybeW5AaBhEJvoOUzoPUevnlA9xCykQS+VxM6eIxHJKISOVyonDkwf+u",
+	"F4/dC1f3X7qHlqh6kh5w6fs9rj/rPPY6nzGhnfPVgOpNKq/apvqhpNvhc6+9EI09BX9bSdDjLdL8loEE",
+	"Pn6A7y046+Ij5HcHiOFcXrSr5+UBPW9kHpaDlIZs3wGwHr2WvgbpdGlM46heizF8gxqTSx+eNRUOUQzV",
+	"iZOAMqnHYU6tnzStOn/edqXAbUKmiq+JVYok2CT82vnUYUI5/jh2VYlUnWincSp+x+Ocyr3gm84Uaobd",
+	"G1fIKCYUx8oE39/qebV25xDakiDXqCwNieB0W/2OjKEfVgPuWS7s0onq/srzvfXCFTP+dD+PyKXyMJBM",
+	"CVdXso3klIj6cH8b4MnKEacrOdelq7PinDs+NuWtWfE6pbA9dYF6tEfgQsDzDdakH1g3G73spX5OnwhD",
+	"4wwahe50Uu4FlTBZyXmLER5Osa12phkXeXfzfeeUtYu9faAdXvmis7BiCcmacMhAcvRHSrqi8wG7jUB8",
+	"8DA8qfluou3AIp42oXpeI+bf20hWnJ7NwPF+um4IU08vP8TzgQeOdfiqqLwrUkbJiVVbm+2Rnku3w1/Z",
+	"7T+w9YuI9Wn/FuM9Ef8J7ls0MT7QMm5r/24d9f2GvXj9KHD/TvNXDSC8oLadS5B3RWHknxYb7J/VvH9/",
+	"Mt1BdTk+U/M2fHjvD/RvvBVPwMKucr1x759AuaLWdT3EJwkWnKz7W0z8m5PZ00ifp07dsTv73VsMXUzd",
+	"XYl2i7sLZuHsQkQsM3fu3hziwEGbiLTHsj78CEc6/JnvEsgIX0rijv36flwJPjOEkfI+QREj+vktxV13",
+	"bv/vkTs2LkOcuprZyFubQvA+lD002FxL08bP757oL+CJnC43lOfozKJQ887kwiWoNhy8YJYsWOZaFIWe",
+	"Vwq+95RFI9n4WGz6N8w3/FXGHglHQYR/WlJsa3j3yoXrd/Y7xbRRjsUbHSaDWMwCU6Pdv8FUb+yjM3If",
+	"yyQwML9VirFFd3E1uc4gpn9Ssrb6YLtCEvYqe7/fTpt8hwfdDA5nx8NVsFb2LtQqBB0u0gjX1EjKMrRD",
+	"V+dnVz9F5dnO68uL4K5cxQnwiGbtfHoLS3eubT2a293aqYXlBaTE3wt0WNUw+vMScAwXt4Dp4GH4AxOF",
+	"Ec91Eq5djYfDRMUsWShjxz+/fPlyyDIxXJ7Tzc3mvwMAYEg8oZpNAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,86 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	indicator_gen "github.com/Dhar01/incident_resp/router/indicators"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type indicatorAPI struct{}
+
+var _ indicator_gen.ServerInterface = (*indicatorAPI)(nil)
+
+func newIndicatorAPI() *indicatorAPI {
+	return &indicatorAPI{}
+}
+
+func (api *indicatorAPI) FetchIncidentIndicators(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentIndicators(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *indicatorAPI) AddIncidentIndicator(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.IndicatorReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.AddIncidentIndicator(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *indicatorAPI) AddIncidentIndicatorsFromText(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.IndicatorBulkReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.AddIncidentIndicatorsFromText(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *indicatorAPI) RemoveIncidentIndicator(c *gin.Context, id uint64, indicatorID uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.RemoveIncidentIndicator(id, indicatorID, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *indicatorAPI) FetchIndicatorIncidents(c *gin.Context, value string) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIndicatorIncidents(value)
+
+	renderResponse(c, resp, statusCode)
+}
@@ -0,0 +1,105 @@
+package router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	maintenance_gen "github.com/Dhar01/incident_resp/router/maintenance"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type maintenanceAPI struct{}
+
+var _ maintenance_gen.ServerInterface = (*maintenanceAPI)(nil)
+
+func newMaintenanceAPI() *maintenanceAPI {
+	return &maintenanceAPI{}
+}
+
+func (api *maintenanceAPI) FetchMaintenanceWindows(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetMaintenanceWindows()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *maintenanceAPI) FetchMaintenanceWindow(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetMaintenanceWindow(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *maintenanceAPI) CreateMaintenanceWindow(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.MaintenanceWindowReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateMaintenanceWindow(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *maintenanceAPI) UpdateMaintenanceWindow(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.MaintenanceWindowReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdateMaintenanceWindow(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *maintenanceAPI) DeleteMaintenanceWindow(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteMaintenanceWindow(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *maintenanceAPI) FetchMaintenanceOccurrences(c *gin.Context, id uint64, params maintenance_gen.FetchMaintenanceOccurrencesParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	from, to := time.Time{}, time.Time{}
+	if params.From != nil {
+		from = *params.From
+	}
+	if params.To != nil {
+		to = *params.To
+	}
+
+	resp, statusCode := handler.GetMaintenanceOccurrences(id, from, to)
+
+	renderResponse(c, resp, statusCode)
+}
@@ -0,0 +1,76 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	participant_gen "github.com/Dhar01/incident_resp/router/participants"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type participantAPI struct{}
+
+var _ participant_gen.ServerInterface = (*participantAPI)(nil)
+
+func newParticipantAPI() *participantAPI {
+	return &participantAPI{}
+}
+
+func (api *participantAPI) FetchParticipants(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentParticipants(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *participantAPI) AddParticipant(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.ParticipantReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.AddIncidentParticipant(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *participantAPI) HandoffRole(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.HandoffReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.HandoffIncidentRole(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *participantAPI) RemoveParticipant(c *gin.Context, id uint64, participantID uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.RemoveIncidentParticipant(id, participantID, authID)
+
+	renderResponse(c, resp, statusCode)
+}
@@ -0,0 +1,143 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	playbook_gen "github.com/Dhar01/incident_resp/router/playbooks"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type playbookAPI struct{}
+
+var _ playbook_gen.ServerInterface = (*playbookAPI)(nil)
+
+func newPlaybookAPI() *playbookAPI {
+	return &playbookAPI{}
+}
+
+func (api *playbookAPI) FetchPlaybooks(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetPlaybooks()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *playbookAPI) FetchPlaybook(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetPlaybook(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *playbookAPI) CreatePlaybook(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PlaybookReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreatePlaybook(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *playbookAPI) UpdatePlaybook(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PlaybookReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdatePlaybook(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *playbookAPI) DeletePlaybook(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeletePlaybook(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *playbookAPI) FetchIncidentPlaybooks(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentPlaybooks(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *playbookAPI) AttachPlaybook(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PlaybookAttachReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.AttachPlaybook(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *playbookAPI) DetachPlaybook(c *gin.Context, id uint64, playbookID uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DetachPlaybook(id, playbookID, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *playbookAPI) UpdatePlaybookStep(c *gin.Context, id uint64, playbookID uint64, stepID uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PlaybookStepReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdatePlaybookStep(id, playbookID, stepID, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
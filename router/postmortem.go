@@ -0,0 +1,214 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	postmortem_gen "github.com/Dhar01/incident_resp/router/postmortems"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type postmortemAPI struct{}
+
+var _ postmortem_gen.ServerInterface = (*postmortemAPI)(nil)
+
+func newPostmortemAPI() *postmortemAPI {
+	return &postmortemAPI{}
+}
+
+func (api *postmortemAPI) FetchPostmortemTemplates(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetPostmortemTemplates()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) CreatePostmortemTemplate(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PostmortemTemplateReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreatePostmortemTemplate(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) UpdatePostmortemTemplate(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PostmortemTemplateReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdatePostmortemTemplate(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) DeletePostmortemTemplate(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeletePostmortemTemplate(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) FetchIncidentPostmortem(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentPostmortem(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) CreatePostmortem(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PostmortemReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreatePostmortem(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) FetchPostmortemByID(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetPostmortemByID(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) UpdatePostmortem(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PostmortemUpdate
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdatePostmortem(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) SubmitPostmortem(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PostmortemSubmitReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.SubmitPostmortem(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) ApprovePostmortem(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PostmortemReviewReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.ReviewPostmortem(id, req, true, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) RequestPostmortemChanges(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.PostmortemReviewReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.ReviewPostmortem(id, req, false, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) PublishPostmortem(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.PublishPostmortem(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *postmortemAPI) ExportPostmortem(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.ExportPostmortem(id)
+
+	md, ok := resp.Message.(string)
+	if statusCode != http.StatusOK || !ok {
+		renderResponse(c, resp, statusCode)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="postmortem.md"`)
+	c.Data(statusCode, "text/markdown; charset=utf-8", []byte(md))
+}
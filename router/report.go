@@ -0,0 +1,37 @@
+package router
+
+import (
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	report_gen "github.com/Dhar01/incident_resp/router/reports"
+	"github.com/gin-gonic/gin"
+)
+
+type reportAPI struct{}
+
+var _ report_gen.ServerInterface = (*reportAPI)(nil)
+
+func newReportAPI() *reportAPI {
+	return &reportAPI{}
+}
+
+func (api *reportAPI) FetchMetrics(c *gin.Context, params report_gen.FetchMetricsParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	filter := model.MetricsFilter{}
+	if params.From != nil {
+		filter.From = *params.From
+	}
+	if params.To != nil {
+		filter.To = *params.To
+	}
+	if params.Team != nil {
+		filter.TeamID = *params.Team
+	}
+
+	resp, statusCode := handler.GetMetrics(filter)
+
+	renderResponse(c, resp, statusCode)
+}
@@ -1,10 +1,35 @@
 package router
 
 import (
+	"net/http"
+	"reflect"
+
 	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/model"
 	"github.com/pilinux/gorest/lib/middleware"
+	"github.com/pilinux/gorest/lib/renderer"
 	auth_gen "github.com/Dhar01/incident_resp/router/auth"
 	incident_gen "github.com/Dhar01/incident_resp/router/incidents"
+	schedule_gen "github.com/Dhar01/incident_resp/router/schedules"
+	service_gen "github.com/Dhar01/incident_resp/router/services"
+	team_gen "github.com/Dhar01/incident_resp/router/teams"
+	participant_gen "github.com/Dhar01/incident_resp/router/participants"
+	task_gen "github.com/Dhar01/incident_resp/router/tasks"
+	postmortem_gen "github.com/Dhar01/incident_resp/router/postmortems"
+	report_gen "github.com/Dhar01/incident_resp/router/reports"
+	tag_gen "github.com/Dhar01/incident_resp/router/tags"
+	customfield_gen "github.com/Dhar01/incident_resp/router/customfields"
+	slapolicy_gen "github.com/Dhar01/incident_resp/router/slapolicies"
+	calendar_gen "github.com/Dhar01/incident_resp/router/calendars"
+	maintenance_gen "github.com/Dhar01/incident_resp/router/maintenance"
+	alert_gen "github.com/Dhar01/incident_resp/router/alerts"
+	heartbeat_gen "github.com/Dhar01/incident_resp/router/heartbeats"
+	check_gen "github.com/Dhar01/incident_resp/router/checks"
+	automation_gen "github.com/Dhar01/incident_resp/router/automation"
+	playbook_gen "github.com/Dhar01/incident_resp/router/playbooks"
+	indicator_gen "github.com/Dhar01/incident_resp/router/indicators"
+	attack_gen "github.com/Dhar01/incident_resp/router/attack"
+	stix_gen "github.com/Dhar01/incident_resp/router/stix"
 	"github.com/gin-gonic/gin"
 )
 
@@ -17,12 +42,76 @@ func SetUpRouter(configure config.Configuration) (*gin.Engine, error) {
 
 	router := gin.Default()
 
+	// indicator values such as URLs are path parameters,
+	// escaped slashes must not split them
+	router.UseRawPath = true
+
 	// auth routes
 	authRoutes(&router.RouterGroup, base)
 
 	// incident routes
 	incidentRoutes(&router.RouterGroup, base)
 
+	// on-call schedule routes
+	scheduleRoutes(&router.RouterGroup, base)
+
+	// service catalog routes
+	serviceRoutes(&router.RouterGroup, base)
+
+	// team routes
+	teamRoutes(&router.RouterGroup, base)
+
+	// incident participant routes
+	participantRoutes(&router.RouterGroup, base)
+
+	// incident task routes
+	taskRoutes(&router.RouterGroup, base)
+
+	// postmortem routes
+	postmortemRoutes(&router.RouterGroup, base)
+
+	// reporting routes
+	reportRoutes(&router.RouterGroup, base)
+
+	// incident tag routes
+	tagRoutes(&router.RouterGroup, base)
+
+	// custom field routes
+	customFieldRoutes(&router.RouterGroup, base)
+
+	// SLA policy routes
+	slaPolicyRoutes(&router.RouterGroup, base)
+
+	// business calendar routes
+	calendarRoutes(&router.RouterGroup, base)
+
+	// maintenance window routes
+	maintenanceRoutes(&router.RouterGroup, base)
+
+	// alert ingestion routes
+	alertRoutes(&router.RouterGroup, base)
+
+	// heartbeat monitor routes
+	heartbeatRoutes(&router.RouterGroup, base)
+
+	// synthetic check routes
+	checkRoutes(&router.RouterGroup, base)
+
+	// incident automation rules routes
+	automationRoutes(&router.RouterGroup, base)
+
+	// response playbook routes
+	playbookRoutes(&router.RouterGroup, base)
+
+	// indicators of compromise routes
+	indicatorRoutes(&router.RouterGroup, base)
+
+	// MITRE ATT&CK technique routes
+	attackRoutes(&router.RouterGroup, base)
+
+	// STIX 2.1 sharing routes
+	stixRoutes(&router.RouterGroup, base)
+
 	if err := router.SetTrustedProxies(nil); err != nil {
 		return router, err
 	}
@@ -57,3 +146,345 @@ func incidentRoutes(router *gin.RouterGroup, baseURL string) {
 
 	incident_gen.RegisterHandlersWithOptions(router, api, opt)
 }
+
+func scheduleRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []schedule_gen.MiddlewareFunc{
+		schedule_gen.MiddlewareFunc(jwtIfSecured(schedule_gen.BearerAuthScopes)),
+	}
+
+	opt := schedule_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newScheduleAPI()
+
+	schedule_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func serviceRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []service_gen.MiddlewareFunc{
+		service_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := service_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newServiceAPI()
+
+	service_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func teamRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []team_gen.MiddlewareFunc{
+		team_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := team_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newTeamAPI()
+
+	team_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func participantRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []participant_gen.MiddlewareFunc{
+		participant_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := participant_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newParticipantAPI()
+
+	participant_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func taskRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []task_gen.MiddlewareFunc{
+		task_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := task_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newTaskAPI()
+
+	task_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func postmortemRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []postmortem_gen.MiddlewareFunc{
+		postmortem_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := postmortem_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newPostmortemAPI()
+
+	postmortem_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func reportRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []report_gen.MiddlewareFunc{
+		report_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := report_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newReportAPI()
+
+	report_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func tagRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []tag_gen.MiddlewareFunc{
+		tag_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := tag_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newTagAPI()
+
+	tag_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func customFieldRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []customfield_gen.MiddlewareFunc{
+		customfield_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := customfield_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newCustomFieldAPI()
+
+	customfield_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func slaPolicyRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []slapolicy_gen.MiddlewareFunc{
+		slapolicy_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := slapolicy_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newSLAPolicyAPI()
+
+	slapolicy_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func calendarRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []calendar_gen.MiddlewareFunc{
+		calendar_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := calendar_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newCalendarAPI()
+
+	calendar_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func maintenanceRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []maintenance_gen.MiddlewareFunc{
+		maintenance_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := maintenance_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newMaintenanceAPI()
+
+	maintenance_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func alertRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []alert_gen.MiddlewareFunc{
+		alert_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := alert_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newAlertAPI()
+
+	alert_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func heartbeatRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []heartbeat_gen.MiddlewareFunc{
+		heartbeat_gen.MiddlewareFunc(jwtIfSecured(heartbeat_gen.BearerAuthScopes)),
+	}
+
+	opt := heartbeat_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newHeartbeatAPI()
+
+	heartbeat_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func checkRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []check_gen.MiddlewareFunc{
+		check_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := check_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newCheckAPI()
+
+	check_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func automationRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []automation_gen.MiddlewareFunc{
+		automation_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := automation_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newAutomationAPI()
+
+	automation_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func playbookRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []playbook_gen.MiddlewareFunc{
+		playbook_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := playbook_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newPlaybookAPI()
+
+	playbook_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func indicatorRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []indicator_gen.MiddlewareFunc{
+		indicator_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := indicator_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newIndicatorAPI()
+
+	indicator_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func attackRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []attack_gen.MiddlewareFunc{
+		attack_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := attack_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newAttackAPI()
+
+	attack_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+func stixRoutes(router *gin.RouterGroup, baseURL string) {
+	middlewares := []stix_gen.MiddlewareFunc{
+		stix_gen.MiddlewareFunc(middleware.JWT()),
+	}
+
+	opt := stix_gen.GinServerOptions{
+		BaseURL:     baseURL,
+		Middlewares: middlewares,
+	}
+
+	api := newStixAPI()
+
+	stix_gen.RegisterHandlersWithOptions(router, api, opt)
+}
+
+// jwtIfSecured validates the access token only for operations which
+// declare a security requirement in the spec, so that public endpoints
+// (i.e. calendar feeds) can share a generated router with secured ones
+func jwtIfSecured(scopesKey string) gin.HandlerFunc {
+	jwt := middleware.JWT()
+
+	return func(c *gin.Context) {
+		if _, ok := c.Get(scopesKey); ok {
+			jwt(c)
+		}
+	}
+}
+
+// authIDFromContext reads the authID set by the JWT middleware,
+// renders 401 and returns false when it is missing or invalid
+func authIDFromContext(c *gin.Context) (uint64, bool) {
+	authIDRaw, ok := c.Get("authID")
+	if !ok {
+		renderer.Render(c, gin.H{"message": "authID not found in context"}, http.StatusUnauthorized)
+		return 0, false
+	}
+
+	authID, ok := authIDRaw.(uint64)
+	if !ok {
+		renderer.Render(c, gin.H{"message": "invalid authID type in context"}, http.StatusUnauthorized)
+		return 0, false
+	}
+
+	return authID, true
+}
+
+// renderResponse renders plain messages wrapped in an object
+// and everything else as it is
+func renderResponse(c *gin.Context, resp model.HTTPResponse, statusCode int) {
+	if reflect.TypeOf(resp.Message).Kind() == reflect.String {
+		renderer.Render(c, resp, statusCode)
+		return
+	}
+
+	renderer.Render(c, resp.Message, statusCode)
+}
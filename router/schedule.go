@@ -0,0 +1,121 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	schedule_gen "github.com/Dhar01/incident_resp/router/schedules"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type scheduleAPI struct{}
+
+var _ schedule_gen.ServerInterface = (*scheduleAPI)(nil)
+
+func newScheduleAPI() *scheduleAPI {
+	return &scheduleAPI{}
+}
+
+func (api *scheduleAPI) FetchSchedules(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetSchedules()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *scheduleAPI) CreateSchedule(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	var req model.ScheduleReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateSchedule(req)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *scheduleAPI) FetchScheduleByID(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetScheduleByID(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *scheduleAPI) FetchScheduleCalendar(c *gin.Context, id uint64, params schedule_gen.FetchScheduleCalendarParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetScheduleCalendar(id, weeksParam(params.Weeks))
+
+	renderCalendar(c, resp, statusCode)
+}
+
+func (api *scheduleAPI) CreateOnCallFeed(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.CreateCalendarFeed(authID)
+
+	if feed, ok := resp.Message.(model.CalendarFeedRes); ok {
+		feed.URL = base + "/users/me/oncall.ics?token=" + feed.Token
+		resp.Message = feed
+	}
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *scheduleAPI) RevokeOnCallFeed(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.RevokeCalendarFeed(authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+// FetchOnCallCalendar is public: calendar clients authenticate
+// with the feed secret instead of a bearer token
+func (api *scheduleAPI) FetchOnCallCalendar(c *gin.Context, params schedule_gen.FetchOnCallCalendarParams) {
+	resp, statusCode := handler.GetOnCallCalendar(params.Token, weeksParam(params.Weeks))
+
+	renderCalendar(c, resp, statusCode)
+}
+
+func weeksParam(weeks *schedule_gen.Weeks) int {
+	if weeks == nil {
+		return 0
+	}
+	return *weeks
+}
+
+// renderCalendar serves an iCalendar document on success
+// and a JSON error otherwise
+func renderCalendar(c *gin.Context, resp model.HTTPResponse, statusCode int) {
+	ics, ok := resp.Message.(string)
+	if statusCode != http.StatusOK || !ok {
+		renderResponse(c, resp, statusCode)
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="oncall.ics"`)
+	c.Data(statusCode, "text/calendar; charset=utf-8", []byte(ics))
+}
@@ -0,0 +1,113 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	service_gen "github.com/Dhar01/incident_resp/router/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type serviceAPI struct{}
+
+var _ service_gen.ServerInterface = (*serviceAPI)(nil)
+
+func newServiceAPI() *serviceAPI {
+	return &serviceAPI{}
+}
+
+func (api *serviceAPI) FetchServices(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetServices()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *serviceAPI) CreateService(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	var req model.ServiceReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateService(req)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *serviceAPI) FetchServiceByID(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetServiceByID(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *serviceAPI) UpdateService(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	var req model.ServiceReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdateService(id, req)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *serviceAPI) DeleteService(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteService(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *serviceAPI) FetchServiceIncidents(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetServiceIncidents(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *serviceAPI) FetchServiceDependencies(c *gin.Context, id uint64, params service_gen.FetchServiceDependenciesParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	depth := 0
+	if params.Depth != nil {
+		depth = *params.Depth
+	}
+
+	direction := ""
+	if params.Direction != nil {
+		direction = string(*params.Direction)
+	}
+
+	resp, statusCode := handler.GetServiceDependencies(id, depth, direction)
+
+	renderResponse(c, resp, statusCode)
+}
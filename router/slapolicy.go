@@ -0,0 +1,76 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	slapolicy_gen "github.com/Dhar01/incident_resp/router/slapolicies"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type slaPolicyAPI struct{}
+
+var _ slapolicy_gen.ServerInterface = (*slaPolicyAPI)(nil)
+
+func newSLAPolicyAPI() *slaPolicyAPI {
+	return &slaPolicyAPI{}
+}
+
+func (api *slaPolicyAPI) FetchSLAPolicies(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetSLAPolicies()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *slaPolicyAPI) CreateSLAPolicy(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.SLAPolicyReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateSLAPolicy(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *slaPolicyAPI) UpdateSLAPolicy(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.SLAPolicyReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdateSLAPolicy(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *slaPolicyAPI) DeleteSLAPolicy(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteSLAPolicy(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
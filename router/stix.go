@@ -0,0 +1,46 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	stix_gen "github.com/Dhar01/incident_resp/router/stix"
+	"github.com/gin-gonic/gin"
+)
+
+type stixAPI struct{}
+
+var _ stix_gen.ServerInterface = (*stixAPI)(nil)
+
+func newStixAPI() *stixAPI {
+	return &stixAPI{}
+}
+
+func (api *stixAPI) ExportIncidentStix(c *gin.Context, id uint64, params stix_gen.ExportIncidentStixParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	tlp := ""
+	if params.Tlp != nil {
+		tlp = string(*params.Tlp)
+	}
+
+	resp, statusCode := handler.ExportIncidentStix(id, tlp)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *stixAPI) ImportIncidentStix(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, model.StixImportMaxBytes)
+
+	resp, statusCode := handler.ImportIncidentStix(id, body, authID)
+
+	renderResponse(c, resp, statusCode)
+}
@@ -0,0 +1,94 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	tag_gen "github.com/Dhar01/incident_resp/router/tags"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type tagAPI struct{}
+
+var _ tag_gen.ServerInterface = (*tagAPI)(nil)
+
+func newTagAPI() *tagAPI {
+	return &tagAPI{}
+}
+
+func (api *tagAPI) FetchTags(c *gin.Context, params tag_gen.FetchTagsParams) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	prefix, limit := "", 0
+	if params.Q != nil {
+		prefix = *params.Q
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	resp, statusCode := handler.GetTagSuggestions(prefix, limit)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *tagAPI) FetchIncidentTags(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentTags(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *tagAPI) AddIncidentTags(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.TagReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.AddIncidentTags(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *tagAPI) SetIncidentTags(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.TagReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.SetIncidentTags(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *tagAPI) RemoveIncidentTag(c *gin.Context, id uint64, tag string) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.RemoveIncidentTag(id, tag, authID)
+
+	renderResponse(c, resp, statusCode)
+}
@@ -0,0 +1,95 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	task_gen "github.com/Dhar01/incident_resp/router/tasks"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type taskAPI struct{}
+
+var _ task_gen.ServerInterface = (*taskAPI)(nil)
+
+func newTaskAPI() *taskAPI {
+	return &taskAPI{}
+}
+
+func (api *taskAPI) FetchIncidentTasks(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetIncidentTasks(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *taskAPI) CreateTask(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.TaskReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateTask(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *taskAPI) UpdateTask(c *gin.Context, id uint64, taskID uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.TaskReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdateTask(id, taskID, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *taskAPI) DeleteTask(c *gin.Context, id uint64, taskID uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteTask(id, taskID, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *taskAPI) FetchMyTasks(c *gin.Context, params task_gen.FetchMyTasksParams) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	filter := model.TaskFilter{}
+	if params.Status != nil {
+		filter.Status = model.TaskStatus(*params.Status)
+	}
+	if params.Overdue != nil {
+		filter.Overdue = *params.Overdue
+	}
+
+	resp, statusCode := handler.GetUserTasks(authID, filter)
+
+	renderResponse(c, resp, statusCode)
+}
@@ -0,0 +1,131 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Dhar01/incident_resp/handler"
+	"github.com/Dhar01/incident_resp/internal/model"
+	team_gen "github.com/Dhar01/incident_resp/router/teams"
+	"github.com/gin-gonic/gin"
+	"github.com/pilinux/gorest/lib/renderer"
+)
+
+type teamAPI struct{}
+
+var _ team_gen.ServerInterface = (*teamAPI)(nil)
+
+func newTeamAPI() *teamAPI {
+	return &teamAPI{}
+}
+
+func (api *teamAPI) FetchTeams(c *gin.Context) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetTeams()
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *teamAPI) CreateTeam(c *gin.Context) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.TeamReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.CreateTeam(req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *teamAPI) FetchTeamByID(c *gin.Context, id uint64) {
+	if _, ok := authIDFromContext(c); !ok {
+		return
+	}
+
+	resp, statusCode := handler.GetTeamByID(id)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *teamAPI) UpdateTeam(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.TeamReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.UpdateTeam(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *teamAPI) DeleteTeam(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.DeleteTeam(id, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *teamAPI) SetTeamMember(c *gin.Context, id uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req model.TeamMemberReq
+
+	if err := c.ShouldBindBodyWithJSON(&req); err != nil {
+		renderer.Render(c, gin.H{"message": err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	resp, statusCode := handler.SetTeamMember(id, req, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *teamAPI) RemoveTeamMember(c *gin.Context, id uint64, memberAuthID uint64) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	resp, statusCode := handler.RemoveTeamMember(id, memberAuthID, authID)
+
+	renderResponse(c, resp, statusCode)
+}
+
+func (api *teamAPI) FetchTeamIncidents(c *gin.Context, id uint64, params team_gen.FetchTeamIncidentsParams) {
+	authID, ok := authIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var status model.StatusType
+	if params.Status != nil {
+		status = model.StatusType(*params.Status)
+	}
+
+	resp, statusCode := handler.GetTeamIncidents(id, status, authID)
+
+	renderResponse(c, resp, statusCode)
+}
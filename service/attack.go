@@ -0,0 +1,73 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+// enterpriseAttack - tactics, techniques and sub-techniques of the MITRE
+// ATT&CK enterprise matrix, revoked and deprecated ones left out
+//
+//go:embed attack/enterprise-attack.json
+var enterpriseAttack []byte
+
+var attackMatrix = loadAttackMatrix()
+
+func loadAttackMatrix() model.AttackMatrix {
+	var matrix model.AttackMatrix
+	if err := json.Unmarshal(enterpriseAttack, &matrix); err != nil {
+		panic("service: bundled ATT&CK matrix: " + err.Error())
+	}
+	return matrix
+}
+
+// Attack returns the bundled ATT&CK enterprise matrix, it must not be
+// modified
+func Attack() model.AttackMatrix {
+	return attackMatrix
+}
+
+// AttackTechnique finds a technique or sub-technique by its ID, case
+// insensitive
+func AttackTechnique(id string) (model.AttackTechnique, bool) {
+	id = strings.ToUpper(strings.TrimSpace(id))
+	i := slices.IndexFunc(attackMatrix.Techniques, func(technique model.AttackTechnique) bool { return technique.ID == id })
+	if i < 0 {
+		return model.AttackTechnique{}, false
+	}
+	return attackMatrix.Techniques[i], true
+}
+
+// AttackTactic finds a tactic by its ID or short name, case insensitive
+func AttackTactic(tactic string) (model.AttackTactic, bool) {
+	tactic = strings.TrimSpace(tactic)
+	i := slices.IndexFunc(attackMatrix.Tactics, func(t model.AttackTactic) bool {
+		return strings.EqualFold(t.ID, tactic) || strings.EqualFold(t.ShortName, tactic)
+	})
+	if i < 0 {
+		return model.AttackTactic{}, false
+	}
+	return attackMatrix.Tactics[i], true
+}
+
+// SearchAttackTechniques lists the techniques of a tactic, all when
+// tacticID is empty, whose ID or name contains the query
+func SearchAttackTechniques(tacticID, query string) []model.AttackTechnique {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	techniques := []model.AttackTechnique{}
+	for _, technique := range attackMatrix.Techniques {
+		if tacticID != "" && !slices.Contains(technique.Tactics, tacticID) {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(technique.ID), query) && !strings.Contains(strings.ToLower(technique.Name), query) {
+			continue
+		}
+		techniques = append(techniques, technique)
+	}
+	return techniques
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/pilinux/gorest/lib"
+)
+
+// automationWebhookTimeout - how long a webhook action may take
+const automationWebhookTimeout = 10 * time.Second
+
+// MatchAutomation reports whether the incident matches the conditions
+// of the rule, a rule without conditions matches every incident
+func MatchAutomation(rule model.AutomationRule, incident model.Incident) bool {
+	if len(rule.Conditions) == 0 {
+		return true
+	}
+
+	for _, condition := range rule.Conditions {
+		matched := matchAutomationCondition(condition, incident)
+		if rule.MatchAny && matched {
+			return true
+		}
+		if !rule.MatchAny && !matched {
+			return false
+		}
+	}
+	return !rule.MatchAny
+}
+
+// AutomationField reports whether field can be used in a condition
+func AutomationField(field string) bool {
+	switch field {
+	case "title", "description", "status", "severity", "priority", "impact", "urgency",
+		"assignedTo", "team", "service", "tag":
+		return true
+	}
+	key, ok := strings.CutPrefix(field, "custom.")
+	return ok && key != ""
+}
+
+func matchAutomationCondition(condition model.AutomationCondition, incident model.Incident) bool {
+	values := automationFieldValues(condition.Field, incident)
+
+	if condition.Operator == model.AutomationNe {
+		for _, value := range values {
+			if strings.EqualFold(value, condition.Value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, value := range values {
+		switch condition.Operator {
+		case model.AutomationEq:
+			if strings.EqualFold(value, condition.Value) {
+				return true
+			}
+		case model.AutomationContains:
+			if strings.Contains(strings.ToLower(value), strings.ToLower(condition.Value)) {
+				return true
+			}
+		case model.AutomationIn:
+			for _, option := range strings.Split(condition.Value, ",") {
+				if strings.EqualFold(value, strings.TrimSpace(option)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// automationFieldValues returns the values of an incident field as
+// text, a missing single value as ""
+func automationFieldValues(field string, incident model.Incident) []string {
+	switch field {
+	case "title":
+		return []string{incident.Title}
+	case "description":
+		return []string{incident.Description}
+	case "status":
+		return []string{string(incident.Status)}
+	case "severity":
+		return []string{string(incident.Severity)}
+	case "priority":
+		return []string{string(incident.Priority)}
+	case "impact":
+		return []string{string(incident.Impact)}
+	case "urgency":
+		return []string{string(incident.Urgency)}
+	case "assignedTo":
+		return []string{strconv.FormatUint(incident.AssignedTo, 10)}
+	case "team":
+		if incident.TeamID == nil {
+			return []string{""}
+		}
+		return []string{strconv.FormatUint(*incident.TeamID, 10)}
+	case "service":
+		values := []string{}
+		for _, service := range incident.Services {
+			values = append(values, service.Name)
+		}
+		return values
+	case "tag":
+		values := []string{}
+		for _, tag := range incident.Tags {
+			values = append(values, tag.Name)
+		}
+		return values
+	}
+
+	key := strings.TrimPrefix(field, "custom.")
+	value, ok := incident.CustomFields[key]
+	if !ok || value == nil {
+		return []string{""}
+	}
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case float64:
+		return []string{strconv.FormatFloat(value, 'f', -1, 64)}
+	}
+	data, _ := json.Marshal(value)
+	return []string{string(data)}
+}
+
+// PostAutomationWebhook posts the payload as JSON, a response other
+// than 2xx is an error
+func PostAutomationWebhook(url string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "incident_resp-automation/1")
+
+	client := &http.Client{Timeout: automationWebhookTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.New("webhook responded with status " + strconv.Itoa(resp.StatusCode))
+	}
+	return nil
+}
+
+// NotifyAutomation emails a user about a rule which fired on an incident
+func NotifyAutomation(authID uint64, incident model.Incident, ruleName string) (bool, error) {
+	if !config.IsAutomationNotice() {
+		return false, nil
+	}
+
+	var auth model.Auth
+	if err := database.GetDB().First(&auth, authID).Error; err != nil {
+		return false, err
+	}
+
+	email := auth.Email
+	if email == "" && auth.EmailCipher != "" {
+		var err error
+		if email, err = DecryptEmail(auth.EmailNonce, auth.EmailCipher); err != nil {
+			return false, err
+		}
+	}
+
+	return SendAutomationNotice(email, incident, ruleName)
+}
+
+// SendAutomationNotice emails the notice of an automation rule
+//
+// {true, nil} => email delivered successfully
+//
+// {false, nil} => automation emails not configured
+//
+// {false, error} => email delivery failed
+func SendAutomationNotice(email string, incident model.Incident, ruleName string) (bool, error) {
+	appConfig := config.GetConfig()
+
+	if !config.IsAutomationNotice() {
+		return false, nil
+	}
+
+	if appConfig.EmailConf.Provider != "postmark" {
+		return false, errors.New(
+			"email delivery service provider: '" + appConfig.EmailConf.Provider + "' is unknown",
+		)
+	}
+
+	htmlModel := lib.HTMLModel(lib.StrArrHTMLModel(appConfig.EmailConf.HTMLModel))
+	htmlModel["incident_id"] = incident.IncidentID
+	htmlModel["incident_title"] = incident.Title
+	htmlModel["incident_status"] = string(incident.Status)
+	htmlModel["incident_severity"] = string(incident.Severity)
+	htmlModel["incident_priority"] = string(incident.Priority)
+	htmlModel["rule_name"] = ruleName
+
+	params := PostmarkParams{}
+	params.ServerToken = appConfig.EmailConf.APIToken
+	params.TemplateID = appConfig.EmailConf.AutomationTemplateID
+	params.From = appConfig.EmailConf.AddrFrom
+	params.To = email
+	params.Tag = appConfig.EmailConf.AutomationTag
+	params.TrackOpens = appConfig.EmailConf.TrackOpens
+	params.TrackLinks = appConfig.EmailConf.TrackLinks
+	params.MessageStream = appConfig.EmailConf.DeliveryType
+	params.HTMLModel = htmlModel
+
+	res, err := Postmark(params)
+	if err != nil {
+		return false, err
+	}
+
+	if res.Message != "OK" {
+		return false, errors.New("email delivery failed")
+	}
+
+	return true, nil
+}
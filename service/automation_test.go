@@ -0,0 +1,175 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+func TestMatchAutomation(t *testing.T) {
+	teamID := uint64(7)
+	incident := model.Incident{
+		Title:      "Database down",
+		Status:     model.Open,
+		Severity:   model.Critical,
+		AssignedTo: 42,
+		TeamID:     &teamID,
+		Services:   []model.Service{{Name: "billing"}, {Name: "checkout"}},
+		Tags:       []model.Tag{{Name: "env:prod"}},
+		CustomFields: model.CustomFieldValues{
+			"region": "eu-west",
+		},
+	}
+	untagged := model.Incident{Title: "Disk full", Severity: model.Low}
+
+	tests := []struct {
+		name     string
+		matchAny bool
+		incident model.Incident
+		conds    []model.AutomationCondition
+		want     bool
+	}{
+		{
+			name:     "no conditions",
+			incident: incident,
+			want:     true,
+		},
+		{
+			name:     "all conditions match",
+			incident: incident,
+			conds: []model.AutomationCondition{
+				{Field: "severity", Operator: model.AutomationEq, Value: "CRITICAL"},
+				{Field: "title", Operator: model.AutomationContains, Value: "database"},
+			},
+			want: true,
+		},
+		{
+			name:     "one condition fails",
+			incident: incident,
+			conds: []model.AutomationCondition{
+				{Field: "severity", Operator: model.AutomationEq, Value: "critical"},
+				{Field: "status", Operator: model.AutomationEq, Value: "resolved"},
+			},
+		},
+		{
+			name:     "any condition matches",
+			matchAny: true,
+			incident: incident,
+			conds: []model.AutomationCondition{
+				{Field: "status", Operator: model.AutomationEq, Value: "resolved"},
+				{Field: "assignedTo", Operator: model.AutomationIn, Value: "1, 42"},
+			},
+			want: true,
+		},
+		{
+			name:     "no condition matches",
+			matchAny: true,
+			incident: incident,
+			conds: []model.AutomationCondition{
+				{Field: "status", Operator: model.AutomationEq, Value: "resolved"},
+				{Field: "team", Operator: model.AutomationEq, Value: "8"},
+			},
+		},
+		{
+			name:     "one of several services",
+			incident: incident,
+			conds:    []model.AutomationCondition{{Field: "service", Operator: model.AutomationEq, Value: "checkout"}},
+			want:     true,
+		},
+		{
+			name:     "ne fails when any service is equal",
+			incident: incident,
+			conds:    []model.AutomationCondition{{Field: "service", Operator: model.AutomationNe, Value: "checkout"}},
+		},
+		{
+			name:     "ne holds when no service is equal",
+			incident: incident,
+			conds:    []model.AutomationCondition{{Field: "service", Operator: model.AutomationNe, Value: "search"}},
+			want:     true,
+		},
+		{
+			name:     "ne holds without tags",
+			incident: untagged,
+			conds:    []model.AutomationCondition{{Field: "tag", Operator: model.AutomationNe, Value: "env:prod"}},
+			want:     true,
+		},
+		{
+			name:     "eq fails without tags",
+			incident: untagged,
+			conds:    []model.AutomationCondition{{Field: "tag", Operator: model.AutomationEq, Value: "env:prod"}},
+		},
+		{
+			name:     "ne fails when the tag is set",
+			incident: incident,
+			conds:    []model.AutomationCondition{{Field: "tag", Operator: model.AutomationNe, Value: "ENV:PROD"}},
+		},
+		{
+			name:     "missing team equals empty",
+			incident: untagged,
+			conds:    []model.AutomationCondition{{Field: "team", Operator: model.AutomationEq, Value: ""}},
+			want:     true,
+		},
+		{
+			name:     "custom field",
+			incident: incident,
+			conds:    []model.AutomationCondition{{Field: "custom.region", Operator: model.AutomationIn, Value: "us-east,eu-west"}},
+			want:     true,
+		},
+		{
+			name:     "missing custom field",
+			incident: incident,
+			conds:    []model.AutomationCondition{{Field: "custom.tier", Operator: model.AutomationNe, Value: "gold"}},
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := model.AutomationRule{MatchAny: tt.matchAny, Conditions: tt.conds}
+			if got := MatchAutomation(rule, tt.incident); got != tt.want {
+				t.Errorf("MatchAutomation(%v) = %t, want %t", tt.conds, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutomationFieldValues(t *testing.T) {
+	incident := model.Incident{
+		AssignedTo: 42,
+		Services:   []model.Service{{Name: "billing"}, {Name: "checkout"}},
+		CustomFields: model.CustomFieldValues{
+			"region":  "eu-west",
+			"count":   float64(3),
+			"ratio":   0.25,
+			"big":     float64(1e21),
+			"enabled": true,
+			"owners":  []any{"alice", "bob"},
+			"empty":   nil,
+		},
+	}
+
+	tests := []struct {
+		field string
+		want  []string
+	}{
+		{field: "assignedTo", want: []string{"42"}},
+		{field: "team", want: []string{""}},
+		{field: "service", want: []string{"billing", "checkout"}},
+		{field: "tag", want: []string{}},
+		{field: "custom.region", want: []string{"eu-west"}},
+		{field: "custom.count", want: []string{"3"}},
+		{field: "custom.ratio", want: []string{"0.25"}},
+		{field: "custom.big", want: []string{"1000000000000000000000"}},
+		{field: "custom.enabled", want: []string{"true"}},
+		{field: "custom.owners", want: []string{`["alice","bob"]`}},
+		{field: "custom.empty", want: []string{""}},
+		{field: "custom.missing", want: []string{""}},
+	}
+
+	for _, tt := range tests {
+		if got := automationFieldValues(tt.field, incident); !slices.Equal(got, tt.want) {
+			t.Errorf("automationFieldValues(%q) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+
+	_ "time/tzdata" // time zones of calendars on hosts without zoneinfo
+)
+
+// businessDaysMax bounds the search for enough business time
+const businessDaysMax = 3660
+
+// BusinessTime - working periods and holidays of a business
+// calendar, ready for time arithmetic
+type BusinessTime struct {
+	loc      *time.Location
+	periods  [7][]clockSpan // by weekday, sorted
+	holidays map[string]bool
+}
+
+// clockSpan - working period in minutes after midnight
+type clockSpan struct {
+	start int
+	end   int
+}
+
+// NewBusinessTime validates a calendar and prepares its arithmetic
+func NewBusinessTime(calendar model.BusinessCalendar) (*BusinessTime, error) {
+	loc, err := time.LoadLocation(calendar.TimeZone)
+	if err != nil {
+		return nil, errors.New("unknown time zone: " + calendar.TimeZone)
+	}
+
+	b := &BusinessTime{loc: loc, holidays: map[string]bool{}}
+
+	for _, hours := range calendar.Hours {
+		if hours.Day < time.Sunday || hours.Day > time.Saturday {
+			return nil, errors.New("working days must be 0 (Sunday) to 6 (Saturday)")
+		}
+		start, err := ParseClock(hours.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := ParseClock(hours.End)
+		if err != nil {
+			return nil, err
+		}
+		if start >= end {
+			return nil, errors.New("working hours must end after they start")
+		}
+		b.periods[hours.Day] = append(b.periods[hours.Day], clockSpan{start, end})
+	}
+
+	for day, periods := range b.periods {
+		sort.Slice(periods, func(i, j int) bool { return periods[i].start < periods[j].start })
+		for i := 1; i < len(periods); i++ {
+			if periods[i].start < periods[i-1].end {
+				return nil, errors.New("working hours of " + time.Weekday(day).String() + " overlap")
+			}
+		}
+	}
+
+	for _, holiday := range calendar.Holidays {
+		b.holidays[holiday.Date] = true
+	}
+
+	return b, nil
+}
+
+// ParseClock parses HH:MM into minutes after midnight,
+// 24:00 is the end of the day
+func ParseClock(s string) (int, error) {
+	invalid := errors.New("working hours must be HH:MM, got '" + s + "'")
+
+	h, m, ok := strings.Cut(s, ":")
+	if !ok || len(h) != 2 || len(m) != 2 {
+		return 0, invalid
+	}
+	hour, err := strconv.Atoi(h)
+	if err != nil {
+		return 0, invalid
+	}
+	minute, err := strconv.Atoi(m)
+	if err != nil || minute < 0 || minute > 59 || hour < 0 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, invalid
+	}
+	return hour*60 + minute, nil
+}
+
+// Add returns the moment when d of business time has passed since t,
+// the first business moment from t when d is 0.
+//
+// Working hours are wall clock time: on days with a DST change a
+// period across the switch is an hour shorter or longer, and a period
+// starting or ending in the skipped hour does so when the clocks have
+// moved on, and one in the repeated hour at its first occurrence.
+func (b *BusinessTime) Add(t time.Time, d time.Duration) (time.Time, error) {
+	if d < 0 {
+		return time.Time{}, errors.New("business time must not be negative")
+	}
+
+	t = t.In(b.loc)
+	for i := 0; i < businessDaysMax; i++ {
+		y, m, day := t.Date()
+
+		if !b.holidays[t.Format(model.HolidayDateLayout)] {
+			for _, span := range b.periods[t.Weekday()] {
+				from := clockTime(y, m, day, span.start, b.loc)
+				to := clockTime(y, m, day, span.end, b.loc)
+
+				if !to.After(t) {
+					continue
+				}
+				if t.Before(from) {
+					t = from
+				}
+				left := to.Sub(t)
+				if d <= left {
+					return t.Add(d), nil
+				}
+				d -= left
+			}
+		}
+
+		t = clockTime(y, m, day+1, 0, b.loc)
+	}
+
+	return time.Time{}, errors.New("calendar has too little business time for the duration")
+}
+
+// AddMinutes returns the moment n business minutes after t
+func (b *BusinessTime) AddMinutes(t time.Time, n int64) (time.Time, error) {
+	return b.Add(t, time.Duration(n)*time.Minute)
+}
+
+// clockTime - the wall clock time minutes after midnight of a day. A
+// time skipped by a DST change is the moment the clocks moved on and a
+// repeated time its first occurrence, time.Date picks either side of
+// the switch depending on the zone.
+func clockTime(y int, m time.Month, day, minutes int, loc *time.Location) time.Time {
+	t := time.Date(y, m, day, minutes/60, minutes%60, 0, 0, loc)
+	start, end := t.ZoneBounds()
+
+	want := time.Date(y, m, day, minutes/60, minutes%60, 0, 0, time.UTC)
+	got := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+	switch {
+	case got.Before(want):
+		return end
+	case got.After(want):
+		return start
+	}
+
+	if !start.IsZero() {
+		_, before := start.Add(-time.Second).Zone()
+		_, offset := t.Zone()
+		earlier := t.Add(-time.Duration(before-offset) * time.Second)
+		if before > offset && earlier.Before(start) {
+			return earlier
+		}
+	}
+	return t
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+// clocks change on 2026-03-08 and 2026-11-01 in New York,
+// on 2026-03-29 and 2026-10-25 in Berlin
+func TestBusinessTimeAddAcrossDST(t *testing.T) {
+	tests := []struct {
+		name     string
+		zone     string
+		hours    []model.WorkingHours
+		holidays []string
+		from     string
+		minutes  int64
+		want     string
+	}{
+		{
+			name:    "new york period starting in the gap",
+			zone:    "America/New_York",
+			hours:   []model.WorkingHours{{Day: time.Sunday, Start: "02:30", End: "04:00"}},
+			from:    "2026-03-08T00:00:00-05:00",
+			minutes: 60,
+			want:    "2026-03-08T04:00:00-04:00",
+		},
+		{
+			name:    "new york period starting in the gap rolls over",
+			zone:    "America/New_York",
+			hours:   []model.WorkingHours{{Day: time.Sunday, Start: "02:30", End: "04:00"}},
+			from:    "2026-03-08T00:00:00-05:00",
+			minutes: 90,
+			want:    "2026-03-15T03:00:00-04:00",
+		},
+		{
+			name:  "new york first business moment in the gap",
+			zone:  "America/New_York",
+			hours: []model.WorkingHours{{Day: time.Sunday, Start: "02:30", End: "04:00"}},
+			from:  "2026-03-08T00:00:00-05:00",
+			want:  "2026-03-08T03:00:00-04:00",
+		},
+		{
+			name:    "new york period ending in the gap",
+			zone:    "America/New_York",
+			hours:   []model.WorkingHours{{Day: time.Sunday, Start: "01:00", End: "02:30"}},
+			from:    "2026-03-08T00:00:00-05:00",
+			minutes: 90,
+			want:    "2026-03-15T01:30:00-04:00",
+		},
+		{
+			name:    "berlin period starting in the gap rolls over",
+			zone:    "Europe/Berlin",
+			hours:   []model.WorkingHours{{Day: time.Sunday, Start: "02:30", End: "04:00"}},
+			from:    "2026-03-29T00:00:00+01:00",
+			minutes: 90,
+			want:    "2026-04-05T03:00:00+02:00",
+		},
+		{
+			name:  "berlin first business moment in the gap",
+			zone:  "Europe/Berlin",
+			hours: []model.WorkingHours{{Day: time.Sunday, Start: "02:30", End: "04:00"}},
+			from:  "2026-03-29T00:00:00+01:00",
+			want:  "2026-03-29T03:00:00+02:00",
+		},
+		{
+			name:    "berlin period ending in the gap",
+			zone:    "Europe/Berlin",
+			hours:   []model.WorkingHours{{Day: time.Sunday, Start: "01:00", End: "02:30"}},
+			from:    "2026-03-29T00:00:00+01:00",
+			minutes: 90,
+			want:    "2026-04-05T01:30:00+02:00",
+		},
+		{
+			name:    "new york period across the switch to standard time",
+			zone:    "America/New_York",
+			hours:   []model.WorkingHours{{Day: time.Sunday, Start: "00:00", End: "03:00"}},
+			from:    "2026-11-01T00:00:00-04:00",
+			minutes: 240,
+			want:    "2026-11-01T03:00:00-05:00",
+		},
+		{
+			name:    "new york period in the repeated hour",
+			zone:    "America/New_York",
+			hours:   []model.WorkingHours{{Day: time.Sunday, Start: "01:00", End: "02:00"}},
+			from:    "2026-11-01T00:00:00-04:00",
+			minutes: 90,
+			want:    "2026-11-01T01:30:00-05:00",
+		},
+		{
+			name:  "new york period starting in the repeated hour",
+			zone:  "America/New_York",
+			hours: []model.WorkingHours{{Day: time.Sunday, Start: "01:30", End: "03:00"}},
+			from:  "2026-11-01T00:00:00-04:00",
+			want:  "2026-11-01T01:30:00-04:00",
+		},
+		{
+			name:    "berlin period in the repeated hour",
+			zone:    "Europe/Berlin",
+			hours:   []model.WorkingHours{{Day: time.Sunday, Start: "02:00", End: "03:00"}},
+			from:    "2026-10-25T00:00:00+02:00",
+			minutes: 90,
+			want:    "2026-10-25T02:30:00+01:00",
+		},
+		{
+			name:  "berlin period starting in the repeated hour",
+			zone:  "Europe/Berlin",
+			hours: []model.WorkingHours{{Day: time.Sunday, Start: "02:30", End: "04:00"}},
+			from:  "2026-10-25T00:00:00+02:00",
+			want:  "2026-10-25T02:30:00+02:00",
+		},
+		{
+			name:    "berlin period ending in the repeated hour",
+			zone:    "Europe/Berlin",
+			hours:   []model.WorkingHours{{Day: time.Sunday, Start: "01:00", End: "02:30"}},
+			from:    "2026-10-25T00:00:00+02:00",
+			minutes: 120,
+			want:    "2026-11-01T01:30:00+01:00",
+		},
+		{
+			name: "new york holiday on the switch to daylight time",
+			zone: "America/New_York",
+			hours: []model.WorkingHours{
+				{Day: time.Sunday, Start: "09:00", End: "17:00"},
+				{Day: time.Monday, Start: "09:00", End: "17:00"},
+				{Day: time.Saturday, Start: "09:00", End: "17:00"},
+			},
+			holidays: []string{"2026-03-08"},
+			from:     "2026-03-07T16:00:00-05:00",
+			minutes:  120,
+			want:     "2026-03-09T10:00:00-04:00",
+		},
+		{
+			name:     "new york holiday on the switch to standard time",
+			zone:     "America/New_York",
+			hours:    []model.WorkingHours{{Day: time.Sunday, Start: "01:00", End: "02:00"}, {Day: time.Saturday, Start: "23:00", End: "24:00"}},
+			holidays: []string{"2026-11-01"},
+			from:     "2026-10-31T23:30:00-04:00",
+			minutes:  60,
+			want:     "2026-11-07T23:30:00-05:00",
+		},
+		{
+			name:     "berlin holiday after the switch to daylight time",
+			zone:     "Europe/Berlin",
+			hours:    []model.WorkingHours{{Day: time.Sunday, Start: "02:30", End: "04:00"}, {Day: time.Monday, Start: "09:00", End: "17:00"}},
+			holidays: []string{"2026-03-30"},
+			from:     "2026-03-29T00:00:00+01:00",
+			minutes:  90,
+			want:     "2026-04-05T03:00:00+02:00",
+		},
+		{
+			name:     "berlin holiday on the switch to standard time",
+			zone:     "Europe/Berlin",
+			hours:    []model.WorkingHours{{Day: time.Sunday, Start: "02:00", End: "03:00"}, {Day: time.Monday, Start: "09:00", End: "17:00"}},
+			holidays: []string{"2026-10-25"},
+			from:     "2026-10-24T12:00:00+02:00",
+			minutes:  30,
+			want:     "2026-10-26T09:30:00+01:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar := model.BusinessCalendar{TimeZone: tt.zone, Hours: tt.hours}
+			for _, date := range tt.holidays {
+				calendar.Holidays = append(calendar.Holidays, model.Holiday{Date: date})
+			}
+			b, err := NewBusinessTime(calendar)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			from, err := time.Parse(time.RFC3339, tt.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := time.Parse(time.RFC3339, tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := b.AddMinutes(from, tt.minutes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("AddMinutes(%s, %d) = %s, want %s", tt.from, tt.minutes, got.Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
+// 2026-06-15 is a Monday
+func TestBusinessTimeAdd(t *testing.T) {
+	weekdays := []model.WorkingHours{}
+	for day := time.Monday; day <= time.Friday; day++ {
+		weekdays = append(weekdays, model.WorkingHours{Day: day, Start: "09:00", End: "17:00"})
+	}
+
+	tests := []struct {
+		name     string
+		holidays []string
+		from     string
+		minutes  int64
+		want     string
+	}{
+		{
+			name:    "within a weekday",
+			from:    "2026-06-15T10:00:00Z",
+			minutes: 120,
+			want:    "2026-06-15T12:00:00Z",
+		},
+		{
+			name:    "until the end of a weekday",
+			from:    "2026-06-15T10:00:00Z",
+			minutes: 420,
+			want:    "2026-06-15T17:00:00Z",
+		},
+		{
+			name:    "across several days",
+			from:    "2026-06-15T16:00:00Z",
+			minutes: 600,
+			want:    "2026-06-17T10:00:00Z",
+		},
+		{
+			name:    "across the weekend",
+			from:    "2026-06-19T16:00:00Z",
+			minutes: 120,
+			want:    "2026-06-22T10:00:00Z",
+		},
+		{
+			name:     "over a holiday",
+			holidays: []string{"2026-06-16"},
+			from:     "2026-06-15T16:00:00Z",
+			minutes:  120,
+			want:     "2026-06-17T10:00:00Z",
+		},
+		{
+			name:     "starting on a holiday",
+			holidays: []string{"2026-06-15"},
+			from:     "2026-06-15T10:00:00Z",
+			minutes:  60,
+			want:     "2026-06-16T10:00:00Z",
+		},
+		{
+			name: "zero minutes in working hours",
+			from: "2026-06-15T10:00:00Z",
+			want: "2026-06-15T10:00:00Z",
+		},
+		{
+			name: "zero minutes on the weekend",
+			from: "2026-06-20T10:00:00Z",
+			want: "2026-06-22T09:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar := model.BusinessCalendar{TimeZone: "UTC", Hours: weekdays}
+			for _, date := range tt.holidays {
+				calendar.Holidays = append(calendar.Holidays, model.Holiday{Date: date})
+			}
+			b, err := NewBusinessTime(calendar)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			from, err := time.Parse(time.RFC3339, tt.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := time.Parse(time.RFC3339, tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := b.AddMinutes(from, tt.minutes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("AddMinutes(%s, %d) = %s, want %s", tt.from, tt.minutes, got.Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
+// one business hour a week cannot cover 1000 hours within businessDaysMax
+func TestBusinessTimeAddExhausted(t *testing.T) {
+	b, err := NewBusinessTime(model.BusinessCalendar{
+		TimeZone: "UTC",
+		Hours:    []model.WorkingHours{{Day: time.Monday, Start: "09:00", End: "10:00"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2026, time.June, 15, 9, 0, 0, 0, time.UTC)
+	if got, err := b.AddMinutes(from, 1000*60); err == nil {
+		t.Errorf("AddMinutes(%s, %d) = %s, want an error", from.Format(time.RFC3339), 1000*60, got.Format(time.RFC3339))
+	}
+
+	// the same calendar covers what fits
+	got, err := b.AddMinutes(from, 2*60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, time.June, 22, 10, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("AddMinutes(%s, %d) = %s, want %s", from.Format(time.RFC3339), 2*60, got.Format(time.RFC3339), want.Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+// RunCheck probes the target of a check once and evaluates its
+// assertions. It only fails by the result, never by an error.
+func RunCheck(check model.Check) (result model.CheckResult) {
+	result.CheckID = check.CheckID
+	result.CreatedAt = time.Now()
+
+	timeout := time.Duration(check.Timeout) * time.Second
+
+	var err error
+	switch check.Type {
+	case model.CheckHTTP:
+		err = probeHTTP(check, timeout, &result)
+	case model.CheckTCP:
+		err = probeTCP(check, timeout, &result)
+	case model.CheckTLS:
+		err = probeTLS(check, timeout, &result)
+	default:
+		err = errors.New("unknown check type: " + string(check.Type))
+	}
+	result.Latency = time.Since(result.CreatedAt).Milliseconds()
+
+	if err == nil && check.MaxLatency > 0 && result.Latency > check.MaxLatency {
+		err = fmt.Errorf("latency %dms above %dms", result.Latency, check.MaxLatency)
+	}
+
+	result.Success = err == nil
+	if err != nil {
+		result.Message = err.Error()
+	}
+	return
+}
+
+func probeHTTP(check model.Check, timeout time.Duration, result *model.CheckResult) error {
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: check.SkipVerify},
+			DisableKeepAlives: true,
+		},
+	}
+
+	method := check.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequest(method, check.Target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "incident_resp-check/1")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		expires := resp.TLS.PeerCertificates[0].NotAfter
+		result.CertExpiresAt = &expires
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, model.CheckBodyMaxBytes))
+	if err != nil {
+		return err
+	}
+
+	if check.ExpectedStatus != 0 {
+		if resp.StatusCode != check.ExpectedStatus {
+			return errors.New("status " + strconv.Itoa(resp.StatusCode) + ", expected " + strconv.Itoa(check.ExpectedStatus))
+		}
+	} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.New("status " + strconv.Itoa(resp.StatusCode) + ", expected 2xx")
+	}
+
+	if check.BodyContains != "" && !bytes.Contains(body, []byte(check.BodyContains)) {
+		return errors.New("body does not contain " + strconv.Quote(check.BodyContains))
+	}
+	return nil
+}
+
+func probeTCP(check model.Check, timeout time.Duration, _ *model.CheckResult) error {
+	conn, err := net.DialTimeout("tcp", check.Target, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func probeTLS(check model.Check, timeout time.Duration, result *model.CheckResult) error {
+	dialer := &net.Dialer{Timeout: timeout}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", check.Target, &tls.Config{InsecureSkipVerify: check.SkipVerify})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return errors.New("no certificate presented")
+	}
+
+	expires := certs[0].NotAfter
+	result.CertExpiresAt = &expires
+
+	left := time.Until(expires)
+	if left < time.Duration(check.ExpiryDays)*24*time.Hour {
+		if left <= 0 {
+			return errors.New("certificate expired at " + expires.UTC().Format(time.RFC3339))
+		}
+		return errors.New("certificate expires in " + strconv.Itoa(int(left.Hours()/24)) + " days at " + expires.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
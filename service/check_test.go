@@ -0,0 +1,212 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+func TestRunCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("status: healthy"))
+	})
+	mux.HandleFunc("/created", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tlsServer := httptest.NewTLSServer(mux)
+	defer tlsServer.Close()
+
+	expiring := httptest.NewUnstartedServer(mux)
+	expiring.TLS = &tls.Config{Certificates: []tls.Certificate{testCertificate(t, time.Now().AddDate(0, 0, 10))}}
+	expiring.StartTLS()
+	defer expiring.Close()
+
+	expired := httptest.NewUnstartedServer(mux)
+	expired.TLS = &tls.Config{Certificates: []tls.Certificate{testCertificate(t, time.Now().AddDate(0, 0, -1))}}
+	expired.StartTLS()
+	defer expired.Close()
+
+	// nothing listens on a closed listener's address
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	refused := listener.Addr().String()
+	listener.Close()
+
+	tests := []struct {
+		name    string
+		check   model.Check
+		success bool
+		message string // contained in the message of a failure
+		status  int
+		cert    bool // certificate expiry recorded
+	}{
+		{
+			name:    "http any 2xx",
+			check:   model.Check{Type: model.CheckHTTP, Target: server.URL + "/created"},
+			success: true,
+			status:  http.StatusCreated,
+		},
+		{
+			name:    "http 5xx",
+			check:   model.Check{Type: model.CheckHTTP, Target: server.URL + "/broken"},
+			message: "status 503, expected 2xx",
+			status:  http.StatusServiceUnavailable,
+		},
+		{
+			name:    "http expected status",
+			check:   model.Check{Type: model.CheckHTTP, Target: server.URL + "/broken", ExpectedStatus: http.StatusServiceUnavailable},
+			success: true,
+			status:  http.StatusServiceUnavailable,
+		},
+		{
+			name:    "http unexpected status",
+			check:   model.Check{Type: model.CheckHTTP, Target: server.URL + "/ok", ExpectedStatus: http.StatusNoContent},
+			message: "status 200, expected 204",
+			status:  http.StatusOK,
+		},
+		{
+			name:    "http body contains",
+			check:   model.Check{Type: model.CheckHTTP, Target: server.URL + "/ok", BodyContains: "healthy"},
+			success: true,
+			status:  http.StatusOK,
+		},
+		{
+			name:    "http body does not contain",
+			check:   model.Check{Type: model.CheckHTTP, Target: server.URL + "/ok", BodyContains: "degraded"},
+			message: `body does not contain "degraded"`,
+			status:  http.StatusOK,
+		},
+		{
+			name:    "http latency",
+			check:   model.Check{Type: model.CheckHTTP, Target: server.URL + "/slow", MaxLatency: 50},
+			message: "above 50ms",
+			status:  http.StatusOK,
+		},
+		{
+			name:    "http connection refused",
+			check:   model.Check{Type: model.CheckHTTP, Target: "http://" + refused},
+			message: "connection refused",
+		},
+		{
+			name:    "https untrusted certificate",
+			check:   model.Check{Type: model.CheckHTTP, Target: tlsServer.URL + "/ok"},
+			message: "certificate",
+		},
+		{
+			name:    "https skip verify",
+			check:   model.Check{Type: model.CheckHTTP, Target: tlsServer.URL + "/ok", SkipVerify: true},
+			success: true,
+			status:  http.StatusOK,
+			cert:    true,
+		},
+		{
+			name:    "tcp open",
+			check:   model.Check{Type: model.CheckTCP, Target: server.Listener.Addr().String()},
+			success: true,
+		},
+		{
+			name:    "tcp connection refused",
+			check:   model.Check{Type: model.CheckTCP, Target: refused},
+			message: "connection refused",
+		},
+		{
+			name:    "tls valid long enough",
+			check:   model.Check{Type: model.CheckTLS, Target: tlsServer.Listener.Addr().String(), SkipVerify: true, ExpiryDays: 30},
+			success: true,
+			cert:    true,
+		},
+		{
+			name:    "tls expires too soon",
+			check:   model.Check{Type: model.CheckTLS, Target: expiring.Listener.Addr().String(), SkipVerify: true, ExpiryDays: 30},
+			message: "certificate expires in",
+			cert:    true,
+		},
+		{
+			name:    "tls expired",
+			check:   model.Check{Type: model.CheckTLS, Target: expired.Listener.Addr().String(), SkipVerify: true},
+			message: "certificate expired at",
+			cert:    true,
+		},
+		{
+			name:    "tls untrusted certificate",
+			check:   model.Check{Type: model.CheckTLS, Target: tlsServer.Listener.Addr().String()},
+			message: "certificate",
+		},
+		{
+			name:    "tls connection refused",
+			check:   model.Check{Type: model.CheckTLS, Target: refused},
+			message: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := tt.check
+			check.Timeout = 5
+
+			result := RunCheck(check)
+
+			if result.Success != tt.success {
+				t.Fatalf("success = %v, want %v (message %q)", result.Success, tt.success, result.Message)
+			}
+			if !strings.Contains(result.Message, tt.message) {
+				t.Errorf("message = %q, want it to contain %q", result.Message, tt.message)
+			}
+			if result.StatusCode != tt.status {
+				t.Errorf("status code = %d, want %d", result.StatusCode, tt.status)
+			}
+			if (result.CertExpiresAt != nil) != tt.cert {
+				t.Errorf("certificate expiry = %v, want recorded %v", result.CertExpiresAt, tt.cert)
+			}
+		})
+	}
+}
+
+// testCertificate - self-signed certificate of 127.0.0.1 valid until notAfter
+func testCertificate(t *testing.T, notAfter time.Time) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "check.test"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    notAfter.AddDate(0, 0, -90),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
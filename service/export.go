@@ -0,0 +1,112 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+// RecordWriter streams records of an export one by one
+type RecordWriter interface {
+	// WriteRecord writes the values of one record, in column order
+	WriteRecord(values []any) error
+	// Flush writes any buffered data to the underlying writer
+	Flush() error
+}
+
+// NewRecordWriter returns a writer for the given export format.
+// CSV exports start with a header line.
+func NewRecordWriter(w io.Writer, format string, columns []string) (RecordWriter, error) {
+	switch format {
+	case model.ExportJSONL:
+		return &jsonlWriter{w: w, columns: columns}, nil
+	default:
+		// RFC 4180: CRLF line breaks, fields with quotes,
+		// separators or line breaks are quoted
+		cw := csv.NewWriter(w)
+		cw.UseCRLF = true
+		if err := cw.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw}, nil
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) WriteRecord(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = exportString(value)
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlWriter struct {
+	w       io.Writer
+	columns []string
+	buf     bytes.Buffer
+}
+
+// WriteRecord writes one JSON object per line, keys in column order
+func (j *jsonlWriter) WriteRecord(values []any) error {
+	j.buf.Reset()
+	j.buf.WriteByte('{')
+	for i, column := range j.columns {
+		if i > 0 {
+			j.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		j.buf.Write(key)
+		j.buf.WriteByte(':')
+		j.buf.Write(value)
+	}
+	j.buf.WriteString("}\n")
+
+	_, err := j.w.Write(j.buf.Bytes())
+	return err
+}
+
+func (j *jsonlWriter) Flush() error {
+	return nil
+}
+
+// exportString formats a value for a CSV field
+func exportString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	case *uint64:
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(*v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+// ServiceGraph - in-memory adjacency lists of the service dependency graph
+type ServiceGraph struct {
+	upstream   map[uint64][]uint64 // service -> its dependencies
+	downstream map[uint64][]uint64 // service -> its dependents
+}
+
+// NewServiceGraph builds the graph from dependency edges
+func NewServiceGraph(edges []model.ServiceDependency) *ServiceGraph {
+	g := &ServiceGraph{
+		upstream:   make(map[uint64][]uint64),
+		downstream: make(map[uint64][]uint64),
+	}
+
+	for _, edge := range edges {
+		g.upstream[edge.ServiceID] = append(g.upstream[edge.ServiceID], edge.DependencyID)
+		g.downstream[edge.DependencyID] = append(g.downstream[edge.DependencyID], edge.ServiceID)
+	}
+
+	return g
+}
+
+// Walk performs a breadth-first walk from the start services in the
+// given direction and returns every reached service (start services
+// excluded) with its shortest distance. A maxDepth of 0 means no limit.
+func (g *ServiceGraph) Walk(start []uint64, direction string, maxDepth int) []model.DependencyNode {
+	adjacency := g.upstream
+	if direction == model.DependencyDownstream {
+		adjacency = g.downstream
+	}
+
+	nodes := []model.DependencyNode{}
+	visited := make(map[uint64]bool, len(start))
+	queue := make([]model.DependencyNode, 0, len(start))
+
+	for _, id := range start {
+		if !visited[id] {
+			visited[id] = true
+			queue = append(queue, model.DependencyNode{ServiceID: id})
+		}
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if maxDepth > 0 && current.Depth >= maxDepth {
+			continue
+		}
+
+		next := append([]uint64(nil), adjacency[current.ServiceID]...)
+		sort.Slice(next, func(i, j int) bool { return next[i] < next[j] })
+
+		for _, id := range next {
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+
+			node := model.DependencyNode{
+				ServiceID: id,
+				Depth:     current.Depth + 1,
+				Via:       current.ServiceID,
+			}
+			nodes = append(nodes, node)
+			queue = append(queue, node)
+		}
+	}
+
+	return nodes
+}
+
+// PathTo returns a dependency path from one service to another
+// following upstream edges, or nil when the target is unreachable
+func (g *ServiceGraph) PathTo(from, to uint64) []uint64 {
+	prev := map[uint64]uint64{from: from}
+	queue := []uint64{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == to {
+			path := []uint64{to}
+			for current != from {
+				current = prev[current]
+				path = append([]uint64{current}, path...)
+			}
+			return path
+		}
+
+		for _, id := range g.upstream[current] {
+			if _, ok := prev[id]; !ok {
+				prev[id] = current
+				queue = append(queue, id)
+			}
+		}
+	}
+
+	return nil
+}
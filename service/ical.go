@@ -0,0 +1,191 @@
+package service
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+// iCalendar constants
+//
+// https://datatracker.ietf.org/doc/html/rfc5545
+const (
+	icalProdID     string = "-//incident_resp//on-call schedule//EN"
+	icalTimeFormat string = "20060102T150405Z"
+	icalDateFormat string = "20060102"
+	icalLineLimit  int    = 75
+)
+
+// RenderICalendar renders the given shifts as an RFC 5545
+// calendar with one VEVENT per shift
+func RenderICalendar(calName string, shifts []model.Shift) string {
+	var b strings.Builder
+	stamp := time.Now().UTC().Format(icalTimeFormat)
+
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:"+icalProdID)
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(calName))
+
+	for _, shift := range shifts {
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, fmt.Sprintf(
+			"UID:shift-%d-%d-%d@incident-resp",
+			shift.ScheduleID,
+			shift.AuthID,
+			shift.Start.Unix(),
+		))
+		writeICalLine(&b, "DTSTAMP:"+stamp)
+		writeICalLine(&b, "DTSTART:"+shift.Start.UTC().Format(icalTimeFormat))
+		writeICalLine(&b, "DTEND:"+shift.End.UTC().Format(icalTimeFormat))
+		writeICalLine(&b, "SUMMARY:"+escapeICalText("On call: "+shift.ScheduleName))
+		writeICalLine(&b, "TRANSP:OPAQUE")
+		writeICalLine(&b, "END:VEVENT")
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+
+	return b.String()
+}
+
+// escapeICalText escapes a TEXT property value (RFC 5545 section 3.3.11)
+func escapeICalText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// writeICalLine writes a content line terminated by CRLF, folding
+// it at 75 octets without splitting a UTF-8 sequence
+// (RFC 5545 section 3.1)
+func writeICalLine(b *strings.Builder, line string) {
+	limit := icalLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && (line[cut]&0xC0) == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space
+		limit = icalLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// ParseICalendarHolidays reads the events of an RFC 5545 calendar as
+// holidays, one per day of multi-day events. Timed events count for
+// the day they start, recurrence rules are not expanded.
+func ParseICalendarHolidays(r io.Reader) ([]model.Holiday, error) {
+	lines, err := readICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, errors.New("not an iCalendar document")
+	}
+
+	holidays := []model.Holiday{}
+	var inEvent bool
+	var summary string
+	var start, end time.Time
+
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, params, _ := strings.Cut(strings.ToUpper(name), ";")
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			inEvent = true
+			summary, start, end = "", time.Time{}, time.Time{}
+
+		case !inEvent:
+			continue
+
+		case name == "SUMMARY":
+			summary = unescapeICalText(value)
+
+		case name == "DTSTART" || name == "DTEND":
+			// dates, or date-times whose date is taken as is
+			if len(value) < len(icalDateFormat) {
+				return nil, errors.New("invalid " + name + ": " + value)
+			}
+			date, err := time.Parse(icalDateFormat, value[:len(icalDateFormat)])
+			if err != nil {
+				return nil, errors.New("invalid " + name + ": " + value)
+			}
+			if name == "DTSTART" {
+				start = date
+			} else if strings.Contains(params, "VALUE=DATE") || len(value) == len(icalDateFormat) {
+				end = date // exclusive
+			}
+
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			inEvent = false
+			if start.IsZero() {
+				return nil, errors.New("event without DTSTART: " + summary)
+			}
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for day := start; day.Before(end) && day.Before(start.AddDate(1, 0, 0)); day = day.AddDate(0, 0, 1) {
+				holidays = append(holidays, model.Holiday{
+					Date: day.Format(model.HolidayDateLayout),
+					Name: summary,
+				})
+			}
+		}
+	}
+
+	return holidays, nil
+}
+
+// readICalLines splits a calendar into unfolded content lines
+// (RFC 5545 section 3.1)
+func readICalLines(r io.Reader) ([]string, error) {
+	lines := []string{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// unescapeICalText reverts escapeICalText
+func unescapeICalText(s string) string {
+	r := strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	)
+	return r.Replace(s)
+}
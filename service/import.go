@@ -0,0 +1,145 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Import formats
+const (
+	ImportCSV  string = "csv"
+	ImportJSON string = "json"
+)
+
+// ParseImportRecords reads a CSV document with a header line or a
+// JSON array of objects into records keyed by column name. Mapping
+// renames source columns, unmapped columns keep their name.
+func ParseImportRecords(r io.Reader, format string, mapping map[string]string, maxRows int) ([]map[string]string, error) {
+	var records []map[string]string
+	var err error
+
+	switch format {
+	case ImportCSV:
+		records, err = parseCSVRecords(r, maxRows)
+	case ImportJSON:
+		records, err = parseJSONRecords(r, maxRows)
+	default:
+		return nil, errors.New("unsupported import format: " + format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mapping) == 0 {
+		return records, nil
+	}
+
+	for i, record := range records {
+		mapped := make(map[string]string, len(record))
+		for column, value := range record {
+			if target, ok := mapping[column]; ok {
+				column = target
+			}
+			mapped[column] = value
+		}
+		records[i] = mapped
+	}
+
+	return records, nil
+}
+
+// ParseImportMapping parses "source:target" pairs separated by commas
+func ParseImportMapping(s string) (map[string]string, error) {
+	mapping := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		source, target, ok := strings.Cut(pair, ":")
+		source, target = strings.TrimSpace(source), strings.TrimSpace(target)
+		if !ok || source == "" || target == "" {
+			return nil, errors.New("invalid mapping: " + pair)
+		}
+		mapping[source] = target
+	}
+	return mapping, nil
+}
+
+func parseCSVRecords(r io.Reader, maxRows int) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return []map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	records := []map[string]string{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(records) == maxRows {
+			return nil, fmt.Errorf("import is limited to %d rows", maxRows)
+		}
+
+		record := make(map[string]string, len(header))
+		for i, column := range header {
+			record[column] = row[i]
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func parseJSONRecords(r io.Reader, maxRows int) ([]map[string]string, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var rows []map[string]any
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of objects: %w", err)
+	}
+
+	if len(rows) > maxRows {
+		return nil, fmt.Errorf("import is limited to %d rows", maxRows)
+	}
+
+	records := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		record := make(map[string]string, len(row))
+		for column, value := range row {
+			switch v := value.(type) {
+			case nil:
+				record[column] = ""
+			case string:
+				record[column] = v
+			case json.Number:
+				record[column] = v.String()
+			case bool:
+				record[column] = fmt.Sprint(v)
+			default:
+				return nil, fmt.Errorf("column %q must hold a scalar value", column)
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
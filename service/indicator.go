@@ -0,0 +1,262 @@
+package service
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+// defanged notations used in reports to keep indicators from being
+// clickable, hxxp://evil[.]com
+var (
+	defangedDot   = regexp.MustCompile(`(?i)\s?[\[({](\.|dot)[\])}]\s?`)
+	defangedAt    = regexp.MustCompile(`(?i)\s?[\[({](@|at)[\])}]\s?`)
+	defangedColon = regexp.MustCompile(`\[:\]|\[://\]`)
+	defangedHTTP  = regexp.MustCompile(`(?i)\bh(?:xx|xt|tx)p(s?)\b`)
+	defangedFTP   = regexp.MustCompile(`(?i)\bfxp\b`)
+)
+
+// patterns of the indicators extracted from text, validated afterwards
+var (
+	urlPattern    = regexp.MustCompile(`(?i)\b(?:https?|ftp)://[^\s<>"'` + "`" + `]+`)
+	emailPattern  = regexp.MustCompile(`(?i)\b[a-z0-9._%+\-]+@(?:[a-z0-9\-]+\.)+[a-z]{2,24}\b`)
+	ipv4Pattern   = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6Word      = regexp.MustCompile(`(?i)[0-9a-z_]*(?::[0-9a-z_]*)+`)
+	hashPattern   = regexp.MustCompile(`(?i)\b[0-9a-f]{32,128}\b`)
+	domainPattern = regexp.MustCompile(`(?i)\b(?:[a-z0-9](?:[a-z0-9\-]{0,61}[a-z0-9])?\.)+[a-z][a-z0-9\-]{1,23}\b`)
+)
+
+// ipv6Pattern - IPv6 address written in full or with :: between groups,
+// std::string or deadbeef::cafe are words rather than addresses
+var ipv6Pattern = regexp.MustCompile(`(?i)^(?:(?:[0-9a-f]{1,4}:){7}[0-9a-f]{1,4}|(?:[0-9a-f]{1,4}:)+(?::[0-9a-f]{1,4})+)$`)
+
+// fileExtensions are not taken for top-level domains when extracting,
+// report.pdf is a file rather than a domain
+var fileExtensions = []string{
+	"bat", "bin", "cfg", "conf", "csv", "dat", "dll", "doc", "docx", "exe", "gif", "htm", "html",
+	"ini", "jpeg", "jpg", "js", "json", "lnk", "log", "pdf", "php", "png", "ps1", "sys", "tmp",
+	"txt", "vbs", "xls", "xlsx", "xml", "yaml", "yml",
+}
+
+// hashLengths - hex digits of MD5, SHA-1, SHA-256 and SHA-512
+var hashLengths = []int{32, 40, 64, 128}
+
+// Refang undoes the usual defanging of indicators
+func Refang(text string) string {
+	text = defangedDot.ReplaceAllString(text, ".")
+	text = defangedAt.ReplaceAllString(text, "@")
+	text = defangedColon.ReplaceAllStringFunc(text, func(s string) string { return strings.Trim(s, "[]") })
+	text = defangedHTTP.ReplaceAllString(text, "http$1")
+	return defangedFTP.ReplaceAllString(text, "ftp")
+}
+
+// ParseIndicator validates and normalizes an indicator, its type is
+// detected when empty. msg explains why it is not acceptable.
+func ParseIndicator(t model.IndicatorType, value string) (model.Indicator, string) {
+	value = strings.TrimSpace(Refang(value))
+	if value == "" {
+		return model.Indicator{}, "indicator value is required"
+	}
+	if len(value) > model.IndicatorValueMax {
+		return model.Indicator{}, "indicator value is too long"
+	}
+
+	if t == "" {
+		t = detectIndicator(value)
+		if t == "" {
+			return model.Indicator{}, "'" + value + "' is not an IP, domain, URL, hash or email address"
+		}
+	}
+
+	var normalized string
+	switch t {
+	case model.IndicatorIP:
+		normalized = normalizeIP(value)
+	case model.IndicatorDomain:
+		normalized = normalizeDomain(value)
+	case model.IndicatorURL:
+		normalized = normalizeURL(value)
+	case model.IndicatorHash:
+		normalized = normalizeHash(value)
+	case model.IndicatorEmail:
+		normalized = normalizeEmail(value)
+	default:
+		return model.Indicator{}, "type must be ip, domain, url, hash or email"
+	}
+	if normalized == "" {
+		return model.Indicator{}, "'" + value + "' is not a valid " + string(t)
+	}
+
+	return model.Indicator{Type: t, Value: normalized}, ""
+}
+
+// ExtractIndicators finds the indicators in free text such as an alert
+// or a threat report, defanged ones included. Duplicates are dropped.
+// URLs add their host as well, the domains of email addresses are not
+// extracted on their own.
+func ExtractIndicators(text string) []model.Indicator {
+	text = Refang(text)
+
+	indicators := []model.Indicator{}
+	add := func(t model.IndicatorType, value string) {
+		indicator, msg := ParseIndicator(t, value)
+		if msg == "" && !slices.Contains(indicators, indicator) {
+			indicators = append(indicators, indicator)
+		}
+	}
+	// blank out what was found so that later patterns skip it
+	consume := func(pattern *regexp.Regexp, found func(string)) {
+		text = pattern.ReplaceAllStringFunc(text, func(match string) string {
+			found(match)
+			return strings.Repeat(" ", len(match))
+		})
+	}
+
+	consume(urlPattern, func(match string) {
+		match = strings.TrimRight(match, ".,;:!?)]}'\"")
+		add(model.IndicatorURL, match)
+		if u, err := url.Parse(match); err == nil {
+			host := u.Hostname()
+			if net.ParseIP(host) != nil {
+				add(model.IndicatorIP, host)
+			} else {
+				add(model.IndicatorDomain, host)
+			}
+		}
+	})
+	consume(emailPattern, func(match string) { add(model.IndicatorEmail, match) })
+	consume(ipv4Pattern, func(match string) { add(model.IndicatorIP, match) })
+	// only whole words are addresses, the others are left to the later
+	// patterns
+	text = ipv6Word.ReplaceAllStringFunc(text, func(match string) string {
+		if !ipv6Pattern.MatchString(match) {
+			return match
+		}
+		add(model.IndicatorIP, match)
+		return strings.Repeat(" ", len(match))
+	})
+	consume(hashPattern, func(match string) { add(model.IndicatorHash, match) })
+	consume(domainPattern, func(match string) {
+		tld := match[strings.LastIndex(match, ".")+1:]
+		if !slices.Contains(fileExtensions, strings.ToLower(tld)) {
+			add(model.IndicatorDomain, match)
+		}
+	})
+
+	return indicators
+}
+
+// detectIndicator returns the type of the value, empty when unknown
+func detectIndicator(value string) model.IndicatorType {
+	switch {
+	case strings.Contains(value, "://"):
+		return model.IndicatorURL
+	case strings.Contains(value, "@"):
+		return model.IndicatorEmail
+	case normalizeIP(value) != "":
+		return model.IndicatorIP
+	case normalizeHash(value) != "":
+		return model.IndicatorHash
+	case normalizeDomain(value) != "":
+		return model.IndicatorDomain
+	}
+	return ""
+}
+
+func normalizeIP(value string) string {
+	ip := net.ParseIP(strings.Trim(value, "[]"))
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// normalizeDomain lowercases a host name of at least two labels
+func normalizeDomain(value string) string {
+	domain := strings.TrimSuffix(strings.ToLower(value), ".")
+	if len(domain) > 253 || net.ParseIP(domain) != nil {
+		return ""
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return ""
+	}
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return ""
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return ""
+			}
+		}
+	}
+
+	// top-level domains are not numeric
+	tld := labels[len(labels)-1]
+	if strings.Trim(tld, "0123456789") == "" {
+		return ""
+	}
+	return domain
+}
+
+// normalizeURL lowercases the scheme and host of an http, https or ftp URL
+func normalizeURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "ftp" {
+		return ""
+	}
+
+	host := u.Hostname()
+	if normalized := normalizeIP(host); normalized != "" {
+		host = normalized
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+	} else if host = normalizeDomain(host); host == "" {
+		return ""
+	}
+	if port := u.Port(); port != "" {
+		host += ":" + port
+	}
+	u.Host = host
+
+	return u.String()
+}
+
+func normalizeHash(value string) string {
+	hash := strings.ToLower(value)
+	if !slices.Contains(hashLengths, len(hash)) {
+		return ""
+	}
+	for _, r := range hash {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return ""
+		}
+	}
+	return hash
+}
+
+// normalizeEmail lowercases a bare address of a domain
+func normalizeEmail(value string) string {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name != "" || address.Address != value {
+		return ""
+	}
+
+	local, domain, _ := strings.Cut(address.Address, "@")
+	if domain = normalizeDomain(domain); domain == "" {
+		return ""
+	}
+	return strings.ToLower(local) + "@" + domain
+}
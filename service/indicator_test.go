@@ -0,0 +1,76 @@
+package service
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractIndicatorsIPv6(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string // type:value
+	}{
+		{
+			name: "compressed",
+			text: "beacon to 2001:db8::1 every minute",
+			want: []string{"ip:2001:db8::1"},
+		},
+		{
+			name: "full",
+			text: "from 2001:0db8:0000:0000:0000:ff00:0042:8329",
+			want: []string{"ip:2001:db8::ff00:42:8329"},
+		},
+		{
+			name: "end of a sentence",
+			text: "seen from 2001:db8::1.",
+			want: []string{"ip:2001:db8::1"},
+		},
+		{
+			name: "with port",
+			text: "[2001:db8::2]:443",
+			want: []string{"ip:2001:db8::2"},
+		},
+		{
+			name: "defanged",
+			text: "2001[:]db8::3",
+			want: []string{"ip:2001:db8::3"},
+		},
+		{
+			name: "c++ identifier",
+			text: "crash in std::basic_string",
+		},
+		{
+			name: "groups longer than four digits",
+			text: "deadbeef::cafe",
+		},
+		{
+			name: "empty group at the start",
+			text: "::1",
+		},
+		{
+			name: "time and hardware address",
+			text: "at 12:30:45 from 00:11:22:33:44:55",
+		},
+		{
+			name: "domain with port",
+			text: "callback to evil.example.com:8080",
+			want: []string{"domain:evil.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, indicator := range ExtractIndicators(tt.text) {
+				got = append(got, string(indicator.Type)+":"+indicator.Value)
+			}
+			if tt.want == nil {
+				tt.want = []string{}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ExtractIndicators(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
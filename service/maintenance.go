@@ -0,0 +1,249 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+// recurrencePeriodsMax bounds the days, weeks or months a
+// recurrence is expanded over
+const recurrencePeriodsMax = 100000
+
+// recurrence frequencies
+const (
+	freqDaily   = "DAILY"
+	freqWeekly  = "WEEKLY"
+	freqMonthly = "MONTHLY"
+)
+
+var rruleDays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Recurrence - the subset of RFC 5545 recurrence rules maintenance
+// windows repeat by: FREQ DAILY, WEEKLY or MONTHLY with INTERVAL,
+// COUNT or UNTIL, BYDAY for weekly and BYMONTHDAY for monthly rules
+type Recurrence struct {
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	untilDate  bool // UNTIL is a date, inclusive in the local time zone
+	byDay      []time.Weekday
+	byMonthDay []int
+}
+
+// ParseRRule parses a recurrence rule, with or without "RRULE:"
+func ParseRRule(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	r := &Recurrence{interval: 1}
+
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, errors.New("malformed rrule part: " + part)
+		}
+		value = strings.ToUpper(value)
+
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			if value != freqDaily && value != freqWeekly && value != freqMonthly {
+				return nil, errors.New("rrule FREQ must be DAILY, WEEKLY or MONTHLY")
+			}
+			r.freq = value
+
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, errors.New("rrule INTERVAL must be a positive number")
+			}
+			r.interval = n
+
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, errors.New("rrule COUNT must be a positive number")
+			}
+			r.count = n
+
+		case "UNTIL":
+			if t, err := time.Parse(icalTimeFormat, value); err == nil {
+				r.until = t
+			} else if t, err := time.Parse(icalDateFormat, value); err == nil {
+				r.until = t
+				r.untilDate = true
+			} else {
+				return nil, errors.New("rrule UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ")
+			}
+
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleDays[day]
+				if !ok {
+					return nil, errors.New("rrule BYDAY must list SU, MO, TU, WE, TH, FR or SA")
+				}
+				r.byDay = append(r.byDay, weekday)
+			}
+
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n < 1 || n > 31 {
+					return nil, errors.New("rrule BYMONTHDAY must list days 1 to 31")
+				}
+				r.byMonthDay = append(r.byMonthDay, n)
+			}
+
+		default:
+			return nil, errors.New("unsupported rrule part: " + name)
+		}
+	}
+
+	switch {
+	case r.freq == "":
+		return nil, errors.New("rrule FREQ is required")
+	case r.count > 0 && !r.until.IsZero():
+		return nil, errors.New("rrule COUNT and UNTIL are exclusive")
+	case len(r.byDay) > 0 && r.freq != freqWeekly:
+		return nil, errors.New("rrule BYDAY needs FREQ=WEEKLY")
+	case len(r.byMonthDay) > 0 && r.freq != freqMonthly:
+		return nil, errors.New("rrule BYMONTHDAY needs FREQ=MONTHLY")
+	}
+
+	// weeks start on Monday
+	sort.Slice(r.byDay, func(i, j int) bool { return (r.byDay[i]+6)%7 < (r.byDay[j]+6)%7 })
+	sort.Ints(r.byMonthDay)
+
+	return r, nil
+}
+
+// each calls yield with the occurrences of the recurrence in order,
+// the first one being start, until yield returns false. Occurrences
+// keep the wall clock time of start in its location.
+func (r *Recurrence) each(start time.Time, yield func(time.Time) bool) {
+	loc := start.Location()
+	y, m, d := start.Date()
+	hour, minute, sec := start.Clock()
+
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, minute, sec, start.Nanosecond(), loc)
+	}
+
+	until := r.until
+	if r.untilDate {
+		uy, um, ud := r.until.Date()
+		until = time.Date(uy, um, ud, 23, 59, 59, 0, loc)
+	}
+
+	byDay := r.byDay
+	if len(byDay) == 0 {
+		byDay = []time.Weekday{start.Weekday()}
+	}
+	byMonthDay := r.byMonthDay
+	if len(byMonthDay) == 0 {
+		byMonthDay = []int{d}
+	}
+
+	n := 0
+	emit := func(t time.Time) bool {
+		if t.Before(start) {
+			return true
+		}
+		if r.count > 0 && n >= r.count {
+			return false
+		}
+		if !until.IsZero() && t.After(until) {
+			return false
+		}
+		n++
+		return yield(t)
+	}
+
+	for p := 0; p < recurrencePeriodsMax; p++ {
+		step := p * r.interval
+
+		switch r.freq {
+		case freqDaily:
+			if !emit(at(y, m, d+step)) {
+				return
+			}
+
+		case freqWeekly:
+			monday := d - (int(start.Weekday())+6)%7 + 7*step
+			for _, day := range byDay {
+				if !emit(at(y, m, monday+(int(day)+6)%7)) {
+					return
+				}
+			}
+
+		case freqMonthly:
+			first := time.Date(y, m+time.Month(step), 1, 0, 0, 0, 0, loc)
+			days := first.AddDate(0, 1, -1).Day()
+			for _, day := range byMonthDay {
+				// months without the day are skipped
+				if day > days {
+					continue
+				}
+				if !emit(at(first.Year(), first.Month(), day)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// MaintenanceOccurrences returns up to limit occurrences of a
+// maintenance window which overlap the time from from to to
+func MaintenanceOccurrences(window model.MaintenanceWindow, from, to time.Time, limit int) ([]model.MaintenanceOccurrence, error) {
+	occurrences := []model.MaintenanceOccurrence{}
+
+	if window.RRule == "" {
+		if window.StartsAt.Before(to) && window.EndsAt.After(from) {
+			occurrences = append(occurrences, model.MaintenanceOccurrence{Start: window.StartsAt, End: window.EndsAt})
+		}
+		return occurrences, nil
+	}
+
+	loc, err := time.LoadLocation(window.TimeZone)
+	if err != nil {
+		return nil, errors.New("unknown time zone: " + window.TimeZone)
+	}
+	recurrence, err := ParseRRule(window.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := window.EndsAt.Sub(window.StartsAt)
+	recurrence.each(window.StartsAt.In(loc), func(start time.Time) bool {
+		if !start.Before(to) {
+			return false
+		}
+		if end := start.Add(duration); end.After(from) {
+			occurrences = append(occurrences, model.MaintenanceOccurrence{Start: start, End: end})
+		}
+		return len(occurrences) < limit
+	})
+
+	return occurrences, nil
+}
+
+// MaintenanceActive reports whether an occurrence of a maintenance
+// window covers now
+func MaintenanceActive(window model.MaintenanceWindow, now time.Time) (bool, error) {
+	occurrences, err := MaintenanceOccurrences(window, now, now.Add(time.Nanosecond), 1)
+	if err != nil {
+		return false, err
+	}
+	return len(occurrences) > 0, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+// ComputeShifts returns all shifts of the schedule that overlap
+// the window [from, to).
+//
+// When the shift length is a whole number of days, handovers are
+// calculated in the schedule's time zone so that they keep the same
+// wall-clock time across DST changes.
+func ComputeShifts(schedule model.Schedule, from, to time.Time) []model.Shift {
+	shifts := []model.Shift{}
+
+	if len(schedule.Members) == 0 || schedule.ShiftHours == 0 || !from.Before(to) {
+		return shifts
+	}
+
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	members := make([]model.ScheduleMember, len(schedule.Members))
+	copy(members, schedule.Members)
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].Position < members[j].Position
+	})
+
+	start := schedule.RotationStart.In(loc)
+	shiftLen := time.Duration(schedule.ShiftHours) * time.Hour
+
+	boundary := func(k int64) time.Time {
+		if schedule.ShiftHours%24 == 0 {
+			return start.AddDate(0, 0, int(k)*int(schedule.ShiftHours/24))
+		}
+		return start.Add(time.Duration(k) * shiftLen)
+	}
+
+	// first shift overlapping the window
+	var k int64
+	if from.After(start) {
+		k = int64(from.Sub(start)/shiftLen) - 1
+		if k < 0 {
+			k = 0
+		}
+		for !boundary(k + 1).After(from) {
+			k++
+		}
+	}
+
+	for {
+		shiftStart := boundary(k)
+		if !shiftStart.Before(to) {
+			break
+		}
+
+		member := members[k%int64(len(members))]
+		shifts = append(shifts, model.Shift{
+			ScheduleID:   schedule.ScheduleID,
+			ScheduleName: schedule.Name,
+			AuthID:       member.AuthID,
+			Start:        shiftStart.UTC(),
+			End:          boundary(k + 1).UTC(),
+		})
+		k++
+	}
+
+	return shifts
+}
+
+// OnCallAt returns who is on call in the schedule at t, false when
+// nobody is (no members or before the rotation starts)
+func OnCallAt(schedule model.Schedule, t time.Time) (uint64, bool) {
+	shifts := ComputeShifts(schedule, t, t.Add(time.Nanosecond))
+	if len(shifts) == 0 {
+		return 0, false
+	}
+	return shifts[0].AuthID, true
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+// postmortemTimeFormat - timestamps in postmortem documents
+const postmortemTimeFormat string = "2006-01-02 15:04 UTC"
+
+// TimelineExcerpt renders incident events as a Markdown list
+func TimelineExcerpt(events []model.IncidentEvent) string {
+	var b strings.Builder
+	for _, event := range events {
+		fmt.Fprintf(&b, "- **%s** %s\n", event.CreatedAt.UTC().Format(postmortemTimeFormat), event.Message)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// ActionItemList renders the follow-up tasks as a Markdown task list
+func ActionItemList(tasks []model.Task) string {
+	var b strings.Builder
+	for _, task := range tasks {
+		check := " "
+		if task.Status == model.TaskStatusDone {
+			check = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] %s (%s priority", check, task.Title, task.Priority)
+		if task.DueAt != nil {
+			fmt.Fprintf(&b, ", due %s", task.DueAt.UTC().Format(time.DateOnly))
+		}
+		b.WriteString(")\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// RenderPostmortemMarkdown renders a postmortem as a Markdown document
+func RenderPostmortemMarkdown(pm model.Postmortem, incident model.Incident) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", pm.Title)
+	fmt.Fprintf(&b, "| | |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Incident | #%d %s |\n", incident.IncidentID, escapeMarkdownCell(incident.Title))
+	fmt.Fprintf(&b, "| Severity | %s |\n", incident.Severity)
+	fmt.Fprintf(&b, "| Status | %s |\n", pm.Status)
+	if pm.PublishedAt != nil {
+		fmt.Fprintf(&b, "| Published | %s |\n", pm.PublishedAt.UTC().Format(postmortemTimeFormat))
+	}
+
+	sections := append([]model.PostmortemSection(nil), pm.Sections...)
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Position < sections[j].Position
+	})
+
+	for _, section := range sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.Title)
+		content := strings.TrimSpace(section.Content)
+		if content == "" {
+			content = "_Not provided._"
+		}
+		b.WriteString(content + "\n")
+	}
+
+	approved := []string{}
+	for _, review := range pm.Reviews {
+		if review.Approved {
+			approved = append(approved, fmt.Sprintf("user %d", review.AuthID))
+		}
+	}
+	if len(approved) > 0 {
+		fmt.Fprintf(&b, "\n---\n\nApproved by %s\n", strings.Join(approved, ", "))
+	}
+
+	return b.String()
+}
+
+// escapeMarkdownCell keeps a value inside one table cell
+func escapeMarkdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r", " ", "\n", " ").Replace(s)
+}
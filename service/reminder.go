@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/pilinux/gorest/lib"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// StartTaskReminders emails the assignees of overdue tasks at the
+// configured interval. It blocks, run it in its own goroutine.
+func StartTaskReminders() {
+	interval := time.Duration(config.GetConfig().EmailConf.TaskReminderInterval) * time.Second
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := RemindOverdueTasks(time.Now()); err != nil {
+			log.WithError(err).Error("error code: 3510.1")
+		}
+		<-ticker.C
+	}
+}
+
+// RemindOverdueTasks sends one reminder per overdue task and repeats
+// it at most every model.TaskReminderPeriod. It returns the number of
+// reminders delivered.
+func RemindOverdueTasks(now time.Time) (int, error) {
+	db := database.GetDB()
+
+	var tasks []model.Task
+
+	err := db.Where("status <> ? AND assigned_to <> 0 AND due_at < ?", model.TaskStatusDone, now).
+		Where("reminded_at IS NULL OR reminded_at < ?", now.Add(-model.TaskReminderPeriod)).
+		Find(&tasks).Error
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, task := range tasks {
+		var auth model.Auth
+		if err := db.First(&auth, task.AssignedTo).Error; err != nil {
+			log.WithError(err).Error("error code: 3510.2")
+			continue
+		}
+
+		email := auth.Email
+		if email == "" && auth.EmailCipher != "" {
+			email, err = DecryptEmail(auth.EmailNonce, auth.EmailCipher)
+			if err != nil {
+				log.WithError(err).Error("error code: 3510.3")
+				continue
+			}
+		}
+
+		ok, err := SendTaskReminder(email, task)
+		if err != nil {
+			log.WithError(err).Error("error code: 3510.4")
+			continue
+		}
+		if !ok {
+			// email service not configured
+			return sent, nil
+		}
+
+		if err := db.Model(&task).Update("reminded_at", now).Error; err != nil {
+			log.WithError(err).Error("error code: 3510.5")
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// SendTaskReminder emails an overdue task reminder to its assignee
+//
+// {true, nil} => email delivered successfully
+//
+// {false, nil} => reminders not configured
+//
+// {false, error} => email delivery failed
+func SendTaskReminder(email string, task model.Task) (bool, error) {
+	appConfig := config.GetConfig()
+
+	if !config.IsTaskReminder() {
+		return false, nil
+	}
+
+	if appConfig.EmailConf.Provider != "postmark" {
+		return false, errors.New(
+			"email delivery service provider: '" + appConfig.EmailConf.Provider + "' is unknown",
+		)
+	}
+
+	htmlModel := lib.HTMLModel(lib.StrArrHTMLModel(appConfig.EmailConf.HTMLModel))
+	htmlModel["task_id"] = task.TaskID
+	htmlModel["task_title"] = task.Title
+	htmlModel["task_priority"] = string(task.Priority)
+	htmlModel["incident_id"] = task.IncidentID
+	if task.DueAt != nil {
+		htmlModel["task_due_at"] = task.DueAt.UTC().Format(time.RFC3339)
+	}
+
+	params := PostmarkParams{}
+	params.ServerToken = appConfig.EmailConf.APIToken
+	params.TemplateID = appConfig.EmailConf.TaskReminderTemplateID
+	params.From = appConfig.EmailConf.AddrFrom
+	params.To = email
+	params.Tag = appConfig.EmailConf.TaskReminderTag
+	params.TrackOpens = appConfig.EmailConf.TrackOpens
+	params.TrackLinks = appConfig.EmailConf.TrackLinks
+	params.MessageStream = appConfig.EmailConf.DeliveryType
+	params.HTMLModel = htmlModel
+
+	res, err := Postmark(params)
+	if err != nil {
+		return false, err
+	}
+
+	if res.Message != "OK" {
+		return false, errors.New("email delivery failed")
+	}
+
+	return true, nil
+}
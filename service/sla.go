@@ -0,0 +1,184 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/database"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/pilinux/gorest/lib"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// StartSLAChecker raises SLA alerts at the configured interval.
+// It blocks, run it in its own goroutine.
+func StartSLAChecker() {
+	interval := time.Duration(config.GetConfig().Priority.SLACheckInterval) * time.Second
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := CheckSLAs(time.Now()); err != nil {
+			log.WithError(err).Error("error code: 4410.1")
+		}
+		<-ticker.C
+	}
+}
+
+// slaTarget - one SLA target of an incident
+type slaTarget struct {
+	name     string
+	due      *time.Time
+	reached  *time.Time
+	atRisk   model.SLAAlert
+	breached model.SLAAlert
+}
+
+// CheckSLAs records an at risk and a breached event once per SLA
+// target of the open incidents and emails them to the assignee.
+// It returns the number of alerts raised.
+func CheckSLAs(now time.Time) (int, error) {
+	db := database.GetDB()
+
+	var incidents []model.Incident
+
+	err := db.Where("status <> ? AND duplicate_of IS NULL", model.Closed).
+		Where("(acknowledge_due_at IS NOT NULL AND acknowledged_at IS NULL) OR resolve_due_at IS NOT NULL").
+		Find(&incidents).Error
+	if err != nil {
+		return 0, err
+	}
+
+	raised := 0
+	for _, incident := range incidents {
+		targets := []slaTarget{
+			{"acknowledgement", incident.AcknowledgeDueAt, incident.AcknowledgedAt, model.SLAAcknowledgeAtRisk, model.SLAAcknowledgeBreached},
+			{"resolution", incident.ResolveDueAt, incident.ResolvedAt, model.SLAResolveAtRisk, model.SLAResolveBreached},
+		}
+
+		alerts := incident.SLAAlerts
+		for _, target := range targets {
+			state := model.SLATargetState(incident.CreatedAt, target.due, target.reached, now)
+
+			var event model.EventType
+			switch {
+			case state == model.SLABreached && alerts&target.breached == 0:
+				event = model.EventSLABreached
+				alerts |= target.atRisk | target.breached
+			case state == model.SLAAtRisk && alerts&target.atRisk == 0:
+				event = model.EventSLAAtRisk
+				alerts |= target.atRisk
+			default:
+				continue
+			}
+
+			message := target.name + " SLA at risk, due " + target.due.UTC().Format(time.RFC3339)
+			if event == model.EventSLABreached {
+				message = target.name + " SLA breached, was due " + target.due.UTC().Format(time.RFC3339)
+			}
+
+			tx := db.Begin()
+			if err := tx.Create(&model.IncidentEvent{
+				IncidentID: incident.IncidentID,
+				Type:       event,
+				Message:    message,
+			}).Error; err != nil {
+				tx.Rollback()
+				log.WithError(err).Error("error code: 4410.2")
+				break
+			}
+			if err := tx.Model(&model.Incident{IncidentID: incident.IncidentID}).UpdateColumn("sla_alerts", alerts).Error; err != nil {
+				tx.Rollback()
+				log.WithError(err).Error("error code: 4410.3")
+				break
+			}
+			if err := tx.Commit().Error; err != nil {
+				log.WithError(err).Error("error code: 4410.5")
+				break
+			}
+			raised++
+
+			if _, err := notifySLAAlert(incident, event, message); err != nil {
+				log.WithError(err).Error("error code: 4410.4")
+			}
+		}
+	}
+
+	return raised, nil
+}
+
+// notifySLAAlert emails an SLA alert to the assignee of the incident
+func notifySLAAlert(incident model.Incident, event model.EventType, message string) (bool, error) {
+	if !config.IsSLAAlert() {
+		return false, nil
+	}
+
+	var auth model.Auth
+	if err := database.GetDB().First(&auth, incident.AssignedTo).Error; err != nil {
+		return false, err
+	}
+
+	email := auth.Email
+	if email == "" && auth.EmailCipher != "" {
+		var err error
+		if email, err = DecryptEmail(auth.EmailNonce, auth.EmailCipher); err != nil {
+			return false, err
+		}
+	}
+
+	return SendSLAAlert(email, incident, event, message)
+}
+
+// SendSLAAlert emails an SLA at risk or breach alert
+//
+// {true, nil} => email delivered successfully
+//
+// {false, nil} => SLA alerts not configured
+//
+// {false, error} => email delivery failed
+func SendSLAAlert(email string, incident model.Incident, event model.EventType, message string) (bool, error) {
+	appConfig := config.GetConfig()
+
+	if !config.IsSLAAlert() {
+		return false, nil
+	}
+
+	if appConfig.EmailConf.Provider != "postmark" {
+		return false, errors.New(
+			"email delivery service provider: '" + appConfig.EmailConf.Provider + "' is unknown",
+		)
+	}
+
+	htmlModel := lib.HTMLModel(lib.StrArrHTMLModel(appConfig.EmailConf.HTMLModel))
+	htmlModel["incident_id"] = incident.IncidentID
+	htmlModel["incident_title"] = incident.Title
+	htmlModel["incident_severity"] = string(incident.Severity)
+	htmlModel["incident_priority"] = string(incident.Priority)
+	htmlModel["sla_event"] = string(event)
+	htmlModel["sla_message"] = message
+
+	params := PostmarkParams{}
+	params.ServerToken = appConfig.EmailConf.APIToken
+	params.TemplateID = appConfig.EmailConf.SLAAlertTemplateID
+	params.From = appConfig.EmailConf.AddrFrom
+	params.To = email
+	params.Tag = appConfig.EmailConf.SLAAlertTag
+	params.TrackOpens = appConfig.EmailConf.TrackOpens
+	params.TrackLinks = appConfig.EmailConf.TrackLinks
+	params.MessageStream = appConfig.EmailConf.DeliveryType
+	params.HTMLModel = htmlModel
+
+	res, err := Postmark(params)
+	if err != nil {
+		return false, err
+	}
+
+	if res.Message != "OK" {
+		return false, errors.New("email delivery failed")
+	}
+
+	return true, nil
+}
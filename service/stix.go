@@ -0,0 +1,507 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Dhar01/incident_resp/config"
+	"github.com/Dhar01/incident_resp/internal/model"
+	"github.com/google/uuid"
+)
+
+// STIX 2.1 constants
+const (
+	StixSpecVersion = "2.1"
+	StixTimeFormat  = "2006-01-02T15:04:05.000Z"
+)
+
+// stixObservableNamespace - namespace of the deterministic IDs of
+// cyber-observables defined by the specification, the same observable
+// gets the same ID in every producer's bundles
+var stixObservableNamespace = uuid.MustParse("00abedb4-aa42-466c-9c01-fed23315a9b7")
+
+// stixDefaultNamespace - namespace of the IDs of exported objects when
+// STIX_NAMESPACE is not set. Servers sharing with the same partners
+// should each set their own.
+var stixDefaultNamespace = uuid.MustParse("a7c7d85e-e1f4-41c5-9e63-d0dc463aa8e2")
+
+// stixTLPIDs - IDs of the TLP marking definitions predefined by the
+// specification
+var stixTLPIDs = map[string]string{
+	model.TLPWhite: "marking-definition--613f2e26-407d-48c7-9eca-b8e91df99dc9",
+	model.TLPGreen: "marking-definition--34098fce-860f-48ae-8e50-ebd3cc5e41da",
+	model.TLPAmber: "marking-definition--f88d31f6-486f-44da-b317-01333bde0b82",
+	model.TLPRed:   "marking-definition--5e57c739-391a-4eb3-b6be-7d15ca92d5ed",
+}
+
+// stixObservableTypes - cyber-observable object types of the
+// specification, they do not require created and modified
+var stixObservableTypes = []string{
+	"artifact", "autonomous-system", "directory", "domain-name", "email-addr", "email-message",
+	"file", "ipv4-addr", "ipv6-addr", "mac-addr", "mutex", "network-traffic", "process",
+	"software", "url", "user-account", "windows-registry-key", "x509-certificate",
+}
+
+// stixHashLengths - hex digits of the hash algorithms of file
+// observables, keyed by lowercase algorithm name
+var stixHashLengths = map[string]int{
+	"md5": 32, "sha-1": 40, "sha1": 40, "sha-256": 64, "sha256": 64, "sha-512": 128, "sha512": 128,
+}
+
+var (
+	stixTypePattern      = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,248}[a-z0-9]$`)
+	stixTimestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z$`)
+)
+
+// StixID returns the identifier of an exported object, derived from the
+// server's namespace and the key of the record it is built from. Every
+// export of the record gives the object the same ID, so partners see a
+// new version of it rather than a new object.
+func StixID(objectType, key string) string {
+	namespace := stixDefaultNamespace
+	if configured := config.GetConfig().Server.StixNamespace; configured != "" {
+		namespace = uuid.MustParse(configured)
+	}
+	return objectType + "--" + uuid.NewSHA1(namespace, []byte(objectType+":"+key)).String()
+}
+
+// StixBundleID returns a new random bundle identifier, a bundle only
+// carries objects and is not kept by its recipients
+func StixBundleID() string {
+	return "bundle--" + uuid.NewString()
+}
+
+// StixTime formats a timestamp the way STIX requires
+func StixTime(t time.Time) string {
+	return t.UTC().Format(StixTimeFormat)
+}
+
+// StixTLPMarking returns the marking definition of a TLP level, TLP 2.0
+// clear is taken for white
+func StixTLPMarking(level string) (model.StixMarkingDefinition, bool) {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if level == "clear" {
+		level = model.TLPWhite
+	}
+	id, ok := stixTLPIDs[level]
+	if !ok {
+		return model.StixMarkingDefinition{}, false
+	}
+
+	return model.StixMarkingDefinition{
+		StixCommon: model.StixCommon{
+			Type:        "marking-definition",
+			SpecVersion: StixSpecVersion,
+			ID:          id,
+			Created:     "2017-01-20T00:00:00.000Z",
+		},
+		Name:           "TLP:" + strings.ToUpper(level),
+		DefinitionType: "tlp",
+		Definition:     map[string]string{"tlp": level},
+	}, true
+}
+
+// StixObservable returns the cyber-observable of an indicator of
+// compromise, its ID is derived from its value as the specification
+// requires
+func StixObservable(indicator model.Indicator) model.StixObservable {
+	observable := model.StixObservable{StixCommon: model.StixCommon{SpecVersion: StixSpecVersion}}
+
+	switch indicator.Type {
+	case model.IndicatorIP:
+		observable.Type = "ipv4-addr"
+		if strings.Contains(indicator.Value, ":") {
+			observable.Type = "ipv6-addr"
+		}
+		observable.Value = indicator.Value
+	case model.IndicatorDomain:
+		observable.Type = "domain-name"
+		observable.Value = indicator.Value
+	case model.IndicatorURL:
+		observable.Type = "url"
+		observable.Value = indicator.Value
+	case model.IndicatorEmail:
+		observable.Type = "email-addr"
+		observable.Value = indicator.Value
+	case model.IndicatorHash:
+		observable.Type = "file"
+		observable.Hashes = map[string]string{stixHashAlgorithm(indicator.Value): indicator.Value}
+	}
+
+	// the ID contributing properties, canonicalized
+	contributing := map[string]any{"value": observable.Value}
+	if observable.Hashes != nil {
+		contributing = map[string]any{"hashes": observable.Hashes}
+	}
+	var canonical bytes.Buffer
+	encoder := json.NewEncoder(&canonical)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(contributing)
+	observable.ID = observable.Type + "--" + uuid.NewSHA1(stixObservableNamespace, bytes.TrimSpace(canonical.Bytes())).String()
+
+	return observable
+}
+
+// StixPattern returns the STIX pattern which matches an indicator of
+// compromise
+func StixPattern(indicator model.Indicator) string {
+	observable := StixObservable(indicator)
+	value := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(indicator.Value)
+
+	if indicator.Type == model.IndicatorHash {
+		algorithm := stixHashAlgorithm(indicator.Value)
+		if strings.Contains(algorithm, "-") {
+			algorithm = "'" + algorithm + "'"
+		}
+		return "[file:hashes." + algorithm + " = '" + value + "']"
+	}
+	return "[" + observable.Type + ":value = '" + value + "']"
+}
+
+func stixHashAlgorithm(hash string) string {
+	switch len(hash) {
+	case 32:
+		return "MD5"
+	case 40:
+		return "SHA-1"
+	case 64:
+		return "SHA-256"
+	}
+	return "SHA-512"
+}
+
+// ParseStixBundle decodes a bundle and checks its envelope, the objects
+// are left raw to be checked by their type
+func ParseStixBundle(body io.Reader) (id string, objects []json.RawMessage, err error) {
+	var bundle struct {
+		Type    string            `json:"type"`
+		ID      string            `json:"id"`
+		Objects []json.RawMessage `json:"objects"`
+	}
+
+	if err = json.NewDecoder(body).Decode(&bundle); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return "", nil, err
+		}
+		return "", nil, errors.New("bundle is not valid JSON: " + err.Error())
+	}
+	if bundle.Type != "bundle" {
+		return "", nil, errors.New("type must be bundle")
+	}
+	if msg := stixIDMessage(bundle.ID, "bundle"); msg != "" {
+		return "", nil, errors.New(msg)
+	}
+	if len(bundle.Objects) == 0 {
+		return "", nil, errors.New("bundle has no objects")
+	}
+
+	return bundle.ID, bundle.Objects, nil
+}
+
+// ValidateStixObject checks the common properties of an object of a
+// bundle. msg explains which rule of the specification it breaks.
+func ValidateStixObject(raw json.RawMessage) (object model.StixCommon, msg string) {
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return object, "object is not valid: " + err.Error()
+	}
+
+	if !stixTypePattern.MatchString(object.Type) {
+		return object, "type must be 3 to 250 lowercase letters, digits and hyphens"
+	}
+	if msg = stixIDMessage(object.ID, object.Type); msg != "" {
+		return object, msg
+	}
+	for _, ref := range object.ObjectMarkingRefs {
+		if msg = stixIDMessage(ref, "marking-definition"); msg != "" {
+			return object, "object_marking_refs: " + msg
+		}
+	}
+
+	// cyber-observables only carry the ID and their own properties
+	if slices.Contains(stixObservableTypes, object.Type) {
+		if object.SpecVersion != "" && object.SpecVersion != StixSpecVersion {
+			return object, "spec_version must be " + StixSpecVersion
+		}
+		return object, ""
+	}
+
+	if object.SpecVersion != StixSpecVersion {
+		return object, "spec_version must be " + StixSpecVersion
+	}
+	created, ok := parseStixTime(object.Created)
+	if !ok {
+		return object, "created must be an RFC 3339 UTC timestamp"
+	}
+	// marking definitions are never modified
+	if object.Type == "marking-definition" {
+		return object, ""
+	}
+	modified, ok := parseStixTime(object.Modified)
+	if !ok {
+		return object, "modified must be an RFC 3339 UTC timestamp"
+	}
+	if modified.Before(created) {
+		return object, "modified must not be before created"
+	}
+
+	return object, ""
+}
+
+// ValidateStixIndicator checks the properties of an indicator object
+// on top of the common ones
+func ValidateStixIndicator(raw json.RawMessage) (indicator model.StixIndicator, msg string) {
+	if err := json.Unmarshal(raw, &indicator); err != nil {
+		return indicator, "indicator is not valid: " + err.Error()
+	}
+
+	if strings.TrimSpace(indicator.Pattern) == "" {
+		return indicator, "pattern is required"
+	}
+	if indicator.PatternType == "" {
+		return indicator, "pattern_type is required"
+	}
+	validFrom, ok := parseStixTime(indicator.ValidFrom)
+	if !ok {
+		return indicator, "valid_from must be an RFC 3339 UTC timestamp"
+	}
+	if indicator.ValidUntil != "" {
+		validUntil, ok := parseStixTime(indicator.ValidUntil)
+		if !ok {
+			return indicator, "valid_until must be an RFC 3339 UTC timestamp"
+		}
+		if !validUntil.After(validFrom) {
+			return indicator, "valid_until must be after valid_from"
+		}
+	}
+
+	return indicator, ""
+}
+
+// ValidateStixRelationship checks the properties of a relationship
+// object on top of the common ones
+func ValidateStixRelationship(raw json.RawMessage) string {
+	var relationship model.StixRelationship
+	if err := json.Unmarshal(raw, &relationship); err != nil {
+		return "relationship is not valid: " + err.Error()
+	}
+
+	if !stixTypePattern.MatchString(relationship.RelationshipType) {
+		return "relationship_type must be 3 to 250 lowercase letters, digits and hyphens"
+	}
+	if msg := stixIDMessage(relationship.SourceRef, ""); msg != "" {
+		return "source_ref: " + msg
+	}
+	if msg := stixIDMessage(relationship.TargetRef, ""); msg != "" {
+		return "target_ref: " + msg
+	}
+	return ""
+}
+
+// ParseStixPattern extracts the indicators of compromise of the
+// equality comparisons of a STIX pattern, [ipv4-addr:value = '1.2.3.4']
+// gives an IP. Other comparisons and object types are left out. msg
+// explains why the pattern is malformed.
+func ParseStixPattern(pattern string) (indicators []model.Indicator, msg string) {
+	tokens, msg := stixPatternTokens(pattern)
+	if msg != "" {
+		return nil, msg
+	}
+
+	indicators = []model.Indicator{}
+	inObservation := false
+	observations, parentheses := 0, 0
+	for i, token := range tokens {
+		switch {
+		case token.kind == stixPunctuation && token.text == "[":
+			if inObservation {
+				return nil, "observation expressions must not be nested"
+			}
+			inObservation = true
+			observations++
+		case token.kind == stixPunctuation && token.text == "]":
+			if !inObservation {
+				return nil, "unbalanced brackets"
+			}
+			inObservation = false
+		case token.kind == stixPunctuation && token.text == "(":
+			parentheses++
+		case token.kind == stixPunctuation && token.text == ")":
+			if parentheses--; parentheses < 0 {
+				return nil, "unbalanced parentheses"
+			}
+		case token.kind == stixPath:
+			if !inObservation {
+				return nil, "comparison '" + token.text + "' is outside of an observation expression"
+			}
+			// path = 'value', negated ones do not name the observable
+			if i+2 >= len(tokens) || tokens[i+1].text != "=" || tokens[i+2].kind != stixString {
+				continue
+			}
+			if indicator, ok := stixComparison(token.text, tokens[i+2].text); ok && !slices.Contains(indicators, indicator) {
+				indicators = append(indicators, indicator)
+			}
+		}
+	}
+	if inObservation {
+		return nil, "unbalanced brackets"
+	}
+	if parentheses != 0 {
+		return nil, "unbalanced parentheses"
+	}
+	if observations == 0 {
+		return nil, "pattern has no observation expression"
+	}
+
+	return indicators, ""
+}
+
+// stixComparison maps an equality comparison to an indicator of
+// compromise, ok is false for other observables and invalid values
+func stixComparison(path, value string) (model.Indicator, bool) {
+	objectType, property, _ := strings.Cut(path, ":")
+
+	var t model.IndicatorType
+	switch {
+	case (objectType == "ipv4-addr" || objectType == "ipv6-addr") && property == "value":
+		t = model.IndicatorIP
+	case objectType == "domain-name" && property == "value":
+		t = model.IndicatorDomain
+	case objectType == "url" && property == "value":
+		t = model.IndicatorURL
+	case objectType == "email-addr" && property == "value":
+		t = model.IndicatorEmail
+	case objectType == "file" && strings.HasPrefix(property, "hashes."):
+		algorithm := strings.ToLower(strings.Trim(strings.TrimPrefix(property, "hashes."), "'"))
+		if length, ok := stixHashLengths[algorithm]; !ok || length != len(value) {
+			return model.Indicator{}, false
+		}
+		t = model.IndicatorHash
+	default:
+		return model.Indicator{}, false
+	}
+
+	indicator, msg := ParseIndicator(t, value)
+	return indicator, msg == ""
+}
+
+type stixTokenKind int
+
+const (
+	stixPunctuation stixTokenKind = iota
+	stixString
+	stixPath
+	stixWord // operators, keywords, numbers and typed literals
+)
+
+type stixToken struct {
+	kind stixTokenKind
+	text string // unescaped for strings
+}
+
+// stixPatternTokens splits a pattern into tokens, string literals are
+// unescaped
+func stixPatternTokens(pattern string) (tokens []stixToken, msg string) {
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.IndexByte("[](),", c) >= 0:
+			tokens = append(tokens, stixToken{stixPunctuation, string(c)})
+			i++
+		case c == '\'':
+			value, n, ok := stixStringLiteral(pattern[i:])
+			if !ok {
+				return nil, "unterminated or badly escaped string"
+			}
+			tokens = append(tokens, stixToken{stixString, value})
+			i += n
+		case strings.IndexByte("tbh", c) >= 0 && i+1 < len(pattern) && pattern[i+1] == '\'':
+			// timestamp, binary and hex literals
+			_, n, ok := stixStringLiteral(pattern[i+1:])
+			if !ok {
+				return nil, "unterminated or badly escaped string"
+			}
+			tokens = append(tokens, stixToken{stixWord, pattern[i : i+1+n]})
+			i += 1 + n
+		case strings.IndexByte("=!<>", c) >= 0:
+			start := i
+			for i < len(pattern) && strings.IndexByte("=!<>", pattern[i]) >= 0 {
+				i++
+			}
+			tokens = append(tokens, stixToken{stixWord, pattern[start:i]})
+		default:
+			// words and object paths, dictionary keys of paths may be quoted
+			start := i
+			for i < len(pattern) && strings.IndexByte(" \t\n\r[](),=!<>", pattern[i]) < 0 {
+				if pattern[i] == '\'' {
+					end := strings.IndexByte(pattern[i+1:], '\'')
+					if end < 0 {
+						return nil, "unterminated quoted path key"
+					}
+					i += end + 1
+				}
+				i++
+			}
+			token := stixToken{stixWord, pattern[start:i]}
+			if strings.Contains(token.text, ":") {
+				token.kind = stixPath
+			}
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, ""
+}
+
+// stixStringLiteral reads the quoted literal at the start of s, only
+// \' and \\ are valid escapes. n is the length read.
+func stixStringLiteral(s string) (value string, n int, ok bool) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 >= len(s) || (s[i+1] != '\'' && s[i+1] != '\\') {
+				return "", 0, false
+			}
+			i++
+			b.WriteByte(s[i])
+		case '\'':
+			return b.String(), i + 1, true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", 0, false
+}
+
+// stixIDMessage checks an identifier of an object type, any type when
+// empty
+func stixIDMessage(id, objectType string) string {
+	prefix, suffix, ok := strings.Cut(id, "--")
+	if !ok || !stixTypePattern.MatchString(prefix) {
+		return "'" + id + "' is not a STIX identifier"
+	}
+	if objectType != "" && prefix != objectType {
+		return "'" + id + "' must start with " + objectType + "--"
+	}
+	if _, err := uuid.Parse(suffix); err != nil || len(suffix) != 36 {
+		return "'" + id + "' does not end with a UUID"
+	}
+	return ""
+}
+
+func parseStixTime(s string) (time.Time, bool) {
+	if !stixTimestampPattern.MatchString(s) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Dhar01/incident_resp/internal/model"
+)
+
+func TestParseStixPattern(t *testing.T) {
+	sha256 := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string // type:value
+		msg     string   // contained in the message of a malformed pattern
+	}{
+		{
+			name:    "ip",
+			pattern: "[ipv4-addr:value = '198.51.100.7']",
+			want:    []string{"ip:198.51.100.7"},
+		},
+		{
+			name:    "escaped quote and backslash",
+			pattern: `[url:value = 'https://example.com/it\'s\\here']`,
+			want:    []string{"url:https://example.com/it%27s%5Chere"}, // normalized after unescaping
+		},
+		{
+			name:    "invalid escape",
+			pattern: `[url:value = 'https://example.com/a\nb']`,
+			msg:     "badly escaped",
+		},
+		{
+			name:    "unterminated string",
+			pattern: "[domain-name:value = 'evil.example.com]",
+			msg:     "unterminated",
+		},
+		{
+			name:    "quoted hash key",
+			pattern: "[file:hashes.'SHA-256' = '" + sha256 + "']",
+			want:    []string{"hash:" + sha256},
+		},
+		{
+			name:    "hash of the wrong length",
+			pattern: "[file:hashes.'SHA-256' = 'abcd']",
+			want:    []string{},
+		},
+		{
+			name:    "unterminated hash key",
+			pattern: "[file:hashes.'SHA-256 = '" + sha256 + "']",
+			msg:     "unterminated",
+		},
+		{
+			name:    "several observations",
+			pattern: "([domain-name:value = 'evil.example.com'] OR [email-addr:value = 'x@evil.example.com']) AND [ipv4-addr:value = '198.51.100.7']",
+			want:    []string{"domain:evil.example.com", "email:x@evil.example.com", "ip:198.51.100.7"},
+		},
+		{
+			name:    "duplicates",
+			pattern: "[domain-name:value = 'evil.example.com'] OR [domain-name:value = 'evil.example.com']",
+			want:    []string{"domain:evil.example.com"},
+		},
+		{
+			name:    "not equal",
+			pattern: "[domain-name:value != 'good.example.com']",
+			want:    []string{},
+		},
+		{
+			name:    "negated",
+			pattern: "[domain-name:value NOT = 'good.example.com' AND ipv4-addr:value = '198.51.100.7']",
+			want:    []string{"ip:198.51.100.7"},
+		},
+		{
+			name:    "other object type",
+			pattern: "[process:name = 'evil.exe']",
+			want:    []string{},
+		},
+		{
+			name:    "timestamp and hex literals",
+			pattern: "[network-traffic:start > t'2026-01-01T00:00:00Z' AND artifact:payload_bin = h'ff00'] START t'2026-01-01T00:00:00Z' STOP t'2026-02-01T00:00:00Z'",
+			want:    []string{},
+		},
+		{
+			name:    "unterminated timestamp",
+			pattern: "[network-traffic:start > t'2026-01-01T00:00:00Z]",
+			msg:     "unterminated",
+		},
+		{
+			name:    "nested brackets",
+			pattern: "[[ipv4-addr:value = '198.51.100.7']]",
+			msg:     "nested",
+		},
+		{
+			name:    "unclosed bracket",
+			pattern: "[ipv4-addr:value = '198.51.100.7'",
+			msg:     "unbalanced brackets",
+		},
+		{
+			name:    "unopened bracket",
+			pattern: "ipv4-addr:value = '198.51.100.7']",
+			msg:     "outside of an observation",
+		},
+		{
+			name:    "stray closing bracket",
+			pattern: "[ipv4-addr:value = '198.51.100.7']]",
+			msg:     "unbalanced brackets",
+		},
+		{
+			name:    "nested parentheses",
+			pattern: "(([ipv4-addr:value = '198.51.100.7']))",
+			want:    []string{"ip:198.51.100.7"},
+		},
+		{
+			name:    "unclosed parenthesis",
+			pattern: "([ipv4-addr:value = '198.51.100.7']",
+			msg:     "unbalanced parentheses",
+		},
+		{
+			name:    "stray closing parenthesis",
+			pattern: "[ipv4-addr:value = '198.51.100.7'])",
+			msg:     "unbalanced parentheses",
+		},
+		{
+			name:    "no observation",
+			pattern: "",
+			msg:     "no observation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indicators, msg := ParseStixPattern(tt.pattern)
+			if tt.msg != "" {
+				if !strings.Contains(msg, tt.msg) {
+					t.Errorf("ParseStixPattern(%q) message = %q, want %q", tt.pattern, msg, tt.msg)
+				}
+				return
+			}
+			if msg != "" {
+				t.Fatalf("ParseStixPattern(%q) message = %q", tt.pattern, msg)
+			}
+
+			got := []string{}
+			for _, indicator := range indicators {
+				got = append(got, string(indicator.Type)+":"+indicator.Value)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseStixPattern(%q) = %v, want %v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStixPatternTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		want    []stixToken
+		msg     string
+	}{
+		{
+			name:    "comparison",
+			pattern: "[ipv4-addr:value = '198.51.100.7']",
+			want: []stixToken{
+				{stixPunctuation, "["},
+				{stixPath, "ipv4-addr:value"},
+				{stixWord, "="},
+				{stixString, "198.51.100.7"},
+				{stixPunctuation, "]"},
+			},
+		},
+		{
+			name:    "operators without spaces",
+			pattern: "[file:size>=10 AND file:name!='a']",
+			want: []stixToken{
+				{stixPunctuation, "["},
+				{stixPath, "file:size"},
+				{stixWord, ">="},
+				{stixWord, "10"},
+				{stixWord, "AND"},
+				{stixPath, "file:name"},
+				{stixWord, "!="},
+				{stixString, "a"},
+				{stixPunctuation, "]"},
+			},
+		},
+		{
+			name:    "quoted path key with spaces",
+			pattern: "file:hashes.'SHA 256' = 'a'",
+			want: []stixToken{
+				{stixPath, "file:hashes.'SHA 256'"},
+				{stixWord, "="},
+				{stixString, "a"},
+			},
+		},
+		{
+			name:    "escapes are unescaped",
+			pattern: `'it\'s' '\\'`,
+			want: []stixToken{
+				{stixString, "it's"},
+				{stixString, `\`},
+			},
+		},
+		{
+			name:    "typed literals",
+			pattern: "t'2026-01-01T00:00:00Z' h'ff' b'AA=='",
+			want: []stixToken{
+				{stixWord, "t'2026-01-01T00:00:00Z'"},
+				{stixWord, "h'ff'"},
+				{stixWord, "b'AA=='"},
+			},
+		},
+		{
+			name:    "word starting like a typed literal",
+			pattern: "to tail",
+			want: []stixToken{
+				{stixWord, "to"},
+				{stixWord, "tail"},
+			},
+		},
+		{
+			name:    "parentheses and commas",
+			pattern: "(a, b)",
+			want: []stixToken{
+				{stixPunctuation, "("},
+				{stixWord, "a"},
+				{stixPunctuation, ","},
+				{stixWord, "b"},
+				{stixPunctuation, ")"},
+			},
+		},
+		{
+			name:    "invalid escape",
+			pattern: `'a\tb'`,
+			msg:     "unterminated or badly escaped string",
+		},
+		{
+			name:    "unterminated typed literal",
+			pattern: "h'ff",
+			msg:     "unterminated or badly escaped string",
+		},
+		{
+			name:    "unterminated path key",
+			pattern: "file:hashes.'MD5",
+			msg:     "unterminated quoted path key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, msg := stixPatternTokens(tt.pattern)
+			if msg != tt.msg {
+				t.Fatalf("stixPatternTokens(%q) message = %q, want %q", tt.pattern, msg, tt.msg)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("stixPatternTokens(%q) = %v, want %v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStixStringLiteral(t *testing.T) {
+	tests := []struct {
+		s     string
+		value string
+		n     int
+		ok    bool
+	}{
+		{s: "'abc'", value: "abc", n: 5, ok: true},
+		{s: "'' = 'x'", value: "", n: 2, ok: true},
+		{s: `'it\'s' rest`, value: "it's", n: 7, ok: true},
+		{s: `'a\\b'`, value: `a\b`, n: 6, ok: true},
+		{s: `'a\\'`, value: `a\`, n: 5, ok: true},
+		{s: `'a\n'`},
+		{s: `'a\'`},
+		{s: `'a\`},
+		{s: "'abc"},
+		{s: "'"},
+	}
+
+	for _, tt := range tests {
+		value, n, ok := stixStringLiteral(tt.s)
+		if value != tt.value || n != tt.n || ok != tt.ok {
+			t.Errorf("stixStringLiteral(%q) = %q, %d, %t, want %q, %d, %t", tt.s, value, n, ok, tt.value, tt.n, tt.ok)
+		}
+	}
+}
+
+func TestStixPatternRoundTrip(t *testing.T) {
+	tests := []struct {
+		t     model.IndicatorType
+		value string
+	}{
+		{model.IndicatorIP, "198.51.100.7"},
+		{model.IndicatorIP, "2001:db8::1"},
+		{model.IndicatorDomain, "evil.example.com"},
+		{model.IndicatorURL, `https://evil.example.com/it's\here`},
+		{model.IndicatorEmail, "x@evil.example.com"},
+		{model.IndicatorHash, strings.Repeat("a", 32)},
+		{model.IndicatorHash, strings.Repeat("b", 40)},
+		{model.IndicatorHash, strings.Repeat("c", 64)},
+		{model.IndicatorHash, strings.Repeat("d", 128)},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.t)+" "+tt.value, func(t *testing.T) {
+			indicator, msg := ParseIndicator(tt.t, tt.value)
+			if msg != "" {
+				t.Fatal(msg)
+			}
+
+			pattern := StixPattern(indicator)
+			got, msg := ParseStixPattern(pattern)
+			if msg != "" {
+				t.Fatalf("ParseStixPattern(%q) message = %q", pattern, msg)
+			}
+			if len(got) != 1 || got[0] != indicator {
+				t.Errorf("ParseStixPattern(%q) = %v, want [%v]", pattern, got, indicator)
+			}
+		})
+	}
+}